@@ -0,0 +1,111 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// AccountStatus is the plan and activation status of the account.
+type AccountStatus struct {
+	PricingModel       string `json:"pricingModel"`
+	CanUpdatePlan      bool   `json:"canUpdatePlan"`
+	PlanType           string `json:"planType"`
+	PlanExpirationDays int    `json:"planExpirationDays"`
+	ApplicationUse     string `json:"applicationUse"`
+	AccountActivated   bool   `json:"accountActivated"`
+	TotalCredits       int64  `json:"totalCredits,omitempty"`
+}
+
+// AccountSubdomain is the account's custom subdomain, used in the URL users log in at.
+type AccountSubdomain struct {
+	Subdomain  string `json:"subdomain"`
+	URL        string `json:"url,omitempty"`
+	CreatedAt  string `json:"createdAt,omitempty"`
+	CreatedBy  string `json:"createdBy,omitempty"`
+	ModifiedAt string `json:"modifiedAt,omitempty"`
+	ModifiedBy string `json:"modifiedBy,omitempty"`
+}
+
+// UsageForecast is the forecast of the account's credit usage to the end of its plan,
+// from its average usage over the requested number of days.
+type UsageForecast struct {
+	AverageUsage              float64 `json:"averageUsage"`
+	UsagePercentage           float64 `json:"usagePercentage"`
+	ForecastedUsage           float64 `json:"forecastedUsage"`
+	ForecastedUsagePercentage float64 `json:"forecastedUsagePercentage"`
+	TotalCredits              float64 `json:"totalCredits"`
+}
+
+// ErrSubdomainNotFound is returned when the account has no custom subdomain.
+var ErrSubdomainNotFound = errors.New("Account subdomain not found")
+
+// GetAccountStatus gets the plan and activation status of the account.
+func (c *Client) GetAccountStatus(opts ...CallOption) (*AccountStatus, error) {
+	var as = new(AccountStatus)
+	if err := c.do(call{method: "GET", path: "account/status", out: as}, opts); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+// GetAccountOwner returns the ID of the user who owns the account.
+func (c *Client) GetAccountOwner(opts ...CallOption) (string, error) {
+	var id string
+	if err := c.do(call{method: "GET", path: "account/accountOwner", out: &id}, opts); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// GetUsageForecast forecasts the account's credit usage from its average usage over the
+// last numberOfDays days. A numberOfDays of 0 uses the API default.
+func (c *Client) GetUsageForecast(numberOfDays int, opts ...CallOption) (*UsageForecast, error) {
+	path := "account/usageForecast"
+	if numberOfDays > 0 {
+		path += "?" + url.Values{"numberOfDays": {strconv.Itoa(numberOfDays)}}.Encode()
+	}
+	var uf = new(UsageForecast)
+	if err := c.do(call{method: "GET", path: path, out: uf}, opts); err != nil {
+		return nil, err
+	}
+	return uf, nil
+}
+
+// GetSubdomain gets the account's custom subdomain.
+func (c *Client) GetSubdomain(opts ...CallOption) (*AccountSubdomain, error) {
+	return c.subdomainRequest("GET", nil, opts)
+}
+
+// CreateSubdomain sets a custom subdomain for an account that doesn't have one.
+func (c *Client) CreateSubdomain(subdomain string, opts ...CallOption) (*AccountSubdomain, error) {
+	return c.subdomainRequest("POST", &AccountSubdomain{Subdomain: subdomain}, opts)
+}
+
+// UpdateSubdomain changes the account's custom subdomain. Users have to log in at the
+// new URL afterwards.
+func (c *Client) UpdateSubdomain(subdomain string, opts ...CallOption) (*AccountSubdomain, error) {
+	return c.subdomainRequest("PUT", &AccountSubdomain{Subdomain: subdomain}, opts)
+}
+
+// DeleteSubdomain removes the account's custom subdomain.
+func (c *Client) DeleteSubdomain(opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: "account/subdomain", notFound: ErrSubdomainNotFound}, opts)
+}
+
+// subdomainRequest sends a request to the subdomain endpoint and decodes the subdomain
+// it returns.
+func (c *Client) subdomainRequest(method string, in *AccountSubdomain, opts []CallOption) (*AccountSubdomain, error) {
+	cl := call{method: method, path: "account/subdomain", notFound: ErrSubdomainNotFound}
+	if in != nil {
+		cl.in = in
+		cl.badRequest = fmt.Errorf("Bad Request. Please check if the subdomain `%s` is valid and not taken", in.Subdomain)
+	}
+	var as = new(AccountSubdomain)
+	cl.out = as
+	if err := c.do(cl, opts); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
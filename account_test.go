@@ -0,0 +1,77 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAccountStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/account/status" {
+			t.Errorf("Expected request to ‘/account/status’, got ‘%s’", r.URL.EscapedPath())
+		}
+		w.Write([]byte(`{"pricingModel": "credits", "canUpdatePlan": true, "planType": "Paid", "planExpirationDays": 120, "applicationUse": "Observability", "accountActivated": true}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	as, err := c.GetAccountStatus()
+	if err != nil {
+		t.Errorf("GetAccountStatus() returned an error: %s", err)
+		return
+	}
+	if as.PlanType != "Paid" || as.PlanExpirationDays != 120 || !as.AccountActivated {
+		t.Errorf("Unexpected account status %+v", as)
+	}
+}
+
+func TestGetUsageForecast(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("numberOfDays") != "30" {
+			t.Errorf("Expected numberOfDays 30, got ‘%s’", r.URL.Query().Get("numberOfDays"))
+		}
+		w.Write([]byte(`{"averageUsage": 120.5, "usagePercentage": 40, "forecastedUsage": 3600, "forecastedUsagePercentage": 90, "totalCredits": 4000}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	uf, err := c.GetUsageForecast(30)
+	if err != nil {
+		t.Errorf("GetUsageForecast() returned an error: %s", err)
+		return
+	}
+	if uf.ForecastedUsagePercentage != 90 {
+		t.Errorf("Expected a forecast of 90%%, got %v", uf.ForecastedUsagePercentage)
+	}
+}
+
+func TestSubdomain(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/account/subdomain" {
+			t.Errorf("Expected request to ‘/account/subdomain’, got ‘%s’", r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusNotFound)
+		case "POST":
+			w.Write([]byte(`{"subdomain": "acme", "url": "https://acme.sumologic.com"}`))
+		default:
+			t.Errorf("Unexpected ‘%s’ request", r.Method)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if _, err := c.GetSubdomain(); err != ErrSubdomainNotFound {
+		t.Errorf("Expected ErrSubdomainNotFound, got %v", err)
+	}
+	sd, err := c.CreateSubdomain("acme")
+	if err != nil {
+		t.Errorf("CreateSubdomain() returned an error: %s", err)
+		return
+	}
+	if sd.URL != "https://acme.sumologic.com" {
+		t.Errorf("Expected the subdomain URL, got ‘%s’", sd.URL)
+	}
+}
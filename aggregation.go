@@ -0,0 +1,71 @@
+package sumologic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// The helpers below aggregate messages that have already been fetched, for when the
+// search query itself can't be changed to do it on the server.
+
+// CountBy counts the messages by the value of a field. Messages without the field are
+// counted under the empty string.
+func CountBy(messages []*SearchJobResultMessage, field string) map[string]int {
+	counts := make(map[string]int)
+	for _, m := range messages {
+		counts[messageField(m, field)]++
+	}
+	return counts
+}
+
+// FieldCount is the number of messages with one value of a field.
+type FieldCount struct {
+	Value string
+	Count int
+}
+
+// TopN returns the n most common values of a field, most common first.
+// Ties are ordered by value so the result is stable.
+func TopN(messages []*SearchJobResultMessage, field string, n int) []FieldCount {
+	var top []FieldCount
+	for value, count := range CountBy(messages, field) {
+		top = append(top, FieldCount{Value: value, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Value < top[j].Value
+	})
+	if n >= 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// Percentile returns the p-th percentile (0 to 100) of a numeric field, interpolating
+// between the closest values. Messages where the field is missing or not a number are skipped.
+func Percentile(messages []*SearchJobResultMessage, field string, p float64) (float64, error) {
+	if p < 0 || p > 100 {
+		return 0, fmt.Errorf("percentile %v is outside 0 to 100", p)
+	}
+	var values []float64
+	for _, m := range messages {
+		v, err := strconv.ParseFloat(messageField(m, field), 64)
+		if err != nil || math.IsNaN(v) {
+			continue
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no numeric values for field %s", field)
+	}
+	sort.Float64s(values)
+
+	rank := p / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return values[lower] + (values[upper]-values[lower])*(rank-float64(lower)), nil
+}
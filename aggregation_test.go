@@ -0,0 +1,53 @@
+package sumologic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func aggregationMessages() []*SearchJobResultMessage {
+	var messages []*SearchJobResultMessage
+	for _, m := range []map[string]interface{}{
+		{"host": "a", "latency": "10"},
+		{"host": "b", "latency": "20"},
+		{"host": "a", "latency": "30"},
+		{"host": "c", "latency": 40.0},
+		{"host": "a", "latency": "n/a"},
+		{"latency": "50"},
+	} {
+		messages = append(messages, &SearchJobResultMessage{Map: m})
+	}
+	return messages
+}
+
+func TestCountBy(t *testing.T) {
+	counts := CountBy(aggregationMessages(), "host")
+	expected := map[string]int{"a": 3, "b": 1, "c": 1, "": 1}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected %v, got %v", expected, counts)
+	}
+}
+
+func TestTopN(t *testing.T) {
+	top := TopN(aggregationMessages(), "host", 3)
+	expected := []FieldCount{{"a", 3}, {"", 1}, {"b", 1}}
+	if !reflect.DeepEqual(top, expected) {
+		t.Errorf("Expected %v, got %v", expected, top)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	for p, expected := range map[float64]float64{0: 10, 50: 30, 90: 46, 100: 50} {
+		v, err := Percentile(aggregationMessages(), "latency", p)
+		if err != nil {
+			t.Errorf("Percentile(%v) returned error: %v", p, err)
+			continue
+		}
+		if v != expected {
+			t.Errorf("Expected percentile %v to be %v, got %v", p, expected, v)
+		}
+	}
+	if _, err := Percentile(aggregationMessages(), "host", 50); err == nil {
+		t.Errorf("Expected an error for a field without numeric values")
+	}
+}
@@ -0,0 +1,331 @@
+package sumologic
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Defaults of an AlertEnricher.
+const (
+	DefaultAlertContextWindow = 10 * time.Minute
+	DefaultAlertMessageLimit  = 100
+	DefaultAlertSearchTimeout = 20 * time.Second
+	DefaultAlertTimeField     = "TriggerTime"
+	DefaultAlertSecretHeader  = "X-Alert-Secret"
+)
+
+// alertEnrichmentField is the field of a forwarded alert holding its AlertEnrichment.
+const alertEnrichmentField = "enrichment"
+
+// maxAlertPayload is the largest webhook payload an AlertEnricher reads.
+const maxAlertPayload = 1 << 20
+
+// Alert is the JSON payload of an alert webhook. Its fields are whatever the connection's
+// payload template declares, e.g. {"Name": "{{Name}}", "TriggerTime": "{{TriggerTime}}"}.
+type Alert map[string]interface{}
+
+// AlertEnrichment is the result of the follow-up search of an alert, attached to the
+// forwarded alert as its enrichment field. When the search fails, Error says why and the
+// alert is forwarded without messages.
+type AlertEnrichment struct {
+	Query        string                   `json:"query,omitempty"`
+	From         string                   `json:"from,omitempty"`
+	To           string                   `json:"to,omitempty"`
+	MessageCount int                      `json:"messageCount"`
+	Messages     []map[string]interface{} `json:"messages"`
+	// Truncated is set when the search found more than the enricher's message limit.
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// AlertForwarder sends an enriched alert on, e.g. to an incident management tool.
+type AlertForwarder interface {
+	ForwardAlert(ctx context.Context, alert Alert) error
+}
+
+// AlertForwarderFunc adapts a function to an AlertForwarder.
+type AlertForwarderFunc func(ctx context.Context, alert Alert) error
+
+// ForwardAlert calls f.
+func (f AlertForwarderFunc) ForwardAlert(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+// WebhookForwarder posts enriched alerts as JSON to URL.
+type WebhookForwarder struct {
+	URL string
+	// Header is added to every request, e.g. for an Authorization header.
+	Header http.Header
+	// HTTPClient sends the requests, http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// ForwardAlert posts the alert, failing unless the response status is 2xx.
+func (f *WebhookForwarder) ForwardAlert(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", f.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range f.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	hc := f.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("forwarding alert to %s failed with status %d", f.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// AlertEnricher receives alert webhooks, runs a follow-up search for each alert, such as
+// the messages of the triggering host around the trigger time, and forwards the alert
+// with the messages attached. It's an http.Handler to register as the URL of a webhook
+// connection, whose payload must be a JSON object.
+//
+// The search is the named query of Queries, rendered with the alert's fields as its
+// parameters, e.g. _sourceHost={{.Host}}, over the window from Before the alert's
+// trigger time to After it. Text fields are substituted as quoted phrases, so the host
+// web-1 gives _sourceHost="web-1" and an alert can't change the rest of the query.
+type AlertEnricher struct {
+	Client    *Client
+	Queries   *QueryLibrary
+	QueryName string
+	// Secret authenticates the webhook calls, which must send it in SecretHeader, a custom
+	// header of the webhook connection. Alerts are refused while it's empty.
+	Secret string
+	// SecretHeader is the header holding the secret, DefaultAlertSecretHeader when empty.
+	SecretHeader string
+	// Forwarder receives the enriched alerts.
+	Forwarder AlertForwarder
+
+	// Before and After bound the search around the trigger time, DefaultAlertContextWindow
+	// when zero. The window never ends after the current time.
+	Before time.Duration
+	After  time.Duration
+	// TimeField is the alert field holding the trigger time, DefaultAlertTimeField when
+	// empty. It's read as epoch milliseconds, RFC 3339 or the MM/DD/YYYY HH:MM:SS TZ
+	// format of {{TriggerTime}}; the time the alert is received is used otherwise.
+	TimeField string
+	// MessageLimit is the most messages attached, DefaultAlertMessageLimit when zero.
+	MessageLimit int
+	// Timeout bounds the search, DefaultAlertSearchTimeout when zero. Webhook calls time
+	// out, so it should stay well below a minute.
+	Timeout time.Duration
+	// PollInterval is the delay between status checks of the search.
+	PollInterval time.Duration
+	// OnError is called when an alert can't be enriched or forwarded. Errors are logged
+	// with log.Printf when it's nil.
+	OnError func(alert Alert, err error)
+}
+
+// ServeHTTP enriches and forwards the alert posted to it. It responds 202 once the alert
+// is forwarded, enriched or not, 401 when the call doesn't carry the secret, and 502
+// when forwarding fails so the webhook is retried.
+func (e *AlertEnricher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "alert webhooks must be posted", http.StatusMethodNotAllowed)
+		return
+	}
+	if !e.authenticated(r) {
+		http.Error(w, "alert webhook secret is missing or wrong", http.StatusUnauthorized)
+		return
+	}
+	var alert Alert
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAlertPayload)).Decode(&alert); err != nil || alert == nil {
+		http.Error(w, "alert payload must be a JSON object", http.StatusBadRequest)
+		return
+	}
+
+	if err := e.EnrichAndForward(r.Context(), alert); err != nil {
+		http.Error(w, "forwarding alert failed", http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authenticated reports whether the request carries the enricher's secret.
+func (e *AlertEnricher) authenticated(r *http.Request) bool {
+	if e.Secret == "" {
+		return false
+	}
+	header := e.SecretHeader
+	if header == "" {
+		header = DefaultAlertSecretHeader
+	}
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get(header)), []byte(e.Secret)) == 1
+}
+
+// EnrichAndForward attaches the enrichment of the alert to it and forwards it.
+func (e *AlertEnricher) EnrichAndForward(ctx context.Context, alert Alert) error {
+	enrichment := e.Enrich(ctx, alert)
+	if enrichment.Error != "" {
+		e.onError(alert, fmt.Errorf("enriching alert: %s", enrichment.Error))
+	}
+	alert[alertEnrichmentField] = enrichment
+	if err := e.Forwarder.ForwardAlert(ctx, alert); err != nil {
+		e.onError(alert, err)
+		return err
+	}
+	return nil
+}
+
+// Enrich runs the follow-up search of the alert and returns its messages. Failures are
+// reported in the enrichment's Error rather than returned, so the alert still goes out.
+func (e *AlertEnricher) Enrich(ctx context.Context, alert Alert) *AlertEnrichment {
+	enrichment := &AlertEnrichment{Messages: []map[string]interface{}{}}
+	if err := e.search(ctx, alert, enrichment); err != nil {
+		enrichment.Error = err.Error()
+	}
+	return enrichment
+}
+
+func (e *AlertEnricher) search(ctx context.Context, alert Alert, enrichment *AlertEnrichment) error {
+	q, err := e.Queries.Get(e.QueryName)
+	if err != nil {
+		return err
+	}
+	tr := e.timeRange(alert)
+	ssr, err := q.StartSearchRequestInRange(alertQueryParams(alert), tr)
+	if err != nil {
+		return err
+	}
+	enrichment.Query, enrichment.From, enrichment.To = ssr.Query, ssr.From, ssr.To
+
+	ctx, cancel := context.WithTimeout(ctx, durationOrDefault(e.Timeout, DefaultAlertSearchTimeout))
+	defer cancel()
+	sj, err := e.Client.StartSearchJob(ssr, WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	// The search's context is done once it times out, so the job is deleted without it.
+	defer sj.Delete(WithContext(context.Background()), WithTimeout(searchJobDeleteTimeout))
+
+	pollInterval := e.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = searchJobPollInterval
+	}
+	status, err := sj.WaitForCompletion(ctx, pollInterval)
+	if err != nil {
+		return err
+	}
+	limit := e.MessageLimit
+	if limit <= 0 {
+		limit = DefaultAlertMessageLimit
+	}
+	enrichment.MessageCount = status.MessageCount
+	enrichment.Truncated = status.MessageCount > limit
+	if status.MessageCount == 0 {
+		return nil
+	}
+	if status.MessageCount < limit {
+		limit = status.MessageCount
+	}
+	result, err := sj.GetMessages(0, limit, WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	for _, m := range result.Messages {
+		enrichment.Messages = append(enrichment.Messages, m.Map)
+	}
+	return nil
+}
+
+// alertQueryParams returns the alert's fields as query parameters. Numbers, booleans and
+// empty fields are kept, so required and default still see them; other values are
+// quoted as search phrases.
+func alertQueryParams(alert Alert) TemplateParams {
+	params := make(TemplateParams, len(alert))
+	for k, v := range alert {
+		switch v := v.(type) {
+		case float64, bool, nil:
+			params[k] = v
+		case string:
+			if v == "" {
+				params[k] = v
+			} else {
+				params[k] = quoteSearchPhrase(v)
+			}
+		default:
+			b, _ := json.Marshal(v)
+			params[k] = quoteSearchPhrase(string(b))
+		}
+	}
+	return params
+}
+
+// timeRange returns the search window around the alert's trigger time.
+func (e *AlertEnricher) timeRange(alert Alert) TimeRange {
+	now := e.Client.ServerNow()
+	field := e.TimeField
+	if field == "" {
+		field = DefaultAlertTimeField
+	}
+	at, ok := parseAlertTime(alert[field])
+	if !ok || at.After(now) {
+		at = now
+	}
+	to := at.Add(durationOrDefault(e.After, DefaultAlertContextWindow))
+	if to.After(now) {
+		to = now
+	}
+	return TimeRange{From: at.Add(-durationOrDefault(e.Before, DefaultAlertContextWindow)), To: to}
+}
+
+// alertTimeLayouts are the formats of trigger times after epoch milliseconds.
+var alertTimeLayouts = []string{time.RFC3339, "01/02/2006 15:04:05 MST"}
+
+func parseAlertTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case float64:
+		return time.UnixMilli(int64(v)), true
+	case string:
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.UnixMilli(ms), true
+		}
+		for _, layout := range alertTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (e *AlertEnricher) onError(alert Alert, err error) {
+	if e.OnError != nil {
+		e.OnError(alert, err)
+		return
+	}
+	log.Printf("sumologic: alert %v: %s", alert["Name"], err)
+}
+
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
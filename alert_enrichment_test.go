@@ -0,0 +1,208 @@
+package sumologic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAlertEnricher(t *testing.T) {
+	var ssr StartSearchRequest
+	var deleted int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.EscapedPath()
+		switch {
+		case r.Method == "POST" && path == "/search/jobs":
+			json.NewDecoder(r.Body).Decode(&ssr)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+		case r.Method == "DELETE":
+			atomic.AddInt32(&deleted, 1)
+		case strings.HasSuffix(path, "/messages"):
+			if r.URL.Query().Get("limit") != "2" {
+				t.Errorf("Expected a limit of 2 messages, got ‘%s’", r.URL.Query().Get("limit"))
+			}
+			messages := []*SearchJobResultMessage{{Map: map[string]interface{}{"_raw": "timeout"}}, {Map: map[string]interface{}{"_raw": "retry"}}}
+			json.NewEncoder(w).Encode(SearchJobResult{Messages: messages})
+		default:
+			json.NewEncoder(w).Encode(SearchJobStatusResponse{State: "DONE GATHERING RESULTS", MessageCount: 5})
+		}
+	}))
+	defer ts.Close()
+
+	l := NewQueryLibrary()
+	l.Register(SavedQuery{Name: "host context", Query: "_sourceHost={{.Host}}", Parameters: []string{"Host"}})
+	c, _ := NewClient("accessToken", ts.URL)
+	var forwarded Alert
+	e := &AlertEnricher{
+		Client:       c,
+		Queries:      l,
+		QueryName:    "host context",
+		Secret:       "s3cret",
+		Before:       5 * time.Minute,
+		After:        time.Minute,
+		MessageLimit: 2,
+		PollInterval: time.Millisecond,
+		Forwarder: AlertForwarderFunc(func(ctx context.Context, alert Alert) error {
+			forwarded = alert
+			return nil
+		}),
+	}
+
+	payload := `{"Name": "Web errors", "Host": "web-1", "TriggerTime": "2026-10-01T12:00:00Z"}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", strings.NewReader(payload))
+	req.Header.Set(DefaultAlertSecretHeader, "s3cret")
+	e.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Errorf("Expected status 202, got %d", w.Code)
+		return
+	}
+	if atomic.LoadInt32(&deleted) != 1 {
+		t.Errorf("Expected the search job to be deleted, got %d deletes", deleted)
+	}
+	if ssr.Query != `_sourceHost="web-1"` || ssr.From != "2026-10-01T11:55:00Z" || ssr.To != "2026-10-01T12:01:00Z" {
+		t.Errorf("Expected the host context search around the trigger time, got %+v", ssr)
+	}
+	enrichment, ok := forwarded["enrichment"].(*AlertEnrichment)
+	if !ok {
+		t.Errorf("Expected the forwarded alert to be enriched, got %v", forwarded)
+		return
+	}
+	if forwarded["Name"] != "Web errors" || enrichment.Error != "" || len(enrichment.Messages) != 2 || !enrichment.Truncated || enrichment.MessageCount != 5 {
+		t.Errorf("Expected 2 of 5 messages attached, got %+v", enrichment)
+	}
+}
+
+func TestAlertEnricherForwardsFailedEnrichment(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&alert)
+		enrichment, _ := alert["enrichment"].(map[string]interface{})
+		if alert["Name"] != "Web errors" || !strings.Contains(enrichment["error"].(string), "missing parameters") {
+			t.Errorf("Expected the alert with the enrichment error, got %v", alert)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	l := NewQueryLibrary()
+	l.Register(SavedQuery{Name: "host context", Query: "_sourceHost={{.Host}}", Parameters: []string{"Host"}})
+	c, _ := NewClient("accessToken", ts.URL)
+	var errs []error
+	e := &AlertEnricher{
+		Client:    c,
+		Queries:   l,
+		QueryName: "host context",
+		Secret:    "s3cret",
+		Forwarder: &WebhookForwarder{URL: ts.URL},
+		OnError: func(alert Alert, err error) {
+			errs = append(errs, err)
+		},
+	}
+	if err := e.EnrichAndForward(context.Background(), Alert{"Name": "Web errors"}); err != nil {
+		t.Errorf("EnrichAndForward() returned an error: %s", err)
+	}
+	if len(errs) != 1 {
+		t.Errorf("Expected the enrichment error to be reported, got %v", errs)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/", bytes.NewReader([]byte("[1]")))
+	req.Header.Set(DefaultAlertSecretHeader, "s3cret")
+	e.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a payload that isn't an object, got %d", w.Code)
+	}
+}
+
+func TestAlertEnricherRequiresSecret(t *testing.T) {
+	forwarded := 0
+	e := &AlertEnricher{
+		SecretHeader: "Authorization",
+		Forwarder: AlertForwarderFunc(func(ctx context.Context, alert Alert) error {
+			forwarded++
+			return nil
+		}),
+	}
+	post := func(secret string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"Name": "Web errors"}`))
+		if secret != "" {
+			req.Header.Set("Authorization", secret)
+		}
+		e.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := post("anything"); code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 while no secret is set, got %d", code)
+	}
+	e.Secret = "s3cret"
+	for _, secret := range []string{"", "wrong"} {
+		if code := post(secret); code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401 for the secret %q, got %d", secret, code)
+		}
+	}
+	if forwarded != 0 {
+		t.Errorf("Expected unauthenticated alerts not to be forwarded, got %d", forwarded)
+	}
+}
+
+func TestAlertEnricherQuotesAlertFields(t *testing.T) {
+	var ssr StartSearchRequest
+	var deleted int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			json.NewDecoder(r.Body).Decode(&ssr)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+		case r.Method == "DELETE":
+			atomic.AddInt32(&deleted, 1)
+		default:
+			json.NewEncoder(w).Encode(SearchJobStatusResponse{State: "GATHERING RESULTS"})
+		}
+	}))
+	defer ts.Close()
+
+	l := NewQueryLibrary()
+	l.Register(SavedQuery{Name: "host context", Query: "_sourceHost={{.Host}} | where _size > {{.Size}}", Parameters: []string{"Host", "Size"}})
+	c, _ := NewClient("accessToken", ts.URL)
+	e := &AlertEnricher{
+		Client:       c,
+		Queries:      l,
+		QueryName:    "host context",
+		Timeout:      50 * time.Millisecond,
+		PollInterval: time.Millisecond,
+	}
+	enrichment := e.Enrich(context.Background(), Alert{"Host": `web-1" or _index=secrets or "`, "Size": float64(10)})
+	if ssr.Query != `_sourceHost="web-1\" or _index=secrets or \"" | where _size > 10` {
+		t.Errorf("Expected the host to be quoted as a phrase, got %s", ssr.Query)
+	}
+	if enrichment.Error == "" {
+		t.Errorf("Expected the search to time out")
+	}
+	if atomic.LoadInt32(&deleted) != 1 {
+		t.Errorf("Expected the timed out search job to be deleted, got %d deletes", deleted)
+	}
+}
+
+func TestParseAlertTime(t *testing.T) {
+	expected := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for _, v := range []interface{}{float64(expected.UnixMilli()), "1790856000000", "2026-10-01T12:00:00Z", "10/01/2026 12:00:00 UTC"} {
+		at, ok := parseAlertTime(v)
+		if !ok || !at.Equal(expected) {
+			t.Errorf("parseAlertTime(%v) = %s, %t", v, at, ok)
+		}
+	}
+	if _, ok := parseAlertTime("soon"); ok {
+		t.Errorf("Expected parseAlertTime to reject ‘soon’")
+	}
+}
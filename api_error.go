@@ -0,0 +1,80 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrRateLimited matches, with errors.Is, an *APIError for a call rejected because too
+// many requests were made, once its retries have run out.
+var ErrRateLimited = errors.New("Rate limited by Sumo Logic")
+
+// ErrInvalidQuery matches, with errors.Is, an *APIError for a search the API refused to run.
+var ErrInvalidQuery = errors.New("Invalid search query")
+
+// ErrJobNotFound matches, with errors.Is, an *APIError for a search job that doesn't
+// exist, e.g. because it was deleted or has expired.
+var ErrJobNotFound = errors.New("Search job not found")
+
+// APIError is returned for error responses without a more specific error, such as
+// ErrClientAuthenticationError or a resource's not found error. It holds what the API
+// said about the error.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+
+	// kind is the sentinel error the APIError matches, if any.
+	kind error
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("Unknown Response with Sumo Logic: `%d`", e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error matched by the APIError, if any.
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// newAPIError decodes an error response into an *APIError.
+func newAPIError(resp *http.Response, body []byte) error {
+	e := &APIError{StatusCode: resp.StatusCode}
+	var aeb apiErrorBody
+	if json.Unmarshal(body, &aeb) == nil {
+		e.RequestID, e.Code, e.Message = aeb.ID, aeb.Code, aeb.Message
+		if len(aeb.Errors) > 0 && e.Code == "" {
+			e.Code, e.Message = aeb.Errors[0].Code, aeb.Errors[0].Message
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		e.kind = ErrRateLimited
+	}
+	return e
+}
+
+// searchAPIError decodes an error response of the search job API, where a 400 means the
+// query is invalid and a 404 that the job doesn't exist.
+func searchAPIError(resp *http.Response, body []byte) error {
+	err := newAPIError(resp, body)
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		err.(*APIError).kind = ErrInvalidQuery
+	case http.StatusNotFound:
+		err.(*APIError).kind = ErrJobNotFound
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package sumologic
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/search/jobs":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status": 400, "id": "ABC", "code": "searchjob.invalid.query", "message": "Unexpected token"}`))
+		case "/partitions":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"id": "DEF", "errors": [{"code": "api.rate_limited", "message": "Slow down"}]}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL, WithoutRetries())
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, _, err = c.StartSearch(StartSearchRequest{Query: "error |"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("Expected an *APIError matching ErrInvalidQuery, got %v", err)
+		return
+	}
+	if apiErr.StatusCode != 400 || apiErr.Code != "searchjob.invalid.query" || apiErr.Message != "Unexpected token" || apiErr.RequestID != "ABC" {
+		t.Errorf("Unexpected APIError %+v", apiErr)
+	}
+
+	_, err = c.ListPartitions(0, "")
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected an *APIError matching ErrRateLimited, got %v", err)
+		return
+	}
+	if apiErr.Code != "api.rate_limited" || apiErr.Message != "Slow down" || apiErr.RequestID != "DEF" {
+		t.Errorf("Unexpected APIError %+v", apiErr)
+	}
+
+	err = c.DeleteHostedCollector(defaultCollector.ID)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 || errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected a plain *APIError for a 503, got %v", err)
+	}
+}
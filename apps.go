@@ -0,0 +1,126 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// App is an app of the Sumo Logic app catalog.
+type App struct {
+	AppDefinition AppDefinition `json:"appDefinition"`
+	AppManifest   AppManifest   `json:"appManifest"`
+}
+
+// AppDefinition identifies an app and the version of its content.
+type AppDefinition struct {
+	ContentID   string `json:"contentId"`
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	AppVersion  string `json:"appVersion"`
+	Preview     bool   `json:"preview,omitempty"`
+	ManifestURL string `json:"manifestUrl,omitempty"`
+	IconURL     string `json:"iconURL,omitempty"`
+}
+
+// AppManifest describes an app and the parameters its installation takes.
+type AppManifest struct {
+	Family                           string            `json:"family,omitempty"`
+	Description                      string            `json:"description"`
+	Categories                       []string          `json:"categories,omitempty"`
+	HoverText                        string            `json:"hoverText,omitempty"`
+	IconURL                          string            `json:"iconURL,omitempty"`
+	ScreenshotURLs                   []string          `json:"screenshotURLs,omitempty"`
+	HelpURL                          string            `json:"helpURL,omitempty"`
+	HelpDocIDMap                     map[string]string `json:"helpDocIdMap,omitempty"`
+	CommunityURL                     string            `json:"communityURL,omitempty"`
+	Requirements                     []string          `json:"requirements,omitempty"`
+	AccountTypes                     []string          `json:"accountTypes,omitempty"`
+	RequiresInstallationInstructions bool              `json:"requiresInstallationInstructions,omitempty"`
+	InstallationInstructions         string            `json:"installationInstructions,omitempty"`
+	Parameters                       []AppParameter    `json:"parameters,omitempty"`
+	Author                           string            `json:"author,omitempty"`
+	AuthorWebsite                    string            `json:"authorWebsite,omitempty"`
+}
+
+// AppParameter is a parameter of an app's installation, usually the data source its
+// queries run against, set through AppInstallRequest.DataSourceValues by ParameterID.
+type AppParameter struct {
+	ParameterType  string `json:"parameterType"`
+	ParameterID    string `json:"parameterId"`
+	DataSourceType string `json:"dataSourceType,omitempty"`
+	Label          string `json:"label"`
+	Description    string `json:"description"`
+	Example        string `json:"example,omitempty"`
+	Hidden         bool   `json:"hidden,omitempty"`
+}
+
+// AppInstallRequest installs an app's content into the folder with the ID
+// DestinationFolderID, under Name.
+type AppInstallRequest struct {
+	Name                string            `json:"name"`
+	Description         string            `json:"description"`
+	DestinationFolderID string            `json:"destinationFolderId"`
+	DataSourceValues    map[string]string `json:"dataSourceValues,omitempty"`
+}
+
+// ErrAppNotFound is returned when an app or an app install job doesn't exist.
+var ErrAppNotFound = errors.New("App not found")
+
+// ListApps returns the apps of the app catalog.
+func (c *Client) ListApps(opts ...CallOption) ([]App, error) {
+	var al struct {
+		Apps []App `json:"apps"`
+	}
+	if err := c.do(call{method: "GET", path: "apps", out: &al}, opts); err != nil {
+		return nil, err
+	}
+	return al.Apps, nil
+}
+
+// GetApp gets the app with the specified UUID.
+func (c *Client) GetApp(uuid string, opts ...CallOption) (*App, error) {
+	var app = new(App)
+	if err := c.do(call{method: "GET", path: fmt.Sprintf("apps/%s", uuid), out: app, notFound: ErrAppNotFound}, opts); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// StartAppInstall starts installing the app with the specified UUID and returns the ID
+// of the install job.
+func (c *Client) StartAppInstall(uuid string, air AppInstallRequest, opts ...CallOption) (string, error) {
+	var job = new(contentJob)
+	err := c.do(call{
+		method:     "POST",
+		path:       fmt.Sprintf("apps/%s/install", uuid),
+		in:         air,
+		out:        job,
+		notFound:   ErrAppNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the destination folder and data sources for app `%s`", air.Name),
+	}, opts)
+	if err != nil {
+		return "", err
+	}
+	return job.ID, nil
+}
+
+// GetAppInstallStatus gets the status of an app install job.
+func (c *Client) GetAppInstallStatus(jobID string, opts ...CallOption) (*ContentJobStatus, error) {
+	status, err := c.getContentJobStatus(fmt.Sprintf("apps/install/%s/status", jobID), opts...)
+	if err == ErrContentNotFound {
+		return nil, ErrAppNotFound
+	}
+	return status, err
+}
+
+// InstallApp installs the app with the specified UUID and waits for the install job to
+// finish. It returns a *ContentJobError if the job fails.
+func (c *Client) InstallApp(uuid string, air AppInstallRequest, opts ...CallOption) error {
+	jobID, err := c.StartAppInstall(uuid, air, opts...)
+	if err != nil {
+		return err
+	}
+	return c.waitForContentJob(jobID, func() (*ContentJobStatus, error) {
+		return c.GetAppInstallStatus(jobID, opts...)
+	}, opts)
+}
@@ -0,0 +1,78 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListApps(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/apps" {
+			t.Errorf("Expected request to ‘/apps’, got ‘%s’", r.URL.EscapedPath())
+		}
+		w.Write([]byte(`{"apps": [{"appDefinition": {"uuid": "a1", "name": "Nginx", "appVersion": "1.0"}, "appManifest": {"description": "Nginx logs", "parameters": [{"parameterId": "logsrc", "parameterType": "DATA_SOURCE"}]}}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	apps, err := c.ListApps()
+	if err != nil {
+		t.Errorf("ListApps() returned an error: %s", err)
+		return
+	}
+	if len(apps) != 1 || apps[0].AppDefinition.UUID != "a1" || apps[0].AppManifest.Parameters[0].ParameterID != "logsrc" {
+		t.Errorf("Expected the Nginx app, got %+v", apps)
+	}
+}
+
+func TestInstallApp(t *testing.T) {
+	contentJobPollInterval = time.Millisecond
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /apps/a1/install":
+			var air AppInstallRequest
+			json.NewDecoder(r.Body).Decode(&air)
+			if air.DestinationFolderID != "f1" || air.DataSourceValues["logsrc"] != "_sourceCategory=nginx" {
+				t.Errorf("Expected the install request, got %+v", air)
+			}
+			w.Write([]byte(`{"id": "job1"}`))
+		case "GET /apps/install/job1/status":
+			polls++
+			status := ContentJobStatus{Status: ContentJobStatusInProgress}
+			if polls == 2 {
+				status = ContentJobStatus{Status: ContentJobStatusFailed, Error: &ContentJobStatusError{Code: "apps:invalid_source", Message: "invalid data source"}}
+			}
+			json.NewEncoder(w).Encode(status)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	err := c.InstallApp("a1", AppInstallRequest{
+		Name:                "Nginx",
+		DestinationFolderID: "f1",
+		DataSourceValues:    map[string]string{"logsrc": "_sourceCategory=nginx"},
+	})
+	je, ok := err.(*ContentJobError)
+	if !ok || je.JobID != "job1" || je.Code != "apps:invalid_source" {
+		t.Errorf("Expected the install job's error, got %v", err)
+	}
+}
+
+func TestGetAppInstallStatusNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if _, err := c.GetAppInstallStatus("missing"); err != ErrAppNotFound {
+		t.Errorf("Expected ErrAppNotFound, got %v", err)
+	}
+}
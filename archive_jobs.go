@@ -0,0 +1,87 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ArchiveJob ingests the data stored by an archive source for a time range.
+type ArchiveJob struct {
+	ID                   string `json:"id,omitempty"`
+	Name                 string `json:"name"`
+	StartTime            string `json:"startTime"`
+	EndTime              string `json:"endTime"`
+	Status               string `json:"status,omitempty"`
+	TotalObjectsScanned  int64  `json:"totalObjectsScanned,omitempty"`
+	TotalObjectsIngested int64  `json:"totalObjectsIngested,omitempty"`
+	TotalBytesIngested   int64  `json:"totalBytesIngested,omitempty"`
+	CreatedAt            string `json:"createdAt,omitempty"`
+	CreatedBy            string `json:"createdBy,omitempty"`
+}
+
+// Statuses an archive job goes through.
+const (
+	ArchiveJobStatusPending   = "Pending"
+	ArchiveJobStatusScanning  = "Scanning"
+	ArchiveJobStatusIngesting = "Ingesting"
+	ArchiveJobStatusFailed    = "Failed"
+	ArchiveJobStatusSucceeded = "Succeeded"
+)
+
+// ArchiveJobList is one page of archive jobs. Next is the token for the following page
+// and is empty on the last page.
+type ArchiveJobList struct {
+	Data []ArchiveJob `json:"data"`
+	Next string       `json:"next,omitempty"`
+}
+
+// ErrArchiveJobNotFound is returned when an archive job or its source doesn't exist.
+var ErrArchiveJobNotFound = errors.New("Archive job not found")
+
+// CreateArchiveJob starts ingesting archived data for the archive source with the specified ID.
+// Start and end times are ISO 8601 timestamps.
+func (c *Client) CreateArchiveJob(sourceID int, job ArchiveJob, opts ...CallOption) (*ArchiveJob, error) {
+	var aj = new(ArchiveJob)
+	err := c.do(call{
+		method:     "POST",
+		path:       fmt.Sprintf("archive/%d/jobs", sourceID),
+		in:         job,
+		out:        aj,
+		create:     true,
+		notFound:   ErrArchiveJobNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the time range of archive job `%s`", job.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return aj, nil
+}
+
+// ListArchiveJobs returns one page of the jobs for the archive source with the specified ID.
+// A limit of 0 uses the API default.
+func (c *Client) ListArchiveJobs(sourceID int, limit int, token string, opts ...CallOption) (*ArchiveJobList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := fmt.Sprintf("archive/%d/jobs", sourceID)
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var ajl = new(ArchiveJobList)
+	if err := c.do(call{method: "GET", path: path, out: ajl, notFound: ErrArchiveJobNotFound}, opts); err != nil {
+		return nil, err
+	}
+	return ajl, nil
+}
+
+// DeleteArchiveJob deletes the archive job with the specified ID.
+func (c *Client) DeleteArchiveJob(sourceID int, id string, opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("archive/%d/jobs/%s", sourceID, id), notFound: ErrArchiveJobNotFound}, opts)
+}
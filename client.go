@@ -2,28 +2,490 @@
 package sumologic
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Client communicates with the Sumo Logic API.
 type Client struct {
 	AuthToken   string
 	EndpointURL *url.URL
+
+	// UserAgent, when set, is sent as the User-Agent of every request, e.g. to tell
+	// tools apart in audit logs.
+	UserAgent string
+
+	// HTTPClient makes the requests, http.DefaultClient when nil. Set it to use a proxy,
+	// custom TLS settings or transport timeouts. Its CheckRedirect is ignored; the client
+	// follows redirects itself so they keep the request's credentials and body.
+	HTTPClient *http.Client
+
+	// Redactor, when set, redacts search result messages before they're returned.
+	Redactor *Redactor
+	// SearchBackend, when set, runs the client's searches instead of the v1 Search Job API.
+	SearchBackend SearchBackend
+	// SearchQuota, when set, keeps the client's open search jobs within the concurrent
+	// search limit. See WithSearchQuota.
+	SearchQuota *SearchQuota
+	// SearchCoalescer, when set, shares search jobs among identical searches. See
+	// WithSearchCoalescer.
+	SearchCoalescer *SearchCoalescer
+
+	// ClockSkewThreshold is how far the local clock may drift from the API's before
+	// OnClockSkew is called, DefaultClockSkewThreshold when zero.
+	ClockSkewThreshold time.Duration
+	// OnClockSkew, when set, is called with the measured skew whenever a response shows
+	// the local clock is off by more than ClockSkewThreshold.
+	OnClockSkew func(skew time.Duration)
+
+	// OnEndpointRedirect, when set, is called when a permanent redirect to another
+	// deployment moves the client's endpoint. A warning is logged with the standard logger when it's nil.
+	OnEndpointRedirect func(from, to *url.URL)
+
+	// Idempotency, when set, records create calls so repeating one doesn't create a
+	// duplicate resource. See WithIdempotencyStore.
+	Idempotency IdempotencyStore
+
+	// RetryPolicy retries calls that fail with a transient error; calls aren't retried
+	// when it's nil. NewClient sets it to DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+	// EndpointRetryPolicies override RetryPolicy for groups of endpoints, keyed by the
+	// first segment of the API path after the version, e.g. "search" or "collectors".
+	// A nil policy turns off retries for the group.
+	EndpointRetryPolicies map[string]*RetryPolicy
+
+	// Journal, when set, records every mutating call, labelled with JournalActor.
+	Journal      JournalWriter
+	JournalActor string
+
+	// ReadOnly makes the client refuse calls that could modify the account.
+	ReadOnly bool
+
+	// LatencyBudgets set how long requests to groups of endpoints should take, keyed like
+	// EndpointRetryPolicies. OnLatencyBudgetExceeded, when set, is called for each request
+	// over budget once a group has exceeded it Consecutive times in a row.
+	LatencyBudgets          map[string]LatencyBudget
+	OnLatencyBudgetExceeded func(group string, latency time.Duration, consecutive int)
+
+	// MaxResponseBytes limits the size of GET responses; larger responses fail with a
+	// *ResponseTooLargeError instead of being read into memory. Zero means no limit.
+	MaxResponseBytes int64
+
+	// ProfileLabels sets pprof labels around fetching and decoding responses. See
+	// WithProfileLabels.
+	ProfileLabels bool
+
+	mu        sync.Mutex
+	clockSkew time.Duration
+	latency   map[string]*latencyState
+	limiter   *rateLimiter
+	// searchSessions are the session cookies of the open search jobs, by job ID.
+	searchSessions map[string]*searchSession
+
+	nameResolvers map[string]interface{}
+
+	// optionErr is the first error of the options passed to New.
+	optionErr error
 }
 
 // ErrClientAuthenticationError is returned for authentication errors with the API.
 var ErrClientAuthenticationError = errors.New("Authentication Error with Sumo Logic")
 
-// NewClient returns a new sumologic.Client for accessing the Sumo Logic API.
-func NewClient(authToken, defaultEndpointURL string) (*Client, error) {
+// ClientOption configures a Client created by New or NewClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient makes the client send its requests with hc.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithoutRetries turns off the client's retries, for callers that retry themselves.
+func WithoutRetries() ClientOption {
+	return func(c *Client) {
+		c.RetryPolicy = nil
+	}
+}
+
+// WithRetry makes the client retry calls that fail with a transient error by policy
+// instead of DefaultRetryPolicy.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.RetryPolicy = &policy
+	}
+}
+
+// WithEndpoint sets the API endpoint URL of the account's deployment, e.g.
+// https://api.us2.sumologic.com/api/v1/.
+func WithEndpoint(endpointURL string) ClientOption {
+	return func(c *Client) {
+		u, err := url.Parse(endpointURL)
+		if err != nil {
+			c.setOptionErr(err)
+			return
+		}
+		c.EndpointURL = u
+	}
+}
+
+// WithAuthToken sets the client's credentials to the base64 encoding of
+// <accessId>:<accessKey>.
+func WithAuthToken(authToken string) ClientOption {
+	return func(c *Client) {
+		c.AuthToken = authToken
+	}
+}
+
+// WithAccessKey sets the client's credentials to an access ID and key.
+func WithAccessKey(accessID, accessKey string) ClientOption {
+	return func(c *Client) {
+		c.AuthToken = base64.StdEncoding.EncodeToString([]byte(accessID + ":" + accessKey))
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with the client's requests.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+func (c *Client) setOptionErr(err error) {
+	if c.optionErr == nil {
+		c.optionErr = err
+	}
+}
+
+// New returns a new sumologic.Client for accessing the Sumo Logic API, configured by
+// opts. WithEndpoint is required, along with credentials from WithAccessKey or
+// WithAuthToken:
+//
+//	client, err := sumologic.New(
+//		sumologic.WithEndpoint("https://api.us2.sumologic.com/api/v1/"),
+//		sumologic.WithAccessKey(accessID, accessKey),
+//		sumologic.WithUserAgent("provisioner/1.2"),
+//	)
+//
+// Its requests are limited to SearchJobRateLimit per minute unless WithRateLimit says otherwise.
+func New(opts ...ClientOption) (*Client, error) {
+	retryPolicy := DefaultRetryPolicy
 	s := &Client{
-		AuthToken: authToken,
+		RetryPolicy: &retryPolicy,
+		limiter:     newRateLimiter(SearchJobRateLimit, DefaultRateLimitBurst),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.optionErr != nil {
+		return nil, s.optionErr
+	}
+	if s.EndpointURL == nil {
+		return nil, errors.New("Sumo Logic endpoint URL is required")
+	}
+	return s, nil
+}
+
+// NewClient returns a new sumologic.Client for accessing the Sumo Logic API with the
+// auth token, the base64 encoding of <accessId>:<accessKey>, at the endpoint URL. It's
+// New with WithAuthToken and WithEndpoint.
+func NewClient(authToken, defaultEndpointURL string, opts ...ClientOption) (*Client, error) {
+	return New(append([]ClientOption{WithAuthToken(authToken), WithEndpoint(defaultEndpointURL)}, opts...)...)
+}
+
+// NewClientWithCredentials returns a new sumologic.Client for accessing the Sumo Logic
+// API with an access ID and key at the endpoint URL, building the auth token from them.
+func NewClientWithCredentials(accessID, accessKey, endpointURL string, opts ...ClientOption) (*Client, error) {
+	return New(append([]ClientOption{WithAccessKey(accessID, accessKey), WithEndpoint(endpointURL)}, opts...)...)
+}
+
+// Do calls an endpoint the client doesn't wrap yet with the client's credentials,
+// retries, rate limiting and error handling. path is relative to the endpoint URL, so v2
+// endpoints start with "../v2/". reqBody, when not nil, is sent as JSON, and a 2xx
+// response body is decoded into respOut when it's not nil. Other responses return
+// ErrClientAuthenticationError for a 401, a *ValidationError for a 400 that lists
+// errors, and an *APIError otherwise.
+func (c *Client) Do(ctx context.Context, method, path string, reqBody, respOut interface{}, opts ...CallOption) error {
+	return c.do(call{method: method, path: path, in: reqBody, out: respOut}, append([]CallOption{WithContext(ctx)}, opts...))
+}
+
+// call is an API call made by do.
+type call struct {
+	method string
+	// path is relative to the endpoint URL.
+	path string
+	// in, when not nil, is sent as the JSON request body, and a 2xx response body is
+	// decoded into out when it's not nil.
+	in  interface{}
+	out interface{}
+	// create sends the call through the client's idempotency store.
+	create bool
+	// notFound is returned for a 404 response, an *APIError when nil.
+	notFound error
+	// badRequest is returned for a 400 response that doesn't list validation errors, an
+	// *APIError when nil.
+	badRequest error
+	// modified is returned for a 412 response, an *APIError when nil.
+	modified error
+	// header, when not nil, is set to the headers of a 2xx response.
+	header *http.Header
+}
+
+// do makes an API call and maps its response to an error the way every endpoint does:
+// ErrClientAuthenticationError for a 401, the call's notFound for a 404, a
+// *ValidationError or the call's badRequest for a 400, the call's modified for a 412,
+// and an *APIError otherwise.
+func (c *Client) do(cl call, opts []CallOption) error {
+	req, err := c.newRequest(cl.method, cl.path, cl.in, opts...)
+	if err != nil {
+		return err
+	}
+	var resp *http.Response
+	var body []byte
+	if cl.create {
+		resp, body, err = c.sendCreate(req)
+	} else {
+		resp, body, err = c.send(req)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		if cl.header != nil {
+			*cl.header = resp.Header
+		}
+		if cl.out == nil || len(body) == 0 {
+			return nil
+		}
+		return c.decodeJSON(req, body, cl.out)
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrClientAuthenticationError
+	case resp.StatusCode == http.StatusNotFound && cl.notFound != nil:
+		return cl.notFound
+	case resp.StatusCode == http.StatusBadRequest:
+		if cl.badRequest != nil {
+			return validationError(body, cl.badRequest)
+		}
+		return validationError(body, newAPIError(resp, body))
+	case resp.StatusCode == http.StatusPreconditionFailed && cl.modified != nil:
+		return cl.modified
+	default:
+		return newAPIError(resp, body)
 	}
-	endpointURL, err := url.Parse(defaultEndpointURL)
+}
+
+// newRequest builds an authenticated request for a path relative to the endpoint URL.
+// When in is not nil it is encoded as the JSON request body.
+func (c *Client) newRequest(method, path string, in interface{}, opts ...CallOption) (*http.Request, error) {
+	relativeURL, err := url.Parse(path)
 	if err != nil {
 		return nil, err
 	}
-	s.EndpointURL = endpointURL
-	return s, nil
+	u := c.endpoint().ResolveReference(relativeURL)
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	req.Header.Add("Authorization", "Basic "+c.AuthToken)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	return withCallOptions(req, opts), nil
+}
+
+// send performs the request and returns the response along with its body, recording
+// mutating calls in the client's journal when it has one. A call that can't be
+// journaled returns a *JournalWriteError, even if it succeeded. Read-only clients
+// refuse mutating calls here, so no request reaches the API without being checked.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	if err := c.checkReadOnly(req); err != nil {
+		return nil, nil, err
+	}
+	if err := c.checkShed(req); err != nil {
+		return nil, nil, err
+	}
+	var resp *http.Response
+	var body []byte
+	var err error
+	c.profile(req, "fetch", func(ctx context.Context) {
+		resp, body, err = c.sendRetrying(req.WithContext(ctx))
+	})
+	if c.Journal != nil && isMutating(req.Method) && !isQuery(req) {
+		if jerr := c.journal(req, resp, err); jerr != nil && err == nil {
+			return resp, body, jerr
+		}
+	}
+	return resp, body, err
+}
+
+// sendRetrying performs the request, retrying it as the client's retry policy allows.
+// The call options of the request set its timeout and may override the client's retry
+// policy. Requests wait for the
+// client's rate limit, and a 429 response with a Retry-After header holds back every
+// request for that long before the call is retried.
+func (c *Client) sendRetrying(req *http.Request) (*http.Response, []byte, error) {
+	o := requestCallOptions(req)
+	if o != nil && o.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), o.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	policy := c.RetryPolicy
+	if p, ok := c.EndpointRetryPolicies[endpointGroup(req.URL.Path)]; ok {
+		policy = p
+	}
+	if o != nil && o.retry != nil {
+		policy = o.retry
+	}
+	attempts := 1
+	retryable := RetryTransient
+	if policy != nil {
+		if policy.MaxAttempts > 1 {
+			attempts = policy.MaxAttempts
+		}
+		if policy.Retryable != nil {
+			retryable = policy.Retryable
+		}
+	}
+
+	throttled := 0
+	failures := make(map[failureClass]int)
+	for attempt := 1; ; attempt++ {
+		if o != nil && o.limiter != nil {
+			if err := o.limiter.Wait(req.Context()); err != nil {
+				return nil, nil, err
+			}
+		}
+		if c.limiter != nil {
+			if err := c.limiter.Wait(req.Context()); err != nil {
+				return nil, nil, err
+			}
+		}
+		resp, body, err := c.sendFollowingRedirects(req)
+
+		var wait time.Duration
+		if d, ok := retryAfter(resp); ok && throttled < maxThrottledRetries {
+			// Being told when to retry doesn't use up an attempt of the retry policy.
+			throttled++
+			attempt--
+			wait = d
+			if c.limiter != nil {
+				c.limiter.Pause(d)
+				wait = 0
+			}
+		} else {
+			if err != nil && req.Context().Err() != nil {
+				// The transport's error for an abandoned request doesn't always wrap the
+				// context's, which callers check for.
+				return nil, nil, req.Context().Err()
+			}
+			if !retryable(req, resp, err) || attempt >= attempts || req.Context().Err() != nil {
+				return resp, body, err
+			}
+			class := classifyFailure(resp, err)
+			failures[class]++
+			wait = policy.wait(class, failures[class])
+		}
+		if req.GetBody != nil {
+			b, err := req.GetBody()
+			if err != nil {
+				return nil, nil, err
+			}
+			req.Body = b
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// endpointGroup returns the first segment of an API path after the version,
+// e.g. "search" for /api/v1/search/jobs.
+func endpointGroup(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for len(segments) > 1 && (segments[0] == "api" || isAPIVersion(segments[0])) {
+		segments = segments[1:]
+	}
+	return segments[0]
+}
+
+func isAPIVersion(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+	for _, r := range segment[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Client) sendOnce(req *http.Request) (*http.Response, []byte, error) {
+	start := time.Now()
+	hc := http.Client{}
+	if c.HTTPClient != nil {
+		hc = *c.HTTPClient
+	}
+	// Redirects are followed by sendFollowingRedirects.
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := hc.Do(req)
+	c.observeLatency(req, time.Since(start))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	c.observeClock(start, time.Now(), resp)
+
+	limit := c.MaxResponseBytes
+	if o := requestCallOptions(req); o != nil && o.maxResponseBytes != 0 {
+		limit = o.maxResponseBytes
+	}
+	if req.Method != "GET" || limit <= 0 {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp, body, nil
+	}
+
+	if resp.ContentLength > limit {
+		return nil, nil, newResponseTooLargeError(req, limit)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, nil, newResponseTooLargeError(req, limit)
+	}
+	return resp, body, nil
 }
@@ -0,0 +1,180 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	transport := new(countingTransport)
+	c, err := NewClient("accessToken", ts.URL, WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.DeleteHostedCollector(defaultCollector.ID); err != nil {
+			t.Errorf("DeleteHostedCollector() returned an error: %s", err)
+			return
+		}
+	}
+	if transport.requests != 2 {
+		t.Errorf("Expected both requests to use the configured client, got %d", transport.requests)
+	}
+}
+
+func TestNew(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Basic c3VBQkNERUY6c2VjcmV0" {
+			t.Errorf("Expected the access ID and key as basic auth, got ‘%s’", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("User-Agent") != "provisioner/1.2" {
+			t.Errorf("Expected the configured User-Agent, got ‘%s’", r.Header.Get("User-Agent"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := New(WithEndpoint(ts.URL), WithAccessKey("suABCDEF", "secret"), WithUserAgent("provisioner/1.2"), WithRetry(RetryPolicy{MaxAttempts: 1}))
+	if err != nil {
+		t.Errorf("New() returned an error: %s", err)
+		return
+	}
+	if c.RetryPolicy.MaxAttempts != 1 {
+		t.Errorf("Expected the configured retry policy, got %+v", c.RetryPolicy)
+	}
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err != nil {
+		t.Errorf("DeleteHostedCollector() returned an error: %s", err)
+	}
+
+	if _, err := New(WithAccessKey("suABCDEF", "secret")); err == nil {
+		t.Errorf("Expected New() without an endpoint to fail")
+	}
+	if _, err := New(WithEndpoint("://bad")); err == nil {
+		t.Errorf("Expected New() with an invalid endpoint to fail")
+	}
+}
+
+func TestNewClientWithCredentials(t *testing.T) {
+	c, err := NewClientWithCredentials("suABCDEF", "secret", "https://api.us2.sumologic.com/api/v1/")
+	if err != nil {
+		t.Errorf("NewClientWithCredentials() returned an error: %s", err)
+		return
+	}
+	if c.AuthToken != "c3VBQkNERUY6c2VjcmV0" {
+		t.Errorf("Expected the base64 of the access ID and key, got ‘%s’", c.AuthToken)
+	}
+}
+
+func TestClientDo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/v2/fields":
+			if r.Method != "POST" {
+				t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+			}
+			if r.Header.Get("Authorization") != "Basic accessToken" {
+				t.Errorf("Expected the client's credentials, got ‘%s’", r.Header.Get("Authorization"))
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			var in map[string]string
+			json.Unmarshal(body, &in)
+			json.NewEncoder(w).Encode(map[string]string{"fieldId": "f1", "fieldName": in["fieldName"]})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"id": "req1", "errors": [{"code": "forbidden", "message": "nope"}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	var out struct {
+		FieldID   string `json:"fieldId"`
+		FieldName string `json:"fieldName"`
+	}
+	if err := c.Do(context.Background(), "POST", "../v2/fields", map[string]string{"fieldName": "service"}, &out); err != nil {
+		t.Errorf("Do() returned an error: %s", err)
+		return
+	}
+	if out.FieldID != "f1" || out.FieldName != "service" {
+		t.Errorf("Expected the response to be decoded, got %+v", out)
+	}
+
+	err := c.Do(context.Background(), "GET", "../v2/secret", nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || apiErr.Code != "forbidden" {
+		t.Errorf("Expected an *APIError for the 403, got %v", err)
+	}
+}
+
+func TestClientDoErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/invalid":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"id": "req1", "errors": [{"code": "field:invalid", "message": "bad name", "meta": {"field": "name"}}]}`))
+		case "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/malformed":
+			w.Write([]byte(`{"id": `))
+		case "/null":
+			w.Write([]byte(`null`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`not json`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	errNotFound := errors.New("thing not found")
+	errBadRequest := errors.New("bad thing")
+
+	if err := c.do(call{method: "GET", path: "missing", notFound: errNotFound}, nil); err != errNotFound {
+		t.Errorf("Expected the call's notFound for a 404, got %v", err)
+	}
+	var apiErr *APIError
+	if err := c.do(call{method: "GET", path: "missing"}, nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected an *APIError for a 404 without notFound, got %v", err)
+	}
+	var ve *ValidationError
+	if err := c.do(call{method: "POST", path: "invalid", badRequest: errBadRequest}, nil); !errors.As(err, &ve) || ve.Errors[0].FieldPath != "name" {
+		t.Errorf("Expected a *ValidationError for a 400 listing errors, got %v", err)
+	}
+	if err := c.do(call{method: "POST", path: "other", badRequest: errBadRequest}, nil); err != errBadRequest {
+		t.Errorf("Expected the call's badRequest for a 400 without errors, got %v", err)
+	}
+	if err := c.do(call{method: "GET", path: "unauthorized"}, nil); err != ErrClientAuthenticationError {
+		t.Errorf("Expected ErrClientAuthenticationError for a 401, got %v", err)
+	}
+	var out struct{ ID string }
+	var decodeErr *DecodeError
+	if err := c.do(call{method: "GET", path: "malformed", out: &out}, nil); !errors.As(err, &decodeErr) || decodeErr.Path != "/malformed" {
+		t.Errorf("Expected a *DecodeError for a malformed response, got %v", err)
+	}
+	if err := c.do(call{method: "GET", path: "null", out: &out}, nil); !errors.As(err, &decodeErr) || decodeErr.Path != "/null" {
+		t.Errorf("Expected a *DecodeError for a null response, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package sumologic
+
+import (
+	"net/http"
+	"time"
+)
+
+// DefaultClockSkewThreshold is the clock skew tolerated before OnClockSkew is called.
+// Relative time ranges built from a clock that's ahead or behind silently miss recent
+// messages or search the future.
+const DefaultClockSkewThreshold = 30 * time.Second
+
+// ClockSkew returns how far the API's clock is ahead of the local clock, as measured from the
+// Date header of the latest response. It's negative when the local clock is ahead.
+func (c *Client) ClockSkew() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.clockSkew
+}
+
+// ServerNow returns the current time corrected for the measured clock skew.
+func (c *Client) ServerNow() time.Time {
+	return time.Now().Add(c.ClockSkew())
+}
+
+// RelativeTimeRange returns From and To values for a StartSearchRequest covering the last
+// window of time, measured on the API's clock so skew doesn't drop recent messages.
+// The values are in UTC.
+func (c *Client) RelativeTimeRange(window time.Duration) (from, to string) {
+	now := c.ServerNow().UTC()
+	return now.Add(-window).Format("2006-01-02T15:04:05"), now.Format("2006-01-02T15:04:05")
+}
+
+// observeClock measures the clock skew from a response's Date header, comparing it with the
+// midpoint of the request to discount network latency.
+func (c *Client) observeClock(start, end time.Time, resp *http.Response) {
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	local := start.Add(end.Sub(start) / 2)
+	skew := date.Sub(local)
+	// The Date header has a resolution of one second.
+	if skew > -time.Second && skew < time.Second {
+		skew = 0
+	}
+	c.mu.Lock()
+	c.clockSkew = skew
+	c.mu.Unlock()
+
+	threshold := c.ClockSkewThreshold
+	if threshold <= 0 {
+		threshold = DefaultClockSkewThreshold
+	}
+	if c.OnClockSkew != nil && (skew > threshold || skew < -threshold) {
+		c.OnClockSkew(skew)
+	}
+}
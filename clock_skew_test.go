@@ -0,0 +1,62 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClockSkewDetected(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-5*time.Minute).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	var warned time.Duration
+	c.OnClockSkew = func(skew time.Duration) {
+		warned = skew
+	}
+
+	c.DeleteHostedCollector(defaultCollector.ID)
+	if warned > -4*time.Minute || warned < -6*time.Minute {
+		t.Errorf("Expected a skew of about -5m, got %s", warned)
+	}
+	if c.ClockSkew() != warned {
+		t.Errorf("ClockSkew() expected %s, got %s", warned, c.ClockSkew())
+	}
+
+	_, to := c.RelativeTimeRange(15 * time.Minute)
+	expected := time.Now().Add(-5 * time.Minute).UTC()
+	parsed, err := time.Parse("2006-01-02T15:04:05", to)
+	if err != nil || parsed.Sub(expected) > 2*time.Second || expected.Sub(parsed) > 2*time.Second {
+		t.Errorf("Expected the time range to end around %s, got %s", expected, to)
+	}
+}
+
+func TestClockSkewWithinThreshold(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.OnClockSkew = func(skew time.Duration) {
+		t.Errorf("OnClockSkew() called with %s", skew)
+	}
+
+	c.DeleteHostedCollector(defaultCollector.ID)
+	if c.ClockSkew() != 0 {
+		t.Errorf("Expected no skew, got %s", c.ClockSkew())
+	}
+}
@@ -0,0 +1,159 @@
+// Command smoketest checks that credentials and connectivity to a Sumo Logic account
+// work by running a search, listing collectors and reading the personal folder, then
+// reports the result of each check. It exits with status 1 when any check fails.
+//
+//	smoketest -endpoint https://api.sumologic.com/api/v1/ -format json
+//
+// The auth token, the base64 encoding of <accessId>:<accessKey>, is read from the
+// SUMOLOGIC_AUTH_TOKEN environment variable unless -token is given.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	sumologic "github.com/brandonstevens/sumologic-sdk-go"
+)
+
+// Check is the result of one smoke test check.
+type Check struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	DurationMS int64  `json:"durationMs"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Report is the result of the smoke test.
+type Report struct {
+	Endpoint string  `json:"endpoint"`
+	Passed   bool    `json:"passed"`
+	Checks   []Check `json:"checks"`
+}
+
+func main() {
+	endpoint := flag.String("endpoint", os.Getenv("SUMOLOGIC_ENDPOINT"), "API endpoint URL of the account's deployment")
+	token := flag.String("token", os.Getenv("SUMOLOGIC_AUTH_TOKEN"), "auth token, base64 of <accessId>:<accessKey>")
+	query := flag.String("query", "* | limit 1", "query of the search check")
+	window := flag.Duration("window", 15*time.Minute, "time range of the search check, ending now")
+	timeout := flag.Duration("timeout", 2*time.Minute, "timeout of each check")
+	format := flag.String("format", "text", "report format, text or json")
+	flag.Parse()
+
+	if *endpoint == "" || *token == "" {
+		fmt.Fprintln(os.Stderr, "smoketest: -endpoint and -token, or SUMOLOGIC_ENDPOINT and SUMOLOGIC_AUTH_TOKEN, are required")
+		os.Exit(2)
+	}
+	client, err := sumologic.NewClient(*token, *endpoint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "smoketest: %s\n", err)
+		os.Exit(2)
+	}
+
+	report := Report{Endpoint: *endpoint, Passed: true}
+	for _, check := range []struct {
+		name string
+		run  func(ctx context.Context) (string, error)
+	}{
+		{"search", func(ctx context.Context) (string, error) { return checkSearch(ctx, client, *query, *window) }},
+		{"collectors", func(ctx context.Context) (string, error) { return checkCollectors(ctx, client) }},
+		{"personalFolder", func(ctx context.Context) (string, error) { return checkPersonalFolder(ctx, client) }},
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		start := time.Now()
+		detail, err := check.run(ctx)
+		cancel()
+
+		result := Check{Name: check.name, Passed: err == nil, DurationMS: time.Since(start).Milliseconds(), Detail: detail}
+		if err != nil {
+			result.Error = err.Error()
+			report.Passed = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	if err := writeReport(os.Stdout, report, *format); err != nil {
+		fmt.Fprintf(os.Stderr, "smoketest: %s\n", err)
+		os.Exit(2)
+	}
+	if !report.Passed {
+		os.Exit(1)
+	}
+}
+
+// searchDeleteTimeout bounds deleting the search job once its check is done.
+const searchDeleteTimeout = 10 * time.Second
+
+// checkSearch runs a search and deletes its job. The check fails when the job can't be
+// deleted, since the credentials then leak jobs against the account's search limit.
+func checkSearch(ctx context.Context, client *sumologic.Client, query string, window time.Duration) (detail string, err error) {
+	to := time.Now().UTC()
+	sj, err := client.StartSearchJob(sumologic.StartSearchRequest{
+		Query:    query,
+		From:     to.Add(-window).Format("2006-01-02T15:04:05"),
+		To:       to.Format("2006-01-02T15:04:05"),
+		TimeZone: "UTC",
+	}, sumologic.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		// ctx may be done by now, so the job is deleted without it.
+		derr := sj.Delete(sumologic.WithContext(context.Background()), sumologic.WithTimeout(searchDeleteTimeout))
+		if derr != nil && err == nil {
+			detail, err = "", fmt.Errorf("deleting search job %s: %s", sj.ID, derr)
+		}
+	}()
+
+	status, err := sj.WaitForCompletion(ctx, time.Second)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("search job %s found %d messages and %d records", sj.ID, status.MessageCount, status.RecordCount), nil
+}
+
+func checkCollectors(ctx context.Context, client *sumologic.Client) (string, error) {
+	cl, err := client.ListCollectors(sumologic.ListCollectorsRequest{Limit: 10}, sumologic.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("listed %d collectors", len(cl.Collectors)), nil
+}
+
+func checkPersonalFolder(ctx context.Context, client *sumologic.Client) (string, error) {
+	f, err := client.GetPersonalFolder(sumologic.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("personal folder %s holds %d items", f.ID, len(f.Children)), nil
+}
+
+func writeReport(w io.Writer, report Report, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "text":
+		for _, c := range report.Checks {
+			status, message := "PASS", c.Detail
+			if !c.Passed {
+				status, message = "FAIL", c.Error
+			}
+			fmt.Fprintf(w, "%s %-15s %6dms %s\n", status, c.Name, c.DurationMS, message)
+		}
+		if report.Passed {
+			fmt.Fprintln(w, "PASS")
+		} else {
+			fmt.Fprintln(w, "FAIL")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
@@ -0,0 +1,77 @@
+// Command sumoctl is a command line tool for day to day work with a Sumo Logic account.
+//
+//	sumoctl tail -filter '_sourceCategory=prod/web' -highlight 'error|timeout'
+//	sumoctl metrics query -expr 'metric=CPU_Idle' -step 1m -range 1h
+//	sumoctl monitors diff -f monitors.json
+//
+// Every command takes -endpoint and -token, which default to the SUMOLOGIC_ENDPOINT and
+// SUMOLOGIC_AUTH_TOKEN environment variables. The auth token is the base64 encoding of
+// <accessId>:<accessKey>; -access-id and -access-key, or SUMOLOGIC_ACCESSID and
+// SUMOLOGIC_ACCESSKEY, can be given instead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	sumologic "github.com/brandonstevens/sumologic-sdk-go"
+)
+
+// command is a sumoctl subcommand. run parses its arguments and returns the exit status.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands = []command{
+	{"tail", "follow the messages matching a filter as they arrive", runTail},
+	{"metrics", "query metrics and draw them as sparklines, a table or JSON", runMetrics},
+	{"monitors", "diff or apply monitors declared in a file against the monitors library", runMonitors},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+	}
+	if os.Args[1] != "help" && os.Args[1] != "-h" && os.Args[1] != "-help" {
+		fmt.Fprintf(os.Stderr, "sumoctl: unknown command %q\n", os.Args[1])
+	}
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: sumoctl <command> [flags]\n\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// clientFlags adds the flags every command takes to fs and returns a function building
+// the client from them once fs is parsed.
+func clientFlags(fs *flag.FlagSet) func() (*sumologic.Client, error) {
+	endpoint := fs.String("endpoint", os.Getenv("SUMOLOGIC_ENDPOINT"), "API endpoint URL of the account's deployment")
+	token := fs.String("token", os.Getenv("SUMOLOGIC_AUTH_TOKEN"), "auth token, base64 of <accessId>:<accessKey>")
+	accessID := fs.String("access-id", os.Getenv("SUMOLOGIC_ACCESSID"), "access ID, used with -access-key instead of -token")
+	accessKey := fs.String("access-key", os.Getenv("SUMOLOGIC_ACCESSKEY"), "access key, used with -access-id instead of -token")
+	return func() (*sumologic.Client, error) {
+		if *endpoint == "" {
+			return nil, fmt.Errorf("-endpoint or SUMOLOGIC_ENDPOINT is required")
+		}
+		if *accessID != "" && *accessKey != "" {
+			return sumologic.NewClientWithCredentials(*accessID, *accessKey, *endpoint)
+		}
+		if *token == "" {
+			return nil, fmt.Errorf("-token or SUMOLOGIC_AUTH_TOKEN, or -access-id and -access-key, are required")
+		}
+		return sumologic.NewClient(*token, *endpoint)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	sumologic "github.com/brandonstevens/sumologic-sdk-go"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// runMetrics runs the metrics subcommands.
+func runMetrics(args []string) int {
+	if len(args) == 0 || args[0] != "query" {
+		fmt.Fprintln(os.Stderr, "usage: sumoctl metrics query -expr <query> [flags]")
+		return 2
+	}
+	return runMetricsQuery(args[1:])
+}
+
+// runMetricsQuery runs a metrics query over the last -range and prints each time series.
+func runMetricsQuery(args []string) int {
+	fs := flag.NewFlagSet("metrics query", flag.ExitOnError)
+	newClient := clientFlags(fs)
+	expr := fs.String("expr", "", "metrics query, e.g. metric=CPU_Idle _sourceCategory=prod/web")
+	step := fs.Duration("step", time.Minute, "quantization interval of the values")
+	window := fs.Duration("range", time.Hour, "time range of the query, ending now")
+	rollup := fs.String("rollup", sumologic.MetricsRollupAvg, "how the values of a step are combined: Avg, Min, Max, Sum or Count")
+	format := fs.String("format", "sparkline", "output format: sparkline, table or json")
+	timeout := fs.Duration("timeout", time.Minute, "timeout of the query")
+	fs.Parse(args)
+
+	if *expr == "" {
+		fmt.Fprintln(os.Stderr, "sumoctl metrics query: -expr is required")
+		return 2
+	}
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sumoctl metrics query: %s\n", err)
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	to := client.ServerNow()
+	results, err := client.QueryMetrics(sumologic.MetricsQueryRequest{
+		Queries: []sumologic.MetricsQuery{{Query: *expr, Quantization: *step, Rollup: *rollup}},
+		From:    to.Add(-*window),
+		To:      to,
+	}, sumologic.WithContext(ctx))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sumoctl metrics query: %s\n", err)
+		return 1
+	}
+
+	var series []sumologic.MetricsTimeSeries
+	for _, r := range results {
+		series = append(series, r.TimeSeries...)
+	}
+	if err := writeMetrics(os.Stdout, series, *format); err != nil {
+		fmt.Fprintf(os.Stderr, "sumoctl metrics query: %s\n", err)
+		return 2
+	}
+	return 0
+}
+
+func writeMetrics(w io.Writer, series []sumologic.MetricsTimeSeries, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(series)
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "SERIES\tTIME\tVALUE")
+		for _, s := range series {
+			for _, p := range s.Points {
+				fmt.Fprintf(tw, "%s\t%s\t%g\n", s.Label(), p.Time.Format(time.RFC3339), p.Value)
+			}
+		}
+		return tw.Flush()
+	case "sparkline":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "SERIES\tMIN\tMAX\tLAST\t")
+		for _, s := range series {
+			min, max, last := seriesStats(s.Points)
+			fmt.Fprintf(tw, "%s\t%g\t%g\t%g\t%s\n", s.Label(), min, max, last, sparkline(s.Points))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// sparkline draws the values as a row of block characters scaled from their minimum to
+// their maximum.
+func sparkline(points []sumologic.MetricsPoint) string {
+	min, max, _ := seriesStats(points)
+	var b strings.Builder
+	for _, p := range points {
+		i := 0
+		if max > min {
+			i = int((p.Value - min) / (max - min) * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+func seriesStats(points []sumologic.MetricsPoint) (min, max, last float64) {
+	if len(points) == 0 {
+		return 0, 0, 0
+	}
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, p := range points {
+		min = math.Min(min, p.Value)
+		max = math.Max(max, p.Value)
+	}
+	return min, max, points[len(points)-1].Value
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+
+	sumologic "github.com/brandonstevens/sumologic-sdk-go"
+)
+
+// runMonitors runs the monitors subcommands.
+func runMonitors(args []string) int {
+	if len(args) == 0 || (args[0] != "diff" && args[0] != "apply") {
+		fmt.Fprintln(os.Stderr, "usage: sumoctl monitors diff|apply -f <monitors.json> [flags]")
+		return 2
+	}
+	return runMonitorsPlan(args[0], args[1:])
+}
+
+// runMonitorsPlan compares the monitors declared in a file with a folder of the monitors
+// library and prints the changes, then makes them for apply.
+func runMonitorsPlan(action string, args []string) int {
+	fs := flag.NewFlagSet("monitors "+action, flag.ExitOnError)
+	newClient := clientFlags(fs)
+	file := fs.String("f", "", "JSON file of the declared monitors and folders, - for stdin")
+	folder := fs.String("folder", "", "ID of the folder the monitors are declared in, the library root by default")
+	prune := fs.Bool("prune", false, "delete monitors and folders in the folder that aren't declared")
+	exitCode := fs.Bool("exit-code", false, "diff: exit with status 3 when there are changes")
+	fs.Parse(args)
+
+	if *file == "" {
+		fmt.Fprintf(os.Stderr, "sumoctl monitors %s: -f is required\n", action)
+		return 2
+	}
+	declared, err := loadMonitorsFile(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sumoctl monitors %s: %s: %s\n", action, *file, err)
+		return 2
+	}
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sumoctl monitors %s: %s\n", action, err)
+		return 2
+	}
+
+	plan, err := client.PlanMonitors(*folder, declared, *prune)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sumoctl monitors %s: %s\n", action, err)
+		return 1
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Println("No changes.")
+		return 0
+	}
+	writeMonitorPlan(os.Stdout, plan)
+
+	if action == "diff" {
+		if *exitCode {
+			return 3
+		}
+		return 0
+	}
+	applied, err := client.ApplyMonitorPlan(plan)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sumoctl monitors apply: made %d of %d changes, then: %s\n", len(applied), len(plan.Changes), err)
+		return 1
+	}
+	fmt.Printf("Made %d changes.\n", len(applied))
+	return 0
+}
+
+func loadMonitorsFile(name string) ([]sumologic.Monitor, error) {
+	if name == "-" {
+		return sumologic.LoadMonitors(os.Stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return sumologic.LoadMonitors(f)
+}
+
+// writeMonitorPlan prints each change with +, ~ or -, and the fields an update changes.
+func writeMonitorPlan(w io.Writer, plan *sumologic.MonitorPlan) {
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case sumologic.MonitorChangeCreate:
+			fmt.Fprintf(w, "+ %s\n", change.Path)
+		case sumologic.MonitorChangeUpdate:
+			fmt.Fprintf(w, "~ %s\n", change.Path)
+			for _, field := range changedMonitorFields(change.Monitor, *change.Existing) {
+				fmt.Fprintf(w, "    %s\n", field)
+			}
+		case sumologic.MonitorChangeDelete:
+			fmt.Fprintf(w, "- %s\n", change.Path)
+		}
+	}
+}
+
+// changedMonitorFields returns the top level fields whose values differ, with the old
+// and new values.
+func changedMonitorFields(declared, existing sumologic.Monitor) []string {
+	a, b := monitorFields(declared), monitorFields(existing)
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	var changed []string
+	for k := range keys {
+		switch k {
+		case "id", "parentId", "version", "children", "createdAt", "createdBy", "modifiedAt", "modifiedBy":
+			continue
+		}
+		if !reflect.DeepEqual(a[k], b[k]) {
+			was, _ := json.Marshal(b[k])
+			now, _ := json.Marshal(a[k])
+			changed = append(changed, fmt.Sprintf("%s: %s => %s", k, was, now))
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func monitorFields(m sumologic.Monitor) map[string]interface{} {
+	data, _ := json.Marshal(m)
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+	return fields
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sumologic "github.com/brandonstevens/sumologic-sdk-go"
+)
+
+const (
+	colorHighlight = "\x1b[1;31m"
+	colorField     = "\x1b[36m"
+	colorReset     = "\x1b[0m"
+)
+
+// searchDeleteTimeout bounds deleting a window's search job once the tail is interrupted.
+const searchDeleteTimeout = 10 * time.Second
+
+// runTail follows the messages matching a filter. The API has no public Live Tail
+// endpoint, so each interval it searches the window of time since the previous one,
+// ending lag before now to give messages time to be ingested.
+func runTail(args []string) int {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	newClient := clientFlags(fs)
+	filter := fs.String("filter", "", "search expression the messages must match, e.g. _sourceCategory=prod/web")
+	highlight := fs.String("highlight", "", "regular expression highlighted in each message")
+	fields := fs.String("fields", "", "comma separated fields shown after each message, e.g. _sourcehost,status")
+	interval := fs.Duration("interval", 10*time.Second, "how often to search for new messages")
+	lag := fs.Duration("lag", 30*time.Second, "how long before now each window ends, to allow for ingest delay")
+	noColor := fs.Bool("no-color", false, "don't color the output")
+	fs.Parse(args)
+
+	if *filter == "" {
+		fmt.Fprintln(os.Stderr, "sumoctl tail: -filter is required")
+		return 2
+	}
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sumoctl tail: %s\n", err)
+		return 2
+	}
+	p := &tailPrinter{w: os.Stdout, color: !*noColor}
+	if *highlight != "" {
+		if p.highlight, err = regexp.Compile(*highlight); err != nil {
+			fmt.Fprintf(os.Stderr, "sumoctl tail: -highlight: %s\n", err)
+			return 2
+		}
+	}
+	if *fields != "" {
+		p.fields = strings.Split(*fields, ",")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	from := client.ServerNow().Add(-*lag)
+	for {
+		if err := sleep(ctx, *interval); err != nil {
+			return 0
+		}
+		to := client.ServerNow().Add(-*lag)
+		if err := tailWindow(ctx, client, p, *filter, from, to); err != nil {
+			if ctx.Err() != nil {
+				return 0
+			}
+			fmt.Fprintf(os.Stderr, "sumoctl tail: %s\n", err)
+			return 1
+		}
+		from = to
+	}
+}
+
+// tailWindow searches [from, to) and prints its messages oldest first.
+func tailWindow(ctx context.Context, client *sumologic.Client, p *tailPrinter, filter string, from, to time.Time) error {
+	var ssr sumologic.StartSearchRequest
+	if err := ssr.SetTimeRange(sumologic.TimeRange{From: from, To: to}); err != nil {
+		return err
+	}
+	ssr.Query = filter
+	sj, err := client.StartSearchJob(ssr, sumologic.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	// ctx is canceled when the tail is interrupted, so the job is deleted without it.
+	defer sj.Delete(sumologic.WithContext(context.Background()), sumologic.WithTimeout(searchDeleteTimeout))
+
+	var window []sumologic.SearchJobResultMessage
+	messages, errs := sj.StreamMessages(ctx, 0)
+	for m := range messages {
+		window = append(window, m)
+	}
+	if err := <-errs; err != nil {
+		return err
+	}
+	sort.SliceStable(window, func(i, j int) bool {
+		return messageTime(window[i]) < messageTime(window[j])
+	})
+	for _, m := range window {
+		p.print(m)
+	}
+	return nil
+}
+
+// tailPrinter writes messages with their highlighted matches and selected fields.
+type tailPrinter struct {
+	w         io.Writer
+	color     bool
+	highlight *regexp.Regexp
+	fields    []string
+}
+
+func (p *tailPrinter) print(m sumologic.SearchJobResultMessage) {
+	raw := strings.TrimRight(fmt.Sprint(m.Map["_raw"]), "\n")
+	if p.highlight != nil && p.color {
+		raw = p.highlight.ReplaceAllStringFunc(raw, func(s string) string {
+			return colorHighlight + s + colorReset
+		})
+	}
+	line := time.UnixMilli(messageTime(m)).Format(time.RFC3339) + " " + raw
+	for _, f := range p.fields {
+		v, ok := m.Map[strings.ToLower(f)]
+		if !ok {
+			v, ok = m.Map[f]
+		}
+		if !ok {
+			continue
+		}
+		field := fmt.Sprintf("%s=%v", f, v)
+		if p.color {
+			field = colorField + field + colorReset
+		}
+		line += " " + field
+	}
+	fmt.Fprintln(p.w, line)
+}
+
+// messageTime returns the _messagetime of a message in milliseconds since the epoch.
+func messageTime(m sumologic.SearchJobResultMessage) int64 {
+	ms, _ := strconv.ParseInt(fmt.Sprint(m.Map["_messagetime"]), 10, 64)
+	return ms
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package sumologic
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Collector filters understood by the list collectors API.
+const (
+	CollectorFilterInstalled = "installed"
+	CollectorFilterHosted    = "hosted"
+	CollectorFilterDead      = "dead"
+	CollectorFilterAlive     = "alive"
+)
+
+// ListCollectorsRequest holds the filter and page for listing collectors.
+// Empty fields are not sent.
+type ListCollectorsRequest struct {
+	Filter string
+	Limit  int
+	Offset int
+}
+
+// CollectorList is one page of collectors.
+type CollectorList struct {
+	Collectors []Collector `json:"collectors"`
+}
+
+// collectorsPageLimit is the page size used by ListAllCollectors, the API's maximum.
+const collectorsPageLimit = 1000
+
+// ListCollectors returns one page of collectors of every type, installed and hosted.
+// The get, update and delete calls for hosted collectors work for any collector.
+func (c *Client) ListCollectors(lcr ListCollectorsRequest, opts ...CallOption) (*CollectorList, error) {
+	q := url.Values{}
+	if lcr.Filter != "" {
+		q.Set("filter", lcr.Filter)
+	}
+	if lcr.Limit > 0 {
+		q.Set("limit", strconv.Itoa(lcr.Limit))
+	}
+	if lcr.Offset > 0 {
+		q.Set("offset", strconv.Itoa(lcr.Offset))
+	}
+
+	path := "collectors"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var cl = new(CollectorList)
+	if err := c.do(call{method: "GET", path: path, out: cl}, opts); err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+// ListAllCollectors pages through and returns every collector matching the filter.
+// The request's Limit and Offset are ignored.
+func (c *Client) ListAllCollectors(lcr ListCollectorsRequest, opts ...CallOption) ([]Collector, error) {
+	var collectors []Collector
+	lcr.Limit, lcr.Offset = collectorsPageLimit, 0
+	for {
+		cl, err := c.ListCollectors(lcr, opts...)
+		if err != nil {
+			return nil, err
+		}
+		collectors = append(collectors, cl.Collectors...)
+		if len(cl.Collectors) < lcr.Limit {
+			return collectors, nil
+		}
+		lcr.Offset += len(cl.Collectors)
+	}
+}
+
+// Collectors returns a ResourceClient for collectors of every type. Collectors can only
+// be created this way as hosted collectors; installed collectors register themselves.
+func (c *Client) Collectors(opts ...CallOption) ResourceClient[Collector] {
+	return c.collectorResourceClient("", opts...)
+}
+
+// LookupCollectorByName returns the ID of the collector with the name.
+func (c *Client) LookupCollectorByName(name string, opts ...CallOption) (string, error) {
+	return nameResolver(c, "collector", func() *NameResolver[Collector] {
+		return NewNameResolver(c.Collectors(opts...), "collector",
+			func(collector Collector) string { return collector.Name },
+			func(collector Collector) string { return strconv.Itoa(collector.ID) },
+			ErrCollectorNotFound, NameResolverTTL)
+	}).Resolve(name)
+}
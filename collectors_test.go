@@ -0,0 +1,56 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestListAllCollectorsPages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/collectors" {
+			t.Errorf("Expected request to ‘/collectors’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("limit") != "1000" {
+			t.Errorf("Unexpected query ‘%s’", r.URL.RawQuery)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		count := 1000
+		if offset > 0 {
+			count = 2
+		}
+		var cl CollectorList
+		for i := 0; i < count; i++ {
+			cl.Collectors = append(cl.Collectors, Collector{ID: offset + i, Name: "collector-" + strconv.Itoa(offset+i)})
+		}
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(cl)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collectors, err := c.HostedCollectors().List()
+	if err != nil {
+		t.Errorf("List() returned an error: %s", err)
+		return
+	}
+	if len(collectors) != 1002 || collectors[1001].ID != 1001 {
+		t.Errorf("Expected 1002 collectors over 2 pages, got %d", len(collectors))
+	}
+
+	id, err := c.LookupCollectorByName("collector-1001")
+	if err != nil || id != "1001" {
+		t.Errorf("Expected collector 1001, got %q and %v", id, err)
+	}
+}
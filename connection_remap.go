@@ -0,0 +1,78 @@
+package sumologic
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// ConnectionReferenceFields are the fields of exported monitors and content that hold the
+// ID of a notification connection.
+var ConnectionReferenceFields = []string{"connectionId", "webhookId"}
+
+// RemapConnections rewrites the connection IDs referenced by exported monitor or content JSON
+// using mapping, from source org ID to destination org ID, so imported alerts notify the
+// destination org's connections. IDs missing from the mapping are left unchanged and
+// returned, sorted, so callers can decide whether to import anyway.
+func RemapConnections(data []byte, mapping map[string]string) ([]byte, []string, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, nil, err
+	}
+
+	fields := make(map[string]bool, len(ConnectionReferenceFields))
+	for _, f := range ConnectionReferenceFields {
+		fields[f] = true
+	}
+	unmapped := make(map[string]bool)
+	remapConnections(v, fields, mapping, unmapped)
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	var missing []string
+	for id := range unmapped {
+		missing = append(missing, id)
+	}
+	sort.Strings(missing)
+	return out, missing, nil
+}
+
+func remapConnections(v interface{}, fields map[string]bool, mapping map[string]string, unmapped map[string]bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			id, ok := child.(string)
+			if !fields[k] || !ok {
+				remapConnections(child, fields, mapping, unmapped)
+				continue
+			}
+			if to, ok := mapping[id]; ok {
+				t[k] = to
+			} else if id != "" {
+				unmapped[id] = true
+			}
+		}
+	case []interface{}:
+		for _, child := range t {
+			remapConnections(child, fields, mapping, unmapped)
+		}
+	}
+}
+
+// ConnectionMappingByName builds a RemapConnections mapping by matching connection names.
+// sourceNames maps source org connection IDs to names and destIDs maps destination org
+// connection names to IDs. Source connections without a destination of the same name are
+// left out of the mapping.
+func ConnectionMappingByName(sourceNames map[string]string, destIDs map[string]string) map[string]string {
+	mapping := make(map[string]string)
+	for id, name := range sourceNames {
+		if to, ok := destIDs[name]; ok {
+			mapping[id] = to
+		}
+	}
+	return mapping
+}
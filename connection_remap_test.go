@@ -0,0 +1,65 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const exportedMonitor = `{
+	"type": "MonitorsLibraryMonitorExport",
+	"name": "Errors",
+	"notifications": [
+		{"notification": {"connectionType": "Webhook", "connectionId": "00000000000000A1"}},
+		{"notification": {"connectionType": "PagerDuty", "connectionId": "00000000000000B2"}}
+	],
+	"searchSchedule": {"notification": {"webhookId": "00000000000000A1"}}
+}`
+
+func TestRemapConnections(t *testing.T) {
+	out, unmapped, err := RemapConnections([]byte(exportedMonitor), map[string]string{
+		"00000000000000A1": "00000000000000C3",
+	})
+	if err != nil {
+		t.Errorf("RemapConnections() returned an error: %s", err)
+		return
+	}
+	if len(unmapped) != 1 || unmapped[0] != "00000000000000B2" {
+		t.Errorf("Expected 00000000000000B2 to be unmapped, got %v", unmapped)
+	}
+
+	var monitor struct {
+		Notifications []struct {
+			Notification struct {
+				ConnectionID string `json:"connectionId"`
+			} `json:"notification"`
+		} `json:"notifications"`
+		SearchSchedule struct {
+			Notification struct {
+				WebhookID string `json:"webhookId"`
+			} `json:"notification"`
+		} `json:"searchSchedule"`
+	}
+	if err := json.Unmarshal(out, &monitor); err != nil {
+		t.Errorf("Unable to unmarshal the remapped monitor: %s", err)
+		return
+	}
+	if monitor.Notifications[0].Notification.ConnectionID != "00000000000000C3" {
+		t.Errorf("Expected the webhook connection to be remapped, got %s", monitor.Notifications[0].Notification.ConnectionID)
+	}
+	if monitor.Notifications[1].Notification.ConnectionID != "00000000000000B2" {
+		t.Errorf("Expected the unmapped connection to be left alone, got %s", monitor.Notifications[1].Notification.ConnectionID)
+	}
+	if monitor.SearchSchedule.Notification.WebhookID != "00000000000000C3" {
+		t.Errorf("Expected the scheduled search webhook to be remapped, got %s", monitor.SearchSchedule.Notification.WebhookID)
+	}
+}
+
+func TestConnectionMappingByName(t *testing.T) {
+	mapping := ConnectionMappingByName(
+		map[string]string{"A1": "slack-ops", "B2": "pagerduty"},
+		map[string]string{"slack-ops": "C3"},
+	)
+	if len(mapping) != 1 || mapping["A1"] != "C3" {
+		t.Errorf("ConnectionMappingByName() returned an unexpected mapping: %v", mapping)
+	}
+}
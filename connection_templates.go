@@ -0,0 +1,295 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Alert template variables that Sumo Logic substitutes into connection payloads.
+const (
+	AlertVariableName             = "{{Name}}"
+	AlertVariableDescription      = "{{Description}}"
+	AlertVariableID               = "{{Id}}"
+	AlertVariableQuery            = "{{Query}}"
+	AlertVariableQueryURL         = "{{QueryURL}}"
+	AlertVariableTriggerType      = "{{TriggerType}}"
+	AlertVariableTriggerTime      = "{{TriggerTime}}"
+	AlertVariableTriggerValue     = "{{TriggerValue}}"
+	AlertVariableTriggerCondition = "{{TriggerCondition}}"
+	AlertVariableNumQueryResults  = "{{NumQueryResults}}"
+	AlertVariableAlertResponseURL = "{{AlertResponseUrl}}"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyConnection builds a connection that raises and resolves PagerDuty incidents
+// through the Events API v2.
+type PagerDutyConnection struct {
+	Name        string
+	Description string
+
+	// RoutingKey is the integration key of the PagerDuty service.
+	RoutingKey string
+	// Severity is one of critical, error, warning or info; critical by default.
+	Severity string
+	// Summary is the incident title, the alert name and trigger type by default.
+	Summary string
+}
+
+var pagerDutyRoutingKeyRegexp = regexp.MustCompile(`^[0-9a-zA-Z]{32}$`)
+
+// Connection returns the Sumo Logic connection for the PagerDuty service. The alert ID is
+// used as the dedup key so the resolution payload resolves the incident it triggered.
+func (p PagerDutyConnection) Connection() (*Connection, error) {
+	if p.Name == "" {
+		return nil, fmt.Errorf("PagerDuty connection needs a name")
+	}
+	if !pagerDutyRoutingKeyRegexp.MatchString(p.RoutingKey) {
+		return nil, fmt.Errorf("PagerDuty connection `%s` needs a 32 character routing key", p.Name)
+	}
+	severity := p.Severity
+	if severity == "" {
+		severity = "critical"
+	}
+	switch severity {
+	case "critical", "error", "warning", "info":
+	default:
+		return nil, fmt.Errorf("invalid PagerDuty severity `%s`", severity)
+	}
+	summary := p.Summary
+	if summary == "" {
+		summary = AlertVariableTriggerType + ": " + AlertVariableName
+	}
+
+	event := func(action string) (string, error) {
+		b, err := json.Marshal(map[string]interface{}{
+			"routing_key":  p.RoutingKey,
+			"event_action": action,
+			"dedup_key":    AlertVariableID,
+			"client":       "Sumo Logic",
+			"client_url":   AlertVariableAlertResponseURL,
+			"payload": map[string]interface{}{
+				"summary":   summary,
+				"source":    "Sumo Logic",
+				"severity":  severity,
+				"timestamp": AlertVariableTriggerTime,
+				"custom_details": map[string]string{
+					"description":       AlertVariableDescription,
+					"query":             AlertVariableQuery,
+					"query_url":         AlertVariableQueryURL,
+					"trigger_value":     AlertVariableTriggerValue,
+					"trigger_condition": AlertVariableTriggerCondition,
+				},
+			},
+		})
+		return string(b), err
+	}
+	trigger, err := event("trigger")
+	if err != nil {
+		return nil, err
+	}
+	resolve, err := event("resolve")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connection{
+		Type:              ConnectionTypeWebhook,
+		Name:              p.Name,
+		Description:       p.Description,
+		URL:               PagerDutyEventsURL,
+		DefaultPayload:    trigger,
+		ResolutionPayload: resolve,
+		WebhookType:       WebhookTypePagerDuty,
+		ConnectionSubtype: "Event",
+	}, nil
+}
+
+// OpsgenieConnection builds a connection that creates Opsgenie alerts.
+type OpsgenieConnection struct {
+	Name        string
+	Description string
+
+	// APIKey is the key of the Opsgenie API integration.
+	APIKey string
+	// Priority is one of P1 to P5; P3 by default.
+	Priority string
+	// EU selects the Opsgenie EU instance.
+	EU   bool
+	Tags []string
+}
+
+// Connection returns the Sumo Logic connection for the Opsgenie integration. The alert ID is
+// used as the Opsgenie alias so repeated notifications update the same alert.
+func (o OpsgenieConnection) Connection() (*Connection, error) {
+	if o.Name == "" {
+		return nil, fmt.Errorf("Opsgenie connection needs a name")
+	}
+	if o.APIKey == "" {
+		return nil, fmt.Errorf("Opsgenie connection `%s` needs an API key", o.Name)
+	}
+	priority := o.Priority
+	if priority == "" {
+		priority = "P3"
+	}
+	switch priority {
+	case "P1", "P2", "P3", "P4", "P5":
+	default:
+		return nil, fmt.Errorf("invalid Opsgenie priority `%s`", priority)
+	}
+
+	alert := map[string]interface{}{
+		"message":     AlertVariableName,
+		"alias":       AlertVariableID,
+		"description": AlertVariableDescription + "\n" + AlertVariableQueryURL,
+		"priority":    priority,
+		"source":      "Sumo Logic",
+		"details": map[string]string{
+			"triggerType":      AlertVariableTriggerType,
+			"triggerValue":     AlertVariableTriggerValue,
+			"triggerCondition": AlertVariableTriggerCondition,
+			"query":            AlertVariableQuery,
+		},
+	}
+	if len(o.Tags) > 0 {
+		alert["tags"] = o.Tags
+	}
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return nil, err
+	}
+
+	url := "https://api.opsgenie.com/v2/alerts"
+	if o.EU {
+		url = "https://api.eu.opsgenie.com/v2/alerts"
+	}
+	return &Connection{
+		Type:           ConnectionTypeWebhook,
+		Name:           o.Name,
+		Description:    o.Description,
+		URL:            url,
+		Headers:        []ConnectionHeader{{Name: "Authorization", Value: "GenieKey " + o.APIKey}},
+		DefaultPayload: string(payload),
+		WebhookType:    WebhookTypeOpsgenie,
+	}, nil
+}
+
+// slackWebhookURLRegexp matches Slack incoming webhook URLs.
+var slackWebhookURLRegexp = regexp.MustCompile(`^https://hooks\.slack\.com/services/[A-Za-z0-9/]+$`)
+
+// SlackConnection builds a connection that posts alerts to a Slack channel through an
+// incoming webhook.
+type SlackConnection struct {
+	Name        string
+	Description string
+
+	// WebhookURL is the URL of the Slack incoming webhook.
+	WebhookURL string
+	// Text is the message title, the alert name and trigger type by default.
+	Text string
+}
+
+// Connection returns the Sumo Logic connection for the Slack webhook. The message links
+// to the query that raised the alert.
+func (s SlackConnection) Connection() (*Connection, error) {
+	if s.Name == "" {
+		return nil, fmt.Errorf("Slack connection needs a name")
+	}
+	if !slackWebhookURLRegexp.MatchString(s.WebhookURL) {
+		return nil, fmt.Errorf("Slack connection `%s` needs a https://hooks.slack.com/services/ webhook URL", s.Name)
+	}
+	text := s.Text
+	if text == "" {
+		text = AlertVariableTriggerType + ": " + AlertVariableName
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"text": text,
+		"attachments": []map[string]interface{}{{
+			"title":      AlertVariableName,
+			"title_link": AlertVariableQueryURL,
+			"text":       AlertVariableDescription,
+			"fields": []map[string]interface{}{
+				{"title": "Trigger value", "value": AlertVariableTriggerValue, "short": true},
+				{"title": "Condition", "value": AlertVariableTriggerCondition, "short": true},
+				{"title": "Time", "value": AlertVariableTriggerTime, "short": true},
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connection{
+		Type:           ConnectionTypeWebhook,
+		Name:           s.Name,
+		Description:    s.Description,
+		URL:            s.WebhookURL,
+		DefaultPayload: string(payload),
+		WebhookType:    WebhookTypeSlack,
+	}, nil
+}
+
+// WebhookConnection builds a connection that posts alerts as JSON to any endpoint. Payload
+// and ResolutionPayload are encoded as the request bodies, so their values can use the
+// alert variables; without a Payload, every variable is sent under its own name.
+type WebhookConnection struct {
+	Name        string
+	Description string
+
+	URL               string
+	Headers           map[string]string
+	Payload           map[string]interface{}
+	ResolutionPayload map[string]interface{}
+}
+
+// Connection returns the Sumo Logic connection for the webhook.
+func (w WebhookConnection) Connection() (*Connection, error) {
+	if w.Name == "" {
+		return nil, fmt.Errorf("webhook connection needs a name")
+	}
+	if !strings.HasPrefix(w.URL, "https://") {
+		return nil, fmt.Errorf("webhook connection `%s` needs an https URL", w.Name)
+	}
+	payload := w.Payload
+	if payload == nil {
+		payload = map[string]interface{}{
+			"name":             AlertVariableName,
+			"description":      AlertVariableDescription,
+			"id":               AlertVariableID,
+			"query":            AlertVariableQuery,
+			"queryUrl":         AlertVariableQueryURL,
+			"triggerType":      AlertVariableTriggerType,
+			"triggerTime":      AlertVariableTriggerTime,
+			"triggerValue":     AlertVariableTriggerValue,
+			"triggerCondition": AlertVariableTriggerCondition,
+			"numQueryResults":  AlertVariableNumQueryResults,
+		}
+	}
+	defaultPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	conn := &Connection{
+		Type:           ConnectionTypeWebhook,
+		Name:           w.Name,
+		Description:    w.Description,
+		URL:            w.URL,
+		DefaultPayload: string(defaultPayload),
+		WebhookType:    WebhookTypeWebhook,
+	}
+	if w.ResolutionPayload != nil {
+		resolution, err := json.Marshal(w.ResolutionPayload)
+		if err != nil {
+			return nil, err
+		}
+		conn.ResolutionPayload = string(resolution)
+	}
+	for _, name := range sortedKeys(w.Headers) {
+		conn.Headers = append(conn.Headers, ConnectionHeader{Name: name, Value: w.Headers[name]})
+	}
+	return conn, nil
+}
@@ -0,0 +1,151 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPagerDutyConnection(t *testing.T) {
+	conn, err := PagerDutyConnection{
+		Name:       "pagerduty",
+		RoutingKey: "0123456789abcdef0123456789abcdef",
+		Severity:   "warning",
+	}.Connection()
+	if err != nil {
+		t.Errorf("Connection() returned an error: %s", err)
+		return
+	}
+	if conn.URL != PagerDutyEventsURL || conn.WebhookType != WebhookTypePagerDuty {
+		t.Errorf("Connection() returned an unexpected connection: %v", conn)
+	}
+
+	var trigger, resolve map[string]interface{}
+	if err := json.Unmarshal([]byte(conn.DefaultPayload), &trigger); err != nil {
+		t.Errorf("DefaultPayload is not valid JSON: %s", err)
+		return
+	}
+	if err := json.Unmarshal([]byte(conn.ResolutionPayload), &resolve); err != nil {
+		t.Errorf("ResolutionPayload is not valid JSON: %s", err)
+		return
+	}
+	if trigger["event_action"] != "trigger" || resolve["event_action"] != "resolve" {
+		t.Errorf("Unexpected event actions %v and %v", trigger["event_action"], resolve["event_action"])
+	}
+	if trigger["dedup_key"] != AlertVariableID || resolve["dedup_key"] != AlertVariableID {
+		t.Errorf("Expected both payloads to dedup on the alert ID")
+	}
+}
+
+func TestPagerDutyConnectionInvalid(t *testing.T) {
+	for _, p := range []PagerDutyConnection{
+		{Name: "short key", RoutingKey: "abc"},
+		{Name: "bad severity", RoutingKey: "0123456789abcdef0123456789abcdef", Severity: "page-everyone"},
+		{RoutingKey: "0123456789abcdef0123456789abcdef"},
+	} {
+		if _, err := p.Connection(); err == nil {
+			t.Errorf("Connection() did not return an error for %v", p)
+		}
+	}
+}
+
+func TestOpsgenieConnection(t *testing.T) {
+	conn, err := OpsgenieConnection{Name: "opsgenie", APIKey: "key", EU: true, Tags: []string{"sumo"}}.Connection()
+	if err != nil {
+		t.Errorf("Connection() returned an error: %s", err)
+		return
+	}
+	if !strings.Contains(conn.URL, "api.eu.opsgenie.com") {
+		t.Errorf("Expected the EU endpoint, got %s", conn.URL)
+	}
+	if len(conn.Headers) != 1 || conn.Headers[0].Value != "GenieKey key" {
+		t.Errorf("Expected a GenieKey authorization header, got %v", conn.Headers)
+	}
+	var alert map[string]interface{}
+	if err := json.Unmarshal([]byte(conn.DefaultPayload), &alert); err != nil {
+		t.Errorf("DefaultPayload is not valid JSON: %s", err)
+		return
+	}
+	if alert["priority"] != "P3" || alert["message"] != AlertVariableName {
+		t.Errorf("Unexpected Opsgenie alert %v", alert)
+	}
+}
+
+func TestValidateConnection(t *testing.T) {
+	status := http.StatusAccepted
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method != "POST" || r.URL.EscapedPath() != "/connections/test" {
+			t.Errorf("Expected ‘POST’ request to ‘/connections/test’, got ‘%s’ to ‘%s’", r.Method, r.URL.EscapedPath())
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var conn Connection
+		json.Unmarshal(body, &conn)
+		if conn.WebhookType != WebhookTypeOpsgenie {
+			t.Errorf("Expected an Opsgenie connection, got %v", conn.WebhookType)
+		}
+		body, _ = json.Marshal(ConnectionTestResult{StatusCode: status})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	conn, _ := OpsgenieConnection{Name: "opsgenie", APIKey: "key"}.Connection()
+	if err := c.ValidateConnection(*conn); err != nil {
+		t.Errorf("ValidateConnection() returned an error: %s", err)
+	}
+	status = http.StatusUnauthorized
+	if err := c.ValidateConnection(*conn); err == nil {
+		t.Errorf("ValidateConnection() did not return an error for a rejected notification")
+	}
+}
+
+func TestSlackConnection(t *testing.T) {
+	conn, err := SlackConnection{Name: "slack", WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX"}.Connection()
+	if err != nil {
+		t.Errorf("Connection() returned an error: %s", err)
+		return
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(conn.DefaultPayload), &payload); err != nil {
+		t.Errorf("DefaultPayload is not valid JSON: %s", err)
+		return
+	}
+	if payload["text"] != AlertVariableTriggerType+": "+AlertVariableName || conn.WebhookType != WebhookTypeSlack {
+		t.Errorf("Connection() returned an unexpected connection: %v", conn)
+	}
+
+	if _, err := (SlackConnection{Name: "slack", WebhookURL: "https://example.com/hook"}).Connection(); err == nil {
+		t.Errorf("Connection() did not return an error for a URL that isn't a Slack webhook")
+	}
+}
+
+func TestWebhookConnection(t *testing.T) {
+	conn, err := WebhookConnection{
+		Name:    "webhook",
+		URL:     "https://example.com/alerts",
+		Headers: map[string]string{"X-Token": "secret", "Accept": "application/json"},
+		Payload: map[string]interface{}{"alert": AlertVariableName, "severity": "high"},
+	}.Connection()
+	if err != nil {
+		t.Errorf("Connection() returned an error: %s", err)
+		return
+	}
+	if conn.DefaultPayload != `{"alert":"{{Name}}","severity":"high"}` {
+		t.Errorf("Unexpected payload %s", conn.DefaultPayload)
+	}
+	if len(conn.Headers) != 2 || conn.Headers[0].Name != "Accept" {
+		t.Errorf("Expected the headers in name order, got %v", conn.Headers)
+	}
+	if conn.ResolutionPayload != "" {
+		t.Errorf("Expected no resolution payload, got %s", conn.ResolutionPayload)
+	}
+}
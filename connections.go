@@ -0,0 +1,212 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Connection is a webhook connection that monitors and scheduled searches send alerts to.
+type Connection struct {
+	ID                string             `json:"id,omitempty"`
+	Type              string             `json:"type"`
+	Name              string             `json:"name"`
+	Description       string             `json:"description,omitempty"`
+	URL               string             `json:"url"`
+	Headers           []ConnectionHeader `json:"headers,omitempty"`
+	CustomHeaders     []ConnectionHeader `json:"customHeaders,omitempty"`
+	DefaultPayload    string             `json:"defaultPayload"`
+	ResolutionPayload string             `json:"resolutionPayload,omitempty"`
+	WebhookType       string             `json:"webhookType"`
+	ConnectionSubtype string             `json:"connectionSubtype,omitempty"`
+}
+
+// ConnectionHeader is an HTTP header sent with every notification of a connection.
+type ConnectionHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ConnectionTypeWebhook is the connection type of webhook connections.
+const ConnectionTypeWebhook = "WebhookDefinition"
+
+// connectionTypeWebhookQuery is the type of webhook connections when getting or deleting one.
+const connectionTypeWebhookQuery = "WebhookConnection"
+
+// ConnectionList is one page of connections. Next is the token for the following page
+// and is empty on the last page.
+type ConnectionList struct {
+	Data []Connection `json:"data"`
+	Next string       `json:"next,omitempty"`
+}
+
+// ErrConnectionNotFound is returned when a connection doesn't exist.
+var ErrConnectionNotFound = errors.New("Connection not found")
+
+// Webhook types of connections.
+const (
+	WebhookTypeWebhook   = "Webhook"
+	WebhookTypePagerDuty = "PagerDuty"
+	WebhookTypeOpsgenie  = "Opsgenie"
+	WebhookTypeSlack     = "Slack"
+)
+
+// ConnectionTestResult is the response the connection's endpoint gave to a test notification.
+type ConnectionTestResult struct {
+	StatusCode      int    `json:"statusCode"`
+	ResponseContent string `json:"responseContent"`
+}
+
+// CreateConnection creates a new connection.
+func (c *Client) CreateConnection(conn Connection, opts ...CallOption) (*Connection, error) {
+	var created = new(Connection)
+	err := c.do(call{
+		method:     "POST",
+		path:       "connections",
+		in:         conn,
+		out:        created,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if a connection with this name `%s` already exists", conn.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// TestConnection has Sumo Logic send a test notification through the connection without
+// saving it, and returns how the connection's endpoint responded.
+func (c *Client) TestConnection(conn Connection, opts ...CallOption) (*ConnectionTestResult, error) {
+	var result = new(ConnectionTestResult)
+	err := c.do(call{
+		method:     "POST",
+		path:       "connections/test",
+		in:         conn,
+		out:        result,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for connection `%s`", conn.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ValidateConnection sends a test notification through the connection and returns an error
+// unless the connection's endpoint accepted it with a 2xx status.
+func (c *Client) ValidateConnection(conn Connection, opts ...CallOption) error {
+	result, err := c.TestConnection(conn, opts...)
+	if err != nil {
+		return err
+	}
+	if result.StatusCode < 200 || result.StatusCode > 299 {
+		return fmt.Errorf("connection `%s` test returned status %d: %s", conn.Name, result.StatusCode, result.ResponseContent)
+	}
+	return nil
+}
+
+// ListConnections returns one page of connections. A limit of 0 uses the API default.
+func (c *Client) ListConnections(limit int, token string, opts ...CallOption) (*ConnectionList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "connections"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var cl = new(ConnectionList)
+	if err := c.do(call{method: "GET", path: path, out: cl}, opts); err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+// ListAllConnections follows the pagination tokens and returns every connection.
+func (c *Client) ListAllConnections(opts ...CallOption) ([]Connection, error) {
+	var connections []Connection
+	token := ""
+	for {
+		cl, err := c.ListConnections(0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		connections = append(connections, cl.Data...)
+		if cl.Next == "" {
+			return connections, nil
+		}
+		token = cl.Next
+	}
+}
+
+// GetConnection gets the webhook connection with the specified ID.
+func (c *Client) GetConnection(id string, opts ...CallOption) (*Connection, error) {
+	q := url.Values{}
+	q.Set("type", connectionTypeWebhookQuery)
+	var conn = new(Connection)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("connections/%s?%s", id, q.Encode()),
+		out:      conn,
+		notFound: ErrConnectionNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// UpdateConnection replaces the connection with the same ID. A connection read from the
+// API can be passed back as is.
+func (c *Client) UpdateConnection(conn Connection, opts ...CallOption) (*Connection, error) {
+	// The API reads webhook connections with a different type than it writes them.
+	if conn.Type == connectionTypeWebhookQuery {
+		conn.Type = ConnectionTypeWebhook
+	}
+	var updated = new(Connection)
+	err := c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("connections/%s", conn.ID),
+		in:         conn,
+		out:        updated,
+		notFound:   ErrConnectionNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for connection `%s`", conn.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteConnection deletes the webhook connection with the specified ID. Monitors and
+// scheduled searches using it stop sending its notifications.
+func (c *Client) DeleteConnection(id string, opts ...CallOption) error {
+	q := url.Values{}
+	q.Set("type", connectionTypeWebhookQuery)
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("connections/%s?%s", id, q.Encode()), notFound: ErrConnectionNotFound}, opts)
+}
+
+// Connections returns a ResourceClient for webhook connections.
+func (c *Client) Connections(opts ...CallOption) ResourceClient[Connection] {
+	return &resourceClient[Connection]{
+		list: func() ([]Connection, error) {
+			return c.ListAllConnections(opts...)
+		},
+		get: func(id string) (*Connection, error) {
+			return c.GetConnection(id, opts...)
+		},
+		create: func(conn Connection) (*Connection, error) {
+			return c.CreateConnection(conn, opts...)
+		},
+		update: func(conn Connection) (*Connection, error) {
+			return c.UpdateConnection(conn, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteConnection(id, opts...)
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListAllConnectionsFollowsToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/connections" {
+			t.Errorf("Expected request to ‘/connections’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var cl ConnectionList
+		switch r.URL.Query().Get("token") {
+		case "":
+			cl = ConnectionList{Data: []Connection{{ID: "1", Name: "one"}}, Next: "page2"}
+		case "page2":
+			cl = ConnectionList{Data: []Connection{{ID: "2", Name: "two"}}}
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		json.NewEncoder(w).Encode(cl)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	connections, err := c.ListAllConnections()
+	if err != nil {
+		t.Errorf("ListAllConnections() returned an error: %s", err)
+		return
+	}
+	if len(connections) != 2 {
+		t.Errorf("ListAllConnections() expected 2 connections, got %d", len(connections))
+	}
+}
+
+func TestUpdateConnectionReadFromAPI(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if r.URL.Query().Get("type") != "WebhookConnection" {
+				t.Errorf("Expected the webhook connection type, got ‘%s’", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(Connection{ID: "c1", Type: "WebhookConnection", Name: "hook", URL: "https://example.com"})
+		case "PUT":
+			body, _ := ioutil.ReadAll(r.Body)
+			var conn Connection
+			if err := json.Unmarshal(body, &conn); err != nil {
+				t.Errorf("Unable to unmarshal Connection, got `%s`", body)
+			}
+			if conn.Type != ConnectionTypeWebhook {
+				t.Errorf("Expected type ‘%s’, got ‘%s’", ConnectionTypeWebhook, conn.Type)
+			}
+			json.NewEncoder(w).Encode(conn)
+		default:
+			t.Errorf("Unexpected ‘%s’ request", r.Method)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	conn, err := c.GetConnection("c1")
+	if err != nil {
+		t.Errorf("GetConnection() returned an error: %s", err)
+		return
+	}
+	conn.URL = "https://example.com/v2"
+	updated, err := c.UpdateConnection(*conn)
+	if err != nil {
+		t.Errorf("UpdateConnection() returned an error: %s", err)
+		return
+	}
+	if updated.URL != "https://example.com/v2" {
+		t.Errorf("Expected the updated URL, got ‘%s’", updated.URL)
+	}
+}
+
+func TestDeleteConnectionDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.DeleteConnection("c1"); err != ErrConnectionNotFound {
+		t.Errorf("Expected ErrConnectionNotFound, got %v", err)
+	}
+}
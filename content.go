@@ -0,0 +1,169 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Statuses of a content export or import job.
+const (
+	ContentJobStatusInProgress = "InProgress"
+	ContentJobStatusSuccess    = "Success"
+	ContentJobStatusFailed     = "Failed"
+)
+
+// ContentJobStatus is the status of a content export or import job.
+type ContentJobStatus struct {
+	Status        string                 `json:"status"`
+	StatusMessage string                 `json:"statusMessage,omitempty"`
+	Error         *ContentJobStatusError `json:"error,omitempty"`
+}
+
+// ContentJobStatusError describes why a content job failed.
+type ContentJobStatusError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ContentJobError is returned when a content export or import job fails.
+type ContentJobError struct {
+	JobID   string
+	Code    string
+	Message string
+}
+
+func (e *ContentJobError) Error() string {
+	return fmt.Sprintf("content job %s failed: %s (%s)", e.JobID, e.Message, e.Code)
+}
+
+// ErrContentNotFound is returned when content, a folder or a content job doesn't exist.
+var ErrContentNotFound = errors.New("Content not found")
+
+// contentJob is the response to starting a content job.
+type contentJob struct {
+	ID string `json:"id"`
+}
+
+// contentJobPollInterval is the delay between status checks while waiting on a content job.
+var contentJobPollInterval = time.Second
+
+// StartContentExport starts exporting the content with the specified ID, along with
+// everything in it when it's a folder, and returns the ID of the export job.
+func (c *Client) StartContentExport(id string, opts ...CallOption) (string, error) {
+	return c.startContentJob(fmt.Sprintf("../v2/content/%s/export", id), nil, opts...)
+}
+
+// GetContentExportStatus gets the status of an export job.
+func (c *Client) GetContentExportStatus(id, jobID string, opts ...CallOption) (*ContentJobStatus, error) {
+	return c.getContentJobStatus(fmt.Sprintf("../v2/content/%s/export/%s/status", id, jobID), opts...)
+}
+
+// GetContentExportResult gets the content exported by a successful export job. The
+// content is a definition such as a folder, dashboard or saved search, told apart by its
+// type field.
+func (c *Client) GetContentExportResult(id, jobID string, opts ...CallOption) (json.RawMessage, error) {
+	var content json.RawMessage
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("../v2/content/%s/export/%s/result", id, jobID),
+		out:      &content,
+		notFound: ErrContentNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// ExportContent exports the content with the specified ID, waiting for the export job
+// to finish. A failed job returns a *ContentJobError.
+func (c *Client) ExportContent(id string, opts ...CallOption) (json.RawMessage, error) {
+	jobID, err := c.StartContentExport(id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = c.waitForContentJob(jobID, func() (*ContentJobStatus, error) {
+		return c.GetContentExportStatus(id, jobID, opts...)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetContentExportResult(id, jobID, opts...)
+}
+
+// StartContentImport starts importing exported content into the folder with the
+// specified ID and returns the ID of the import job. With overwrite, content of the same
+// name in the folder is replaced; otherwise the import fails if there is any.
+func (c *Client) StartContentImport(folderID string, content json.RawMessage, overwrite bool, opts ...CallOption) (string, error) {
+	q := url.Values{}
+	q.Set("overwrite", strconv.FormatBool(overwrite))
+	return c.startContentJob(fmt.Sprintf("../v2/content/folders/%s/import?%s", folderID, q.Encode()), content, opts...)
+}
+
+// GetContentImportStatus gets the status of an import job.
+func (c *Client) GetContentImportStatus(folderID, jobID string, opts ...CallOption) (*ContentJobStatus, error) {
+	return c.getContentJobStatus(fmt.Sprintf("../v2/content/folders/%s/import/%s/status", folderID, jobID), opts...)
+}
+
+// ImportContent imports exported content into the folder with the specified ID, waiting
+// for the import job to finish. A failed job returns a *ContentJobError.
+func (c *Client) ImportContent(folderID string, content json.RawMessage, overwrite bool, opts ...CallOption) error {
+	jobID, err := c.StartContentImport(folderID, content, overwrite, opts...)
+	if err != nil {
+		return err
+	}
+	return c.waitForContentJob(jobID, func() (*ContentJobStatus, error) {
+		return c.GetContentImportStatus(folderID, jobID, opts...)
+	}, opts)
+}
+
+func (c *Client) startContentJob(path string, in interface{}, opts ...CallOption) (string, error) {
+	var job = new(contentJob)
+	err := c.do(call{
+		method:     "POST",
+		path:       path,
+		in:         in,
+		out:        job,
+		notFound:   ErrContentNotFound,
+		badRequest: errors.New("Bad Request. Please check the content is valid"),
+	}, opts)
+	if err != nil {
+		return "", err
+	}
+	return job.ID, nil
+}
+
+func (c *Client) getContentJobStatus(path string, opts ...CallOption) (*ContentJobStatus, error) {
+	var status = new(ContentJobStatus)
+	if err := c.do(call{method: "GET", path: path, out: status, notFound: ErrContentNotFound}, opts); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// waitForContentJob polls the job's status until it's no longer in progress.
+func (c *Client) waitForContentJob(jobID string, status func() (*ContentJobStatus, error), opts []CallOption) error {
+	for {
+		s, err := status()
+		if err != nil {
+			return err
+		}
+		switch s.Status {
+		case ContentJobStatusSuccess:
+			return nil
+		case ContentJobStatusFailed:
+			e := &ContentJobError{JobID: jobID, Message: s.StatusMessage}
+			if s.Error != nil {
+				e.Code, e.Message = s.Error.Code, s.Error.Message
+			}
+			return e
+		}
+		if err := sleepCallOptions(contentJobPollInterval, opts); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package sumologic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// VolatileContentFields are the fields of exported content that change without the content
+// itself changing, such as IDs and audit timestamps.
+var VolatileContentFields = []string{
+	"id",
+	"parentId",
+	"createdAt",
+	"createdBy",
+	"modifiedAt",
+	"modifiedBy",
+	"contentId",
+	"itemId",
+}
+
+// CanonicalContent returns a canonical encoding of exported content JSON: object keys are
+// sorted, insignificant whitespace is removed and the ignored fields are dropped at every
+// level. A nil ignoreFields uses VolatileContentFields.
+func CanonicalContent(data []byte, ignoreFields []string) ([]byte, error) {
+	if ignoreFields == nil {
+		ignoreFields = VolatileContentFields
+	}
+	ignore := make(map[string]bool, len(ignoreFields))
+	for _, f := range ignoreFields {
+		ignore[f] = true
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(dropContentFields(v, ignore))
+}
+
+// ContentHash returns the hex encoded SHA-256 of the canonical content, so two exports of the
+// same content hash the same regardless of key order or volatile fields.
+func ContentHash(data []byte, ignoreFields []string) (string, error) {
+	canonical, err := CanonicalContent(data, ignoreFields)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func dropContentFields(v interface{}, ignore map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if ignore[k] {
+				delete(t, k)
+				continue
+			}
+			t[k] = dropContentFields(child, ignore)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = dropContentFields(child, ignore)
+		}
+	}
+	return v
+}
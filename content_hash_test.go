@@ -0,0 +1,52 @@
+package sumologic
+
+import "testing"
+
+func TestContentHashIgnoresOrderAndVolatileFields(t *testing.T) {
+	a := `{"type": "FolderSyncDefinition", "name": "ops", "id": "0001", "children": [{"name": "errors", "modifiedAt": "2017-01-01"}]}`
+	b := `{
+		"children": [{"modifiedAt": "2018-06-01", "name": "errors"}],
+		"name": "ops",
+		"type": "FolderSyncDefinition",
+		"id": "0002"
+	}`
+
+	hashA, err := ContentHash([]byte(a), nil)
+	if err != nil {
+		t.Errorf("ContentHash() returned an error: %s", err)
+		return
+	}
+	hashB, err := ContentHash([]byte(b), nil)
+	if err != nil {
+		t.Errorf("ContentHash() returned an error: %s", err)
+		return
+	}
+	if hashA != hashB {
+		t.Errorf("Expected equal hashes, got %s and %s", hashA, hashB)
+	}
+}
+
+func TestContentHashDetectsDrift(t *testing.T) {
+	hashA, _ := ContentHash([]byte(`{"name": "ops", "children": [{"name": "a"}, {"name": "b"}]}`), nil)
+	hashB, _ := ContentHash([]byte(`{"name": "ops", "children": [{"name": "b"}, {"name": "a"}]}`), nil)
+	if hashA == hashB {
+		t.Errorf("Expected reordered children to change the hash")
+	}
+}
+
+func TestCanonicalContentCustomFields(t *testing.T) {
+	canonical, err := CanonicalContent([]byte(`{"b": 1.50, "a": "x", "id": "1", "secret": "y"}`), []string{"secret"})
+	if err != nil {
+		t.Errorf("CanonicalContent() returned an error: %s", err)
+		return
+	}
+	if string(canonical) != `{"a":"x","b":1.50,"id":"1"}` {
+		t.Errorf("CanonicalContent() returned %s", canonical)
+	}
+}
+
+func TestContentHashInvalidJSON(t *testing.T) {
+	if _, err := ContentHash([]byte(`{"name":`), nil); err == nil {
+		t.Errorf("ContentHash() did not return an error for invalid JSON")
+	}
+}
@@ -0,0 +1,98 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Content permission names. The Grant permissions allow passing the permission on.
+const (
+	ContentPermissionView        = "View"
+	ContentPermissionGrantView   = "GrantView"
+	ContentPermissionEdit        = "Edit"
+	ContentPermissionGrantEdit   = "GrantEdit"
+	ContentPermissionManage      = "Manage"
+	ContentPermissionGrantManage = "GrantManage"
+)
+
+// Types of the subjects content is shared with.
+const (
+	ContentPermissionSourceUser = "user"
+	ContentPermissionSourceRole = "role"
+	ContentPermissionSourceOrg  = "org"
+)
+
+// ContentPermissionAssignment grants a permission on content to a user, a role or the
+// whole organization, identified by SourceType and SourceID.
+type ContentPermissionAssignment struct {
+	PermissionName string `json:"permissionName"`
+	SourceType     string `json:"sourceType"`
+	SourceID       string `json:"sourceId"`
+	ContentID      string `json:"contentId"`
+}
+
+// ContentPermissions are the permissions on content: those set on it explicitly, and
+// those it inherits from its folders.
+type ContentPermissions struct {
+	ExplicitPermissions []ContentPermissionAssignment `json:"explicitPermissions"`
+	ImplicitPermissions []ContentPermissionAssignment `json:"implicitPermissions,omitempty"`
+}
+
+// ContentPermissionsRequest adds or removes permission assignments, optionally emailing
+// the users affected.
+type ContentPermissionsRequest struct {
+	ContentPermissionAssignments []ContentPermissionAssignment `json:"contentPermissionAssignments"`
+	NotifyRecipients             bool                          `json:"notifyRecipients"`
+	NotificationMessage          string                        `json:"notificationMessage,omitempty"`
+}
+
+// GetContentPermissions gets the permissions on the content with the specified ID. With
+// explicitOnly, the inherited permissions are left out.
+func (c *Client) GetContentPermissions(id string, explicitOnly bool, opts ...CallOption) (*ContentPermissions, error) {
+	q := url.Values{}
+	q.Set("explicitOnly", strconv.FormatBool(explicitOnly))
+
+	var cp = new(ContentPermissions)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("../v2/content/%s/permissions?%s", id, q.Encode()),
+		out:      cp,
+		notFound: ErrContentNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// AddContentPermissions shares the content with the specified ID. Assignments without a
+// ContentID are for that content.
+func (c *Client) AddContentPermissions(id string, cpr ContentPermissionsRequest, opts ...CallOption) error {
+	return c.updateContentPermissions(id, "add", cpr, opts)
+}
+
+// RemoveContentPermissions stops sharing the content with the specified ID. Assignments
+// without a ContentID are for that content.
+func (c *Client) RemoveContentPermissions(id string, cpr ContentPermissionsRequest, opts ...CallOption) error {
+	return c.updateContentPermissions(id, "remove", cpr, opts)
+}
+
+func (c *Client) updateContentPermissions(id, action string, cpr ContentPermissionsRequest, opts []CallOption) error {
+	assignments := make([]ContentPermissionAssignment, len(cpr.ContentPermissionAssignments))
+	for i, a := range cpr.ContentPermissionAssignments {
+		if a.ContentID == "" {
+			a.ContentID = id
+		}
+		assignments[i] = a
+	}
+	cpr.ContentPermissionAssignments = assignments
+
+	return c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("../v2/content/%s/permissions/%s", id, action),
+		in:         cpr,
+		notFound:   ErrContentNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the permissions to %s for content `%s`", action, id),
+	}, opts)
+}
@@ -0,0 +1,68 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetContentPermissions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v2/content/abc/permissions" {
+			t.Errorf("Expected request to ‘/v2/content/abc/permissions’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("explicitOnly") != "true" {
+			t.Errorf("Expected explicitOnly=true, got ‘%s’", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"explicitPermissions": [{"permissionName": "View", "sourceType": "role", "sourceId": "r1", "contentId": "abc"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	cp, err := c.GetContentPermissions("abc", true)
+	if err != nil {
+		t.Errorf("GetContentPermissions() returned an error: %s", err)
+		return
+	}
+	if len(cp.ExplicitPermissions) != 1 || cp.ExplicitPermissions[0].SourceID != "r1" {
+		t.Errorf("Expected the role's View permission, got %+v", cp)
+	}
+}
+
+func TestAddContentPermissions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected ‘PUT’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/v2/content/abc/permissions/add" {
+			t.Errorf("Expected request to ‘/v2/content/abc/permissions/add’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var cpr ContentPermissionsRequest
+		json.NewDecoder(r.Body).Decode(&cpr)
+		if len(cpr.ContentPermissionAssignments) != 1 || cpr.ContentPermissionAssignments[0].ContentID != "abc" {
+			t.Errorf("Expected the assignment to default to the content, got %+v", cpr)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	err := c.AddContentPermissions("abc", ContentPermissionsRequest{
+		ContentPermissionAssignments: []ContentPermissionAssignment{{PermissionName: ContentPermissionEdit, SourceType: ContentPermissionSourceUser, SourceID: "u1"}},
+	})
+	if err != nil {
+		t.Errorf("AddContentPermissions() returned an error: %s", err)
+	}
+}
+
+func TestRemoveContentPermissionsNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.RemoveContentPermissions("missing", ContentPermissionsRequest{}); err != ErrContentNotFound {
+		t.Errorf("Expected ErrContentNotFound, got %v", err)
+	}
+}
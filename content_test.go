@@ -0,0 +1,85 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const exportedSavedSearch = `{"type":"SavedSearchWithScheduleSyncDefinition","name":"errors","search":{"queryText":"error","defaultTimeRange":"-15m","byReceiptTime":false}}`
+
+func TestExportContent(t *testing.T) {
+	contentJobPollInterval = time.Millisecond
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/v2/content/abc/export":
+			if r.Method != "POST" {
+				t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+			}
+			if r.Header.Get("isAdminMode") != "true" {
+				t.Errorf("Expected the export to be made in admin mode")
+			}
+			w.Write([]byte(`{"id": "job1"}`))
+		case "/v2/content/abc/export/job1/status":
+			polls++
+			if polls < 3 {
+				w.Write([]byte(`{"status": "InProgress"}`))
+				return
+			}
+			w.Write([]byte(`{"status": "Success"}`))
+		case "/v2/content/abc/export/job1/result":
+			w.Write([]byte(exportedSavedSearch))
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	content, err := c.ExportContent("abc", WithAdminMode())
+	if err != nil {
+		t.Errorf("ExportContent() returned an error: %s", err)
+		return
+	}
+	var search LegacySavedSearch
+	if err := json.Unmarshal(content, &search); err != nil || search.Search.QueryText != "error" {
+		t.Errorf("Expected the exported saved search, got %s", content)
+	}
+	if polls != 3 {
+		t.Errorf("Expected the status to be polled until the job succeeded, got %d polls", polls)
+	}
+}
+
+func TestImportContent(t *testing.T) {
+	contentJobPollInterval = time.Millisecond
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/v2/content/folders/f1/import":
+			if r.URL.Query().Get("overwrite") != "true" {
+				t.Errorf("Expected overwrite=true, got ‘%s’", r.URL.RawQuery)
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			if string(body) != exportedSavedSearch {
+				t.Errorf("Expected the content to be sent as is, got %s", body)
+			}
+			w.Write([]byte(`{"id": "job2"}`))
+		case "/v2/content/folders/f1/import/job2/status":
+			w.Write([]byte(`{"status": "Failed", "error": {"code": "content:duplicate", "message": "Content already exists"}}`))
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	err := c.ImportContent("f1", json.RawMessage(exportedSavedSearch), true)
+	var jobErr *ContentJobError
+	if !errors.As(err, &jobErr) || jobErr.JobID != "job2" || jobErr.Code != "content:duplicate" {
+		t.Errorf("Expected a *ContentJobError for the failed import, got %v", err)
+	}
+}
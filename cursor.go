@@ -0,0 +1,104 @@
+package sumologic
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// cursorData is additional data sealed into every cursor, so the key can't be mistaken for
+// one encrypting something else.
+var cursorData = []byte("sumologic cursor v1")
+
+var (
+	// ErrInvalidCursor is returned when a cursor is malformed, was tampered with or was
+	// made with a different key.
+	ErrInvalidCursor = errors.New("Pagination cursor is invalid")
+	// ErrCursorExpired is returned when a cursor is past its expiry.
+	ErrCursorExpired = errors.New("Pagination cursor has expired")
+)
+
+// ResultCursor is the position of a page of search job results, for backends that give
+// their own users cursor pagination over a search. Records is set for the records of an
+// aggregate search rather than its messages.
+type ResultCursor struct {
+	JobID   string    `json:"j"`
+	Offset  int       `json:"o"`
+	Records bool      `json:"r,omitempty"`
+	Expires time.Time `json:"e"`
+}
+
+// Next returns the cursor of the page after one of count results.
+func (rc ResultCursor) Next(count int) ResultCursor {
+	rc.Offset += count
+	return rc
+}
+
+// Request returns the request for the page of up to limit results at the cursor.
+func (rc ResultCursor) Request(limit int) SearchJobResultsRequest {
+	return SearchJobResultsRequest{ID: rc.JobID, Offset: rc.Offset, Limit: limit}
+}
+
+// CursorCodec turns ResultCursors into opaque strings and back. Cursors are encrypted and
+// authenticated with AES-GCM, so users can neither read the search job ID nor forge a
+// cursor for another job or offset. It's safe for concurrent use.
+type CursorCodec struct {
+	aead cipher.AEAD
+	ttl  time.Duration
+}
+
+// NewCursorCodec returns a CursorCodec whose cursors expire ttl after they're made. The key
+// must be 16, 24 or 32 bytes long and shared by every backend instance decoding the cursors.
+func NewCursorCodec(key []byte, ttl time.Duration) (*CursorCodec, error) {
+	aead, err := newResultsAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &CursorCodec{aead: aead, ttl: ttl}, nil
+}
+
+// Encode returns the opaque cursor for rc. Its expiry is set from the codec's ttl unless
+// it already has one.
+func (cc *CursorCodec) Encode(rc ResultCursor) (string, error) {
+	if rc.Expires.IsZero() {
+		rc.Expires = time.Now().Add(cc.ttl)
+	}
+	rc.Expires = rc.Expires.UTC().Truncate(time.Second)
+	plain, err := json.Marshal(rc)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, cc.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := cc.aead.Seal(nonce, nonce, plain, cursorData)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decode validates an opaque cursor and returns its position. It returns ErrInvalidCursor
+// when the cursor wasn't made by a codec with the same key and ErrCursorExpired when it's
+// past its expiry.
+func (cc *CursorCodec) Decode(cursor string) (*ResultCursor, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(sealed) < cc.aead.NonceSize() {
+		return nil, ErrInvalidCursor
+	}
+	nonce, ciphertext := sealed[:cc.aead.NonceSize()], sealed[cc.aead.NonceSize():]
+	plain, err := cc.aead.Open(nil, nonce, ciphertext, cursorData)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var rc = new(ResultCursor)
+	if err := json.Unmarshal(plain, &rc); err != nil || rc.JobID == "" || rc.Offset < 0 {
+		return nil, ErrInvalidCursor
+	}
+	if time.Now().After(rc.Expires) {
+		return nil, ErrCursorExpired
+	}
+	return rc, nil
+}
@@ -0,0 +1,57 @@
+package sumologic
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+	cc, err := NewCursorCodec([]byte("0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Errorf("NewCursorCodec() returned an error: %s", err)
+		return
+	}
+	cursor, err := cc.Encode(ResultCursor{JobID: "job1", Offset: 100})
+	if err != nil {
+		t.Errorf("Encode() returned an error: %s", err)
+		return
+	}
+	if strings.Contains(cursor, "job1") {
+		t.Errorf("Expected an opaque cursor, got %s", cursor)
+	}
+
+	rc, err := cc.Decode(cursor)
+	if err != nil {
+		t.Errorf("Decode() returned an error: %s", err)
+		return
+	}
+	if rc.JobID != "job1" || rc.Offset != 100 {
+		t.Errorf("Expected job1 at offset 100, got %+v", rc)
+	}
+	if req := rc.Next(50).Request(50); req.ID != "job1" || req.Offset != 150 || req.Limit != 50 {
+		t.Errorf("Expected the request of the next page, got %+v", req)
+	}
+}
+
+func TestCursorCodecRejectsInvalidCursors(t *testing.T) {
+	cc, _ := NewCursorCodec([]byte("0123456789abcdef"), time.Hour)
+	other, _ := NewCursorCodec([]byte("fedcba9876543210"), time.Hour)
+	cursor, _ := cc.Encode(ResultCursor{JobID: "job1"})
+
+	tampered := []byte(cursor)
+	tampered[len(tampered)/2] ^= 1
+	for _, c := range []string{"", "not a cursor", string(tampered)} {
+		if _, err := cc.Decode(c); err != ErrInvalidCursor {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", c, err)
+		}
+	}
+	if _, err := other.Decode(cursor); err != ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor for a cursor made with another key, got %v", err)
+	}
+
+	expired, _ := cc.Encode(ResultCursor{JobID: "job1", Expires: time.Now().Add(-time.Minute)})
+	if _, err := cc.Decode(expired); err != ErrCursorExpired {
+		t.Errorf("Expected ErrCursorExpired, got %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LegacyDashboard is a classic dashboard as exported by the content API
+// (type DashboardSyncDefinition).
+type LegacyDashboard struct {
+	Type        string                  `json:"type"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	DetailLevel int                     `json:"detailLevel"`
+	Properties  string                  `json:"properties"`
+	Panels      []LegacyDashboardPanel  `json:"panels"`
+	Filters     []LegacyDashboardFilter `json:"filters"`
+}
+
+// LegacyDashboardPanel is one panel of a classic dashboard.
+type LegacyDashboardPanel struct {
+	Name           string               `json:"name"`
+	ViewerType     string               `json:"viewerType"`
+	DetailLevel    int                  `json:"detailLevel"`
+	QueryString    string               `json:"queryString"`
+	MetricsQueries []LegacyMetricsQuery `json:"metricsQueries"`
+	TimeRange      json.RawMessage      `json:"timeRange"`
+	X              int                  `json:"x"`
+	Y              int                  `json:"y"`
+	Width          int                  `json:"width"`
+	Height         int                  `json:"height"`
+	Properties     string               `json:"properties"`
+}
+
+// LegacyMetricsQuery is a metrics query of a classic dashboard panel.
+type LegacyMetricsQuery struct {
+	Query string `json:"query"`
+	RowID string `json:"rowId"`
+}
+
+// LegacyDashboardFilter is a field filter of a classic dashboard.
+type LegacyDashboardFilter struct {
+	FieldName    string   `json:"fieldName"`
+	Label        string   `json:"label"`
+	DefaultValue string   `json:"defaultValue"`
+	FilterType   string   `json:"filterType"`
+	Properties   string   `json:"properties"`
+	PanelIDs     []string `json:"panelIds"`
+}
+
+// LegacySavedSearch is a saved search as exported by the content API
+// (type SavedSearchWithScheduleSyncDefinition).
+type LegacySavedSearch struct {
+	Type        string       `json:"type"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Search      LegacySearch `json:"search"`
+}
+
+// LegacySearch is the query of a saved search.
+type LegacySearch struct {
+	QueryText        string `json:"queryText"`
+	DefaultTimeRange string `json:"defaultTimeRange"`
+	ByReceiptTime    bool   `json:"byReceiptTime"`
+	ViewName         string `json:"viewName,omitempty"`
+	ViewStartTime    string `json:"viewStartTime,omitempty"`
+	ParsingMode      string `json:"parsingMode,omitempty"`
+}
+
+// Content export types of the legacy definitions.
+const (
+	LegacyDashboardType   = "DashboardSyncDefinition"
+	LegacySavedSearchType = "SavedSearchWithScheduleSyncDefinition"
+)
+
+// dashboardVisualSettings is the part of a panel's visual settings the converters use.
+type dashboardVisualSettings struct {
+	General struct {
+		Type string `json:"type"`
+	} `json:"general"`
+}
+
+// ConvertLegacyDashboard converts a classic dashboard export into the v2 dashboard schema.
+// Panel positions are copied as is and filters become CSV variables holding the filter's
+// default value; queries need to reference the variables as {{name}} to use them.
+func ConvertLegacyDashboard(ld LegacyDashboard) (*Dashboard, error) {
+	d := &Dashboard{
+		Title:       ld.Name,
+		Description: ld.Description,
+		Layout:      DashboardLayout{LayoutType: "Grid"},
+	}
+
+	for i, lp := range ld.Panels {
+		key := fmt.Sprintf("panel%d", i+1)
+
+		var vs dashboardVisualSettings
+		vs.General.Type = lp.ViewerType
+		visualSettings, err := json.Marshal(vs)
+		if err != nil {
+			return nil, err
+		}
+
+		panel := DashboardPanel{
+			Key:                                    key,
+			Title:                                  lp.Name,
+			VisualSettings:                         string(visualSettings),
+			PanelType:                              DashboardPanelTypeSearch,
+			TimeRange:                              lp.TimeRange,
+			KeepVisualSettingsConsistentWithParent: true,
+		}
+		if len(lp.MetricsQueries) > 0 {
+			for j, mq := range lp.MetricsQueries {
+				panel.Queries = append(panel.Queries, DashboardQuery{
+					QueryString:      mq.Query,
+					QueryType:        DashboardQueryTypeMetrics,
+					QueryKey:         queryKey(j),
+					MetricsQueryMode: "Advanced",
+				})
+			}
+		} else {
+			panel.Queries = []DashboardQuery{{
+				QueryString: lp.QueryString,
+				QueryType:   DashboardQueryTypeLogs,
+				QueryKey:    queryKey(0),
+			}}
+		}
+		d.Panels = append(d.Panels, panel)
+
+		structure, err := json.Marshal(DashboardGridPosition{
+			Height: lp.Height,
+			Width:  lp.Width,
+			X:      lp.X,
+			Y:      lp.Y,
+		})
+		if err != nil {
+			return nil, err
+		}
+		d.Layout.LayoutStructures = append(d.Layout.LayoutStructures, DashboardLayoutStructure{
+			Key:       key,
+			Structure: string(structure),
+		})
+	}
+
+	for _, f := range ld.Filters {
+		d.Variables = append(d.Variables, DashboardVariable{
+			Name:             f.FieldName,
+			DisplayName:      f.Label,
+			DefaultValue:     f.DefaultValue,
+			IncludeAllOption: true,
+			SourceDefinition: DashboardVariableSource{
+				VariableSourceType: DashboardVariableSourceCSV,
+				Values:             f.DefaultValue,
+			},
+		})
+	}
+	return d, nil
+}
+
+// ConvertLegacySavedSearch converts a saved search export into a v2 dashboard with a
+// single panel running the search over its default time range.
+func ConvertLegacySavedSearch(ls LegacySavedSearch) (*Dashboard, error) {
+	var timeRange json.RawMessage
+	if ls.Search.DefaultTimeRange != "" {
+		tr, err := json.Marshal(map[string]interface{}{
+			"type": "BeginBoundedTimeRange",
+			"from": map[string]string{
+				"type":         "RelativeTimeRangeBoundary",
+				"relativeTime": ls.Search.DefaultTimeRange,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		timeRange = tr
+	}
+
+	return ConvertLegacyDashboard(LegacyDashboard{
+		Name:        ls.Name,
+		Description: ls.Description,
+		Panels: []LegacyDashboardPanel{{
+			Name:        ls.Name,
+			ViewerType:  "table",
+			QueryString: ls.Search.QueryText,
+			TimeRange:   timeRange,
+			Width:       24,
+			Height:      12,
+		}},
+	})
+}
+
+// ConvertDashboardToLegacy converts a v2 dashboard into the classic dashboard export format.
+// Only the first logs query of a panel is kept; text panels and panels without a layout
+// structure are returned as errors since classic dashboards can't represent them.
+func ConvertDashboardToLegacy(d Dashboard) (*LegacyDashboard, error) {
+	ld := &LegacyDashboard{
+		Type:        LegacyDashboardType,
+		Name:        d.Title,
+		Description: d.Description,
+		DetailLevel: 2,
+		Properties:  "{}",
+	}
+
+	positions := make(map[string]DashboardGridPosition)
+	for _, ls := range d.Layout.LayoutStructures {
+		var pos DashboardGridPosition
+		if err := json.Unmarshal([]byte(ls.Structure), &pos); err != nil {
+			return nil, fmt.Errorf("invalid layout structure for panel `%s`: %s", ls.Key, err)
+		}
+		positions[ls.Key] = pos
+	}
+
+	for _, p := range d.Panels {
+		if p.PanelType != DashboardPanelTypeSearch {
+			return nil, fmt.Errorf("panel `%s` of type `%s` can't be converted", p.Title, p.PanelType)
+		}
+		pos, ok := positions[p.Key]
+		if !ok {
+			return nil, fmt.Errorf("panel `%s` has no layout structure", p.Title)
+		}
+
+		lp := LegacyDashboardPanel{
+			Name:        p.Title,
+			DetailLevel: 2,
+			TimeRange:   p.TimeRange,
+			X:           pos.X,
+			Y:           pos.Y,
+			Width:       pos.Width,
+			Height:      pos.Height,
+			Properties:  "{}",
+		}
+		if p.VisualSettings != "" {
+			var vs dashboardVisualSettings
+			if err := json.Unmarshal([]byte(p.VisualSettings), &vs); err == nil {
+				lp.ViewerType = vs.General.Type
+			}
+		}
+		for _, q := range p.Queries {
+			switch q.QueryType {
+			case DashboardQueryTypeMetrics:
+				lp.MetricsQueries = append(lp.MetricsQueries, LegacyMetricsQuery{
+					Query: q.QueryString,
+					RowID: q.QueryKey,
+				})
+			case DashboardQueryTypeLogs:
+				if lp.QueryString == "" {
+					lp.QueryString = q.QueryString
+				}
+			}
+		}
+		ld.Panels = append(ld.Panels, lp)
+	}
+
+	for _, v := range d.Variables {
+		ld.Filters = append(ld.Filters, LegacyDashboardFilter{
+			FieldName:    v.Name,
+			Label:        v.DisplayName,
+			DefaultValue: v.DefaultValue,
+			FilterType:   "TEXT_BOX_FILTER",
+			Properties:   "{}",
+		})
+	}
+	return ld, nil
+}
+
+// queryKey returns the key of the i-th query of a panel: A, B, ..., Z, AA, AB, ...
+func queryKey(i int) string {
+	key := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		key = string(rune('A'+(i-1)%26)) + key
+	}
+	return key
+}
@@ -0,0 +1,122 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const legacyDashboardExport = `{
+	"type": "DashboardSyncDefinition",
+	"name": "Checkout",
+	"description": "Checkout service",
+	"detailLevel": 2,
+	"properties": "{}",
+	"panels": [
+		{
+			"name": "Errors",
+			"viewerType": "line",
+			"queryString": "_sourceCategory=checkout error | timeslice 1m | count by _timeslice",
+			"x": 0, "y": 0, "width": 6, "height": 5
+		},
+		{
+			"name": "Latency",
+			"viewerType": "area",
+			"metricsQueries": [{"query": "metric=latency", "rowId": "A"}, {"query": "metric=p99", "rowId": "B"}],
+			"x": 6, "y": 0, "width": 6, "height": 5
+		}
+	],
+	"filters": [
+		{"fieldName": "host", "label": "Host", "defaultValue": "*", "filterType": "TEXT_BOX_FILTER"}
+	]
+}`
+
+func TestConvertLegacyDashboard(t *testing.T) {
+	var ld LegacyDashboard
+	if err := json.Unmarshal([]byte(legacyDashboardExport), &ld); err != nil {
+		t.Errorf("Unable to unmarshal the legacy dashboard: %s", err)
+		return
+	}
+
+	d, err := ConvertLegacyDashboard(ld)
+	if err != nil {
+		t.Errorf("ConvertLegacyDashboard() returned an error: %s", err)
+		return
+	}
+	if d.Title != "Checkout" || len(d.Panels) != 2 || len(d.Layout.LayoutStructures) != 2 {
+		t.Errorf("ConvertLegacyDashboard() returned an unexpected dashboard: %v", d)
+		return
+	}
+	if q := d.Panels[0].Queries; len(q) != 1 || q[0].QueryType != DashboardQueryTypeLogs || q[0].QueryKey != "A" {
+		t.Errorf("Expected a single logs query, got %v", q)
+	}
+	if q := d.Panels[1].Queries; len(q) != 2 || q[1].QueryType != DashboardQueryTypeMetrics || q[1].QueryKey != "B" {
+		t.Errorf("Expected two metrics queries, got %v", q)
+	}
+	var pos DashboardGridPosition
+	json.Unmarshal([]byte(d.Layout.LayoutStructures[1].Structure), &pos)
+	if pos.X != 6 || pos.Width != 6 {
+		t.Errorf("Expected the second panel at x 6 with width 6, got %v", pos)
+	}
+	if len(d.Variables) != 1 || d.Variables[0].Name != "host" {
+		t.Errorf("Expected the host filter to become a variable, got %v", d.Variables)
+	}
+}
+
+func TestConvertDashboardRoundTrip(t *testing.T) {
+	var ld LegacyDashboard
+	json.Unmarshal([]byte(legacyDashboardExport), &ld)
+
+	d, err := ConvertLegacyDashboard(ld)
+	if err != nil {
+		t.Errorf("ConvertLegacyDashboard() returned an error: %s", err)
+		return
+	}
+	back, err := ConvertDashboardToLegacy(*d)
+	if err != nil {
+		t.Errorf("ConvertDashboardToLegacy() returned an error: %s", err)
+		return
+	}
+	if back.Type != LegacyDashboardType || len(back.Panels) != 2 {
+		t.Errorf("ConvertDashboardToLegacy() returned an unexpected dashboard: %v", back)
+		return
+	}
+	for i := range ld.Panels {
+		if back.Panels[i].QueryString != ld.Panels[i].QueryString ||
+			back.Panels[i].ViewerType != ld.Panels[i].ViewerType ||
+			back.Panels[i].X != ld.Panels[i].X ||
+			len(back.Panels[i].MetricsQueries) != len(ld.Panels[i].MetricsQueries) {
+			t.Errorf("Panel %d didn't survive the round trip: %v", i, back.Panels[i])
+		}
+	}
+}
+
+func TestConvertDashboardToLegacyTextPanel(t *testing.T) {
+	_, err := ConvertDashboardToLegacy(Dashboard{
+		Panels: []DashboardPanel{{Key: "panel1", Title: "Notes", PanelType: DashboardPanelTypeText}},
+	})
+	if err == nil {
+		t.Errorf("ConvertDashboardToLegacy() did not return an error for a text panel")
+	}
+}
+
+func TestConvertLegacySavedSearch(t *testing.T) {
+	d, err := ConvertLegacySavedSearch(LegacySavedSearch{
+		Name:   "Errors",
+		Search: LegacySearch{QueryText: "error", DefaultTimeRange: "-15m"},
+	})
+	if err != nil {
+		t.Errorf("ConvertLegacySavedSearch() returned an error: %s", err)
+		return
+	}
+	if len(d.Panels) != 1 || d.Panels[0].Queries[0].QueryString != "error" || len(d.Panels[0].TimeRange) == 0 {
+		t.Errorf("ConvertLegacySavedSearch() returned an unexpected dashboard: %v", d)
+	}
+}
+
+func TestQueryKey(t *testing.T) {
+	for i, expected := range map[int]string{0: "A", 1: "B", 25: "Z", 26: "AA", 27: "AB"} {
+		if key := queryKey(i); key != expected {
+			t.Errorf("queryKey(%d) expected %s, got %s", i, expected, key)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Dashboard is a dashboard in the v2 (Dashboards New) schema.
+type Dashboard struct {
+	ID               string              `json:"id,omitempty"`
+	Title            string              `json:"title"`
+	Description      string              `json:"description,omitempty"`
+	FolderID         string              `json:"folderId,omitempty"`
+	TopologyLabelMap json.RawMessage     `json:"topologyLabelMap,omitempty"`
+	Domain           string              `json:"domain,omitempty"`
+	RefreshInterval  int                 `json:"refreshInterval,omitempty"`
+	TimeRange        json.RawMessage     `json:"timeRange,omitempty"`
+	Panels           []DashboardPanel    `json:"panels"`
+	Layout           DashboardLayout     `json:"layout"`
+	Variables        []DashboardVariable `json:"variables,omitempty"`
+	Theme            string              `json:"theme,omitempty"`
+}
+
+// DashboardPanel is one panel of a dashboard. Its Key ties it to a layout structure.
+type DashboardPanel struct {
+	ID                                     string           `json:"id,omitempty"`
+	Key                                    string           `json:"key"`
+	Title                                  string           `json:"title"`
+	VisualSettings                         string           `json:"visualSettings,omitempty"`
+	KeepVisualSettingsConsistentWithParent bool             `json:"keepVisualSettingsConsistentWithParent"`
+	PanelType                              string           `json:"panelType"`
+	Queries                                []DashboardQuery `json:"queries,omitempty"`
+	Description                            string           `json:"description,omitempty"`
+	TimeRange                              json.RawMessage  `json:"timeRange,omitempty"`
+	Text                                   string           `json:"text,omitempty"`
+}
+
+// Dashboard panel types.
+const (
+	DashboardPanelTypeSearch = "SumoSearchPanel"
+	DashboardPanelTypeText   = "TextPanel"
+)
+
+// DashboardQuery is a logs or metrics query of a panel, identified by its QueryKey (A, B, ...).
+type DashboardQuery struct {
+	QueryString      string `json:"queryString"`
+	QueryType        string `json:"queryType"`
+	QueryKey         string `json:"queryKey"`
+	MetricsQueryMode string `json:"metricsQueryMode,omitempty"`
+	ParseMode        string `json:"parseMode,omitempty"`
+	TimeSource       string `json:"timeSource,omitempty"`
+}
+
+// Dashboard query types.
+const (
+	DashboardQueryTypeLogs    = "Logs"
+	DashboardQueryTypeMetrics = "Metrics"
+)
+
+// DashboardLayout positions the panels of a dashboard.
+type DashboardLayout struct {
+	LayoutType       string                     `json:"layoutType"`
+	LayoutStructures []DashboardLayoutStructure `json:"layoutStructures"`
+}
+
+// DashboardLayoutStructure holds the position of the panel with the same key.
+// Structure is a JSON encoded DashboardGridPosition for grid layouts.
+type DashboardLayoutStructure struct {
+	Key       string `json:"key"`
+	Structure string `json:"structure"`
+}
+
+// DashboardGridPosition is the position and size of a panel in a grid layout.
+type DashboardGridPosition struct {
+	Height int `json:"height"`
+	Width  int `json:"width"`
+	X      int `json:"x"`
+	Y      int `json:"y"`
+}
+
+// DashboardVariable is a dashboard variable that queries reference as {{name}}.
+type DashboardVariable struct {
+	ID               string                  `json:"id,omitempty"`
+	Name             string                  `json:"name"`
+	DisplayName      string                  `json:"displayName,omitempty"`
+	DefaultValue     string                  `json:"defaultValue,omitempty"`
+	SourceDefinition DashboardVariableSource `json:"sourceDefinition"`
+	AllowMultiSelect bool                    `json:"allowMultiSelect"`
+	IncludeAllOption bool                    `json:"includeAllOption"`
+	HideFromUI       bool                    `json:"hideFromUI"`
+}
+
+// DashboardVariableSource defines where the values of a variable come from.
+// Query and Field are used by log query sources, Values by CSV sources.
+type DashboardVariableSource struct {
+	VariableSourceType string `json:"variableSourceType"`
+	Query              string `json:"query,omitempty"`
+	Field              string `json:"field,omitempty"`
+	Values             string `json:"values,omitempty"`
+}
+
+// Dashboard variable source types.
+const (
+	DashboardVariableSourceLogQuery = "LogQueryVariableSourceDefinition"
+	DashboardVariableSourceCSV      = "CsvVariableSourceDefinition"
+)
+
+// DashboardList is one page of dashboards. Next is the token for the following page
+// and is empty on the last page.
+type DashboardList struct {
+	Dashboards []Dashboard `json:"dashboards"`
+	Next       string      `json:"next,omitempty"`
+}
+
+// ErrDashboardNotFound is returned when a dashboard doesn't exist on a Read, Update or Delete.
+var ErrDashboardNotFound = errors.New("Dashboard not found")
+
+// ListDashboards returns one page of dashboards. A limit of 0 uses the API default.
+func (c *Client) ListDashboards(limit int, token string, opts ...CallOption) (*DashboardList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "../v2/dashboards"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var dl = new(DashboardList)
+	if err := c.do(call{method: "GET", path: path, out: dl}, opts); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+// ListAllDashboards follows the pagination tokens and returns every dashboard.
+func (c *Client) ListAllDashboards(opts ...CallOption) ([]Dashboard, error) {
+	var dashboards []Dashboard
+	token := ""
+	for {
+		dl, err := c.ListDashboards(0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		dashboards = append(dashboards, dl.Dashboards...)
+		if dl.Next == "" {
+			return dashboards, nil
+		}
+		token = dl.Next
+	}
+}
+
+// GetDashboard gets the dashboard with the specified ID.
+func (c *Client) GetDashboard(id string, opts ...CallOption) (*Dashboard, error) {
+	var d = new(Dashboard)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("../v2/dashboards/%s", id),
+		out:      d,
+		notFound: ErrDashboardNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// CreateDashboard creates a dashboard in the folder set by its FolderID, or the caller's
+// personal folder when it's empty.
+func (c *Client) CreateDashboard(dashboard Dashboard, opts ...CallOption) (*Dashboard, error) {
+	var d = new(Dashboard)
+	err := c.do(call{
+		method:     "POST",
+		path:       "../v2/dashboards",
+		in:         dashboard,
+		out:        d,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check the dashboard `%s` is valid", dashboard.Title),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// UpdateDashboard replaces the dashboard with the same ID.
+func (c *Client) UpdateDashboard(dashboard Dashboard, opts ...CallOption) (*Dashboard, error) {
+	var d = new(Dashboard)
+	err := c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("../v2/dashboards/%s", dashboard.ID),
+		in:         dashboard,
+		out:        d,
+		notFound:   ErrDashboardNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the dashboard `%s` is valid", dashboard.Title),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DeleteDashboard deletes the dashboard with the specified ID.
+func (c *Client) DeleteDashboard(id string, opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("../v2/dashboards/%s", id), notFound: ErrDashboardNotFound}, opts)
+}
+
+// Dashboards returns a ResourceClient for dashboards.
+func (c *Client) Dashboards(opts ...CallOption) ResourceClient[Dashboard] {
+	return &resourceClient[Dashboard]{
+		list: func() ([]Dashboard, error) {
+			return c.ListAllDashboards(opts...)
+		},
+		get: func(id string) (*Dashboard, error) {
+			return c.GetDashboard(id, opts...)
+		},
+		create: func(d Dashboard) (*Dashboard, error) {
+			return c.CreateDashboard(d, opts...)
+		},
+		update: func(d Dashboard) (*Dashboard, error) {
+			return c.UpdateDashboard(d, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteDashboard(id, opts...)
+		},
+	}
+}
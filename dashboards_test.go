@@ -0,0 +1,95 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListAllDashboardsFollowsToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v2/dashboards" {
+			t.Errorf("Expected request to ‘/v2/dashboards’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var dl DashboardList
+		switch r.URL.Query().Get("token") {
+		case "":
+			dl = DashboardList{Dashboards: []Dashboard{{ID: "1", Title: "one"}}, Next: "page2"}
+		case "page2":
+			dl = DashboardList{Dashboards: []Dashboard{{ID: "2", Title: "two"}}}
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		body, _ := json.Marshal(dl)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	dashboards, err := c.ListAllDashboards()
+	if err != nil {
+		t.Errorf("ListAllDashboards() returned an error: %s", err)
+		return
+	}
+	if len(dashboards) != 2 {
+		t.Errorf("ListAllDashboards() expected 2 dashboards, got %d", len(dashboards))
+	}
+}
+
+func TestCreateDashboard(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var d Dashboard
+		if err := json.Unmarshal(body, &d); err != nil {
+			t.Errorf("Unable to unmarshal Dashboard, got `%s`", body)
+		}
+		if len(d.Panels) != 1 || d.Panels[0].Queries[0].QueryKey != "A" {
+			t.Errorf("Expected the panel and its query to be sent, got `%s`", body)
+		}
+		d.ID = "d1"
+		json.NewEncoder(w).Encode(d)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	d, err := c.CreateDashboard(Dashboard{
+		Title: "Checkout",
+		Panels: []DashboardPanel{{
+			Key:       "panel1",
+			Title:     "Errors",
+			PanelType: DashboardPanelTypeSearch,
+			Queries:   []DashboardQuery{{QueryString: "error", QueryType: DashboardQueryTypeLogs, QueryKey: "A"}},
+		}},
+		Layout: DashboardLayout{LayoutType: "Grid"},
+	})
+	if err != nil {
+		t.Errorf("CreateDashboard() returned an error: %s", err)
+		return
+	}
+	if d.ID != "d1" {
+		t.Errorf("Expected the created dashboard's ID, got ‘%s’", d.ID)
+	}
+}
+
+func TestDeleteDashboardDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/v2/dashboards/d1" {
+			t.Errorf("Expected request to ‘/v2/dashboards/d1’, got ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.Dashboards().Delete("d1"); err != ErrDashboardNotFound {
+		t.Errorf("Delete() returned the wrong error: %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DataForwardingDestination is an S3 bucket that partitions and scheduled views forward data to.
+type DataForwardingDestination struct {
+	ID                     string `json:"id,omitempty"`
+	DestinationName        string `json:"destinationName"`
+	Description            string `json:"description,omitempty"`
+	BucketName             string `json:"bucketName"`
+	S3Region               string `json:"s3Region,omitempty"`
+	AuthenticationMode     string `json:"authenticationMode"`
+	AccessKeyID            string `json:"accessKeyId,omitempty"`
+	SecretAccessKey        string `json:"secretAccessKey,omitempty"`
+	RoleARN                string `json:"roleArn,omitempty"`
+	Encoded                bool   `json:"encoded,omitempty"`
+	Enabled                bool   `json:"enabled"`
+	S3ServerSideEncryption bool   `json:"s3ServerSideEncryption,omitempty"`
+	CreatedAt              string `json:"createdAt,omitempty"`
+	CreatedBy              string `json:"createdBy,omitempty"`
+	ModifiedAt             string `json:"modifiedAt,omitempty"`
+	ModifiedBy             string `json:"modifiedBy,omitempty"`
+}
+
+// Authentication modes for data forwarding destinations.
+const (
+	DataForwardingAuthAccessKey = "AccessKey"
+	DataForwardingAuthRoleBased = "RoleBased"
+)
+
+// CreateDataForwardingDestination creates a new data forwarding destination.
+// Use ValidateS3Destination first to catch credential problems that the API accepts
+// but that make forwarding fail silently later.
+func (c *Client) CreateDataForwardingDestination(d DataForwardingDestination, opts ...CallOption) (*DataForwardingDestination, error) {
+	var dfd = new(DataForwardingDestination)
+	err := c.do(call{
+		method:     "POST",
+		path:       "logsDataForwarding/destinations",
+		in:         d,
+		out:        dfd,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for destination `%s`", d.DestinationName),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return dfd, nil
+}
+
+// DataForwardingDestinationList is one page of data forwarding destinations. Next is the
+// token for the following page and is empty on the last page.
+type DataForwardingDestinationList struct {
+	Data []DataForwardingDestination `json:"data"`
+	Next string                      `json:"next,omitempty"`
+}
+
+// DataForwardingRule forwards the data of a partition or scheduled view, identified by
+// IndexID, to a destination. Rules are keyed by their index.
+type DataForwardingRule struct {
+	ID            string `json:"id,omitempty"`
+	IndexID       string `json:"indexId"`
+	DestinationID string `json:"destinationId"`
+	Enabled       bool   `json:"enabled"`
+	FileFormat    string `json:"fileFormat,omitempty"`
+	PayloadSchema string `json:"payloadSchema,omitempty"`
+	Format        string `json:"format,omitempty"`
+	CreatedAt     string `json:"createdAt,omitempty"`
+	CreatedBy     string `json:"createdBy,omitempty"`
+	ModifiedAt    string `json:"modifiedAt,omitempty"`
+	ModifiedBy    string `json:"modifiedBy,omitempty"`
+}
+
+// Payload schemas and formats of forwarded files.
+const (
+	DataForwardingSchemaBuiltIn = "builtInFields"
+	DataForwardingSchemaRaw     = "raw"
+	DataForwardingFormatCSV     = "Csv"
+	DataForwardingFormatText    = "Text"
+)
+
+// DataForwardingRuleList is one page of data forwarding rules. Next is the token for the
+// following page and is empty on the last page.
+type DataForwardingRuleList struct {
+	Data []DataForwardingRule `json:"data"`
+	Next string               `json:"next,omitempty"`
+}
+
+var (
+	// ErrDataForwardingDestinationNotFound is returned when a data forwarding destination doesn't exist.
+	ErrDataForwardingDestinationNotFound = errors.New("Data forwarding destination not found")
+	// ErrDataForwardingRuleNotFound is returned when an index has no data forwarding rule.
+	ErrDataForwardingRuleNotFound = errors.New("Data forwarding rule not found")
+)
+
+// ListDataForwardingDestinations returns one page of data forwarding destinations.
+// A limit of 0 uses the API default.
+func (c *Client) ListDataForwardingDestinations(limit int, token string, opts ...CallOption) (*DataForwardingDestinationList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "logsDataForwarding/destinations"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var dl = new(DataForwardingDestinationList)
+	if err := c.do(call{method: "GET", path: path, out: dl}, opts); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+// ListAllDataForwardingDestinations follows the pagination tokens and returns every data
+// forwarding destination.
+func (c *Client) ListAllDataForwardingDestinations(opts ...CallOption) ([]DataForwardingDestination, error) {
+	var destinations []DataForwardingDestination
+	token := ""
+	for {
+		dl, err := c.ListDataForwardingDestinations(0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, dl.Data...)
+		if dl.Next == "" {
+			return destinations, nil
+		}
+		token = dl.Next
+	}
+}
+
+// GetDataForwardingDestination gets the data forwarding destination with the specified ID.
+func (c *Client) GetDataForwardingDestination(id string, opts ...CallOption) (*DataForwardingDestination, error) {
+	var dfd = new(DataForwardingDestination)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("logsDataForwarding/destinations/%s", id),
+		out:      dfd,
+		notFound: ErrDataForwardingDestinationNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return dfd, nil
+}
+
+// UpdateDataForwardingDestination updates the data forwarding destination with the same ID.
+func (c *Client) UpdateDataForwardingDestination(d DataForwardingDestination, opts ...CallOption) (*DataForwardingDestination, error) {
+	var dfd = new(DataForwardingDestination)
+	err := c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("logsDataForwarding/destinations/%s", d.ID),
+		in:         d,
+		out:        dfd,
+		notFound:   ErrDataForwardingDestinationNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for destination `%s`", d.DestinationName),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return dfd, nil
+}
+
+// DeleteDataForwardingDestination deletes the data forwarding destination with the
+// specified ID. Destinations used by a rule can't be deleted.
+func (c *Client) DeleteDataForwardingDestination(id string, opts ...CallOption) error {
+	return c.deleteDataForwarding(fmt.Sprintf("logsDataForwarding/destinations/%s", id), ErrDataForwardingDestinationNotFound, opts)
+}
+
+// DataForwardingDestinations returns a ResourceClient for data forwarding destinations.
+func (c *Client) DataForwardingDestinations(opts ...CallOption) ResourceClient[DataForwardingDestination] {
+	return &resourceClient[DataForwardingDestination]{
+		list: func() ([]DataForwardingDestination, error) {
+			return c.ListAllDataForwardingDestinations(opts...)
+		},
+		get: func(id string) (*DataForwardingDestination, error) {
+			return c.GetDataForwardingDestination(id, opts...)
+		},
+		create: func(d DataForwardingDestination) (*DataForwardingDestination, error) {
+			return c.CreateDataForwardingDestination(d, opts...)
+		},
+		update: func(d DataForwardingDestination) (*DataForwardingDestination, error) {
+			return c.UpdateDataForwardingDestination(d, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteDataForwardingDestination(id, opts...)
+		},
+	}
+}
+
+// ListDataForwardingRules returns one page of data forwarding rules. A limit of 0 uses
+// the API default.
+func (c *Client) ListDataForwardingRules(limit int, token string, opts ...CallOption) (*DataForwardingRuleList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "logsDataForwarding/rules"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var rl = new(DataForwardingRuleList)
+	if err := c.do(call{method: "GET", path: path, out: rl}, opts); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+// ListAllDataForwardingRules follows the pagination tokens and returns every data
+// forwarding rule.
+func (c *Client) ListAllDataForwardingRules(opts ...CallOption) ([]DataForwardingRule, error) {
+	var rules []DataForwardingRule
+	token := ""
+	for {
+		rl, err := c.ListDataForwardingRules(0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rl.Data...)
+		if rl.Next == "" {
+			return rules, nil
+		}
+		token = rl.Next
+	}
+}
+
+// GetDataForwardingRule gets the data forwarding rule of the index with the specified ID.
+func (c *Client) GetDataForwardingRule(indexID string, opts ...CallOption) (*DataForwardingRule, error) {
+	return c.sendDataForwardingRule(call{method: "GET", path: fmt.Sprintf("logsDataForwarding/rules/%s", indexID)}, indexID, opts)
+}
+
+// CreateDataForwardingRule starts forwarding the data of the rule's index to its destination.
+func (c *Client) CreateDataForwardingRule(r DataForwardingRule, opts ...CallOption) (*DataForwardingRule, error) {
+	return c.sendDataForwardingRule(call{method: "POST", path: "logsDataForwarding/rules", in: r, create: true}, r.IndexID, opts)
+}
+
+// UpdateDataForwardingRule updates the data forwarding rule of the rule's index.
+func (c *Client) UpdateDataForwardingRule(r DataForwardingRule, opts ...CallOption) (*DataForwardingRule, error) {
+	return c.sendDataForwardingRule(call{method: "PUT", path: fmt.Sprintf("logsDataForwarding/rules/%s", r.IndexID), in: r}, r.IndexID, opts)
+}
+
+// DeleteDataForwardingRule stops forwarding the data of the index with the specified ID.
+func (c *Client) DeleteDataForwardingRule(indexID string, opts ...CallOption) error {
+	return c.deleteDataForwarding(fmt.Sprintf("logsDataForwarding/rules/%s", indexID), ErrDataForwardingRuleNotFound, opts)
+}
+
+// DataForwardingRules returns a ResourceClient for data forwarding rules, whose IDs are
+// the IDs of their indexes.
+func (c *Client) DataForwardingRules(opts ...CallOption) ResourceClient[DataForwardingRule] {
+	return &resourceClient[DataForwardingRule]{
+		list: func() ([]DataForwardingRule, error) {
+			return c.ListAllDataForwardingRules(opts...)
+		},
+		get: func(indexID string) (*DataForwardingRule, error) {
+			return c.GetDataForwardingRule(indexID, opts...)
+		},
+		create: func(r DataForwardingRule) (*DataForwardingRule, error) {
+			return c.CreateDataForwardingRule(r, opts...)
+		},
+		update: func(r DataForwardingRule) (*DataForwardingRule, error) {
+			return c.UpdateDataForwardingRule(r, opts...)
+		},
+		delete: func(indexID string) error {
+			return c.DeleteDataForwardingRule(indexID, opts...)
+		},
+	}
+}
+
+// sendDataForwardingRule makes a call returning a data forwarding rule.
+func (c *Client) sendDataForwardingRule(cl call, indexID string, opts []CallOption) (*DataForwardingRule, error) {
+	var r = new(DataForwardingRule)
+	cl.out = r
+	cl.notFound = ErrDataForwardingRuleNotFound
+	cl.badRequest = fmt.Errorf("Bad Request. Please check the data forwarding rule of index `%s` and its destination", indexID)
+	if err := c.do(cl, opts); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// deleteDataForwarding deletes a destination or rule, returning notFound for a 404.
+func (c *Client) deleteDataForwarding(path string, notFound error, opts []CallOption) error {
+	return c.do(call{method: "DELETE", path: path, notFound: notFound}, opts)
+}
@@ -0,0 +1,129 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListAllDataForwardingDestinations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/logsDataForwarding/destinations" {
+			t.Errorf("Expected request to ‘/logsDataForwarding/destinations’, got ‘%s’", r.URL.EscapedPath())
+		}
+		switch r.URL.Query().Get("token") {
+		case "":
+			json.NewEncoder(w).Encode(DataForwardingDestinationList{Data: []DataForwardingDestination{{ID: "d1"}}, Next: "page2"})
+		case "page2":
+			json.NewEncoder(w).Encode(DataForwardingDestinationList{Data: []DataForwardingDestination{{ID: "d2"}}})
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	destinations, err := c.ListAllDataForwardingDestinations()
+	if err != nil {
+		t.Errorf("ListAllDataForwardingDestinations() returned an error: %s", err)
+		return
+	}
+	if len(destinations) != 2 || destinations[0].ID != "d1" || destinations[1].ID != "d2" {
+		t.Errorf("Expected destinations d1 and d2, got %+v", destinations)
+	}
+}
+
+func TestUpdateDataForwardingDestination(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected ‘PUT’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/logsDataForwarding/destinations/d1" {
+			t.Errorf("Expected request to ‘/logsDataForwarding/destinations/d1’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var d DataForwardingDestination
+		json.NewDecoder(r.Body).Decode(&d)
+		json.NewEncoder(w).Encode(d)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	d, err := c.UpdateDataForwardingDestination(DataForwardingDestination{ID: "d1", DestinationName: "archive", BucketName: "logs"})
+	if err != nil {
+		t.Errorf("UpdateDataForwardingDestination() returned an error: %s", err)
+		return
+	}
+	if d.BucketName != "logs" {
+		t.Errorf("Expected the updated destination, got %+v", d)
+	}
+}
+
+func TestDeleteDataForwardingDestinationNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.DeleteDataForwardingDestination("missing"); err != ErrDataForwardingDestinationNotFound {
+		t.Errorf("Expected ErrDataForwardingDestinationNotFound, got %v", err)
+	}
+}
+
+func TestCreateDataForwardingRule(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/logsDataForwarding/rules" {
+			t.Errorf("Expected request to ‘/logsDataForwarding/rules’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var rule DataForwardingRule
+		json.NewDecoder(r.Body).Decode(&rule)
+		rule.ID = "r1"
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(rule)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	rule, err := c.CreateDataForwardingRule(DataForwardingRule{IndexID: "idx1", DestinationID: "d1", Enabled: true})
+	if err != nil {
+		t.Errorf("CreateDataForwardingRule() returned an error: %s", err)
+		return
+	}
+	if rule.ID != "r1" || rule.IndexID != "idx1" || rule.DestinationID != "d1" {
+		t.Errorf("Expected the created rule, got %+v", rule)
+	}
+}
+
+func TestDataForwardingRulesByIndex(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusNotFound)
+		case "PUT":
+			var rule DataForwardingRule
+			json.NewDecoder(r.Body).Decode(&rule)
+			json.NewEncoder(w).Encode(rule)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	rules := c.DataForwardingRules()
+	if _, err := rules.Get("idx1"); err != ErrDataForwardingRuleNotFound {
+		t.Errorf("Expected ErrDataForwardingRuleNotFound, got %v", err)
+	}
+	if _, err := rules.Update(DataForwardingRule{IndexID: "idx1", DestinationID: "d2"}); err != nil {
+		t.Errorf("Update() returned an error: %s", err)
+		return
+	}
+	expected := []string{"GET /logsDataForwarding/rules/idx1", "PUT /logsDataForwarding/rules/idx1"}
+	if len(requests) != 2 || requests[0] != expected[0] || requests[1] != expected[1] {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,56 @@
+package sumologic
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// S3DestinationChecker verifies S3 access using the caller's AWS client, so this package
+// doesn't depend on an AWS SDK.
+type S3DestinationChecker interface {
+	// CheckBucket returns an error when the bucket can't be reached in the region,
+	// e.g. from a HeadBucket call.
+	CheckBucket(bucket, region string) error
+	// CheckRole returns an error when the role can't be assumed, e.g. from an STS AssumeRole call.
+	CheckRole(roleARN string) error
+}
+
+var (
+	s3BucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	iamRoleARNRegexp   = regexp.MustCompile(`^arn:aws[a-zA-Z-]*:iam::\d{12}:role/.+$`)
+)
+
+// ValidateS3Destination checks a data forwarding destination before it's created.
+// The settings are checked first; when checker is not nil it's then used to confirm the
+// bucket is reachable and, for role based authentication, that the role can be assumed.
+func ValidateS3Destination(d DataForwardingDestination, checker S3DestinationChecker) error {
+	if !s3BucketNameRegexp.MatchString(d.BucketName) {
+		return fmt.Errorf("invalid S3 bucket name `%s`", d.BucketName)
+	}
+
+	switch d.AuthenticationMode {
+	case DataForwardingAuthRoleBased:
+		if !iamRoleARNRegexp.MatchString(d.RoleARN) {
+			return fmt.Errorf("invalid IAM role ARN `%s` for destination `%s`", d.RoleARN, d.DestinationName)
+		}
+	case DataForwardingAuthAccessKey:
+		if d.AccessKeyID == "" || d.SecretAccessKey == "" {
+			return fmt.Errorf("destination `%s` needs an access key ID and secret access key", d.DestinationName)
+		}
+	default:
+		return fmt.Errorf("unknown authentication mode `%s` for destination `%s`", d.AuthenticationMode, d.DestinationName)
+	}
+
+	if checker == nil {
+		return nil
+	}
+	if d.AuthenticationMode == DataForwardingAuthRoleBased {
+		if err := checker.CheckRole(d.RoleARN); err != nil {
+			return fmt.Errorf("unable to assume role `%s`: %s", d.RoleARN, err)
+		}
+	}
+	if err := checker.CheckBucket(d.BucketName, d.S3Region); err != nil {
+		return fmt.Errorf("unable to reach S3 bucket `%s`: %s", d.BucketName, err)
+	}
+	return nil
+}
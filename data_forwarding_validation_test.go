@@ -0,0 +1,66 @@
+package sumologic
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeS3Checker struct {
+	bucketErr error
+	roleErr   error
+	checked   []string
+}
+
+func (f *fakeS3Checker) CheckBucket(bucket, region string) error {
+	f.checked = append(f.checked, "bucket:"+bucket)
+	return f.bucketErr
+}
+
+func (f *fakeS3Checker) CheckRole(roleARN string) error {
+	f.checked = append(f.checked, "role:"+roleARN)
+	return f.roleErr
+}
+
+var defaultDestination = DataForwardingDestination{
+	DestinationName:    "archive",
+	BucketName:         "sumo-archive",
+	S3Region:           "us-east-1",
+	AuthenticationMode: DataForwardingAuthRoleBased,
+	RoleARN:            "arn:aws:iam::123456789012:role/sumo-forwarding",
+}
+
+func TestValidateS3DestinationOK(t *testing.T) {
+	checker := &fakeS3Checker{}
+	if err := ValidateS3Destination(defaultDestination, checker); err != nil {
+		t.Errorf("ValidateS3Destination() returned an error: %s", err)
+	}
+	if len(checker.checked) != 2 {
+		t.Errorf("Expected the role and bucket to be checked, got %v", checker.checked)
+	}
+}
+
+func TestValidateS3DestinationSettings(t *testing.T) {
+	badBucket := defaultDestination
+	badBucket.BucketName = "Not_A_Bucket"
+	badRole := defaultDestination
+	badRole.RoleARN = "arn:aws:iam::role"
+	missingKeys := defaultDestination
+	missingKeys.AuthenticationMode = DataForwardingAuthAccessKey
+
+	for _, d := range []DataForwardingDestination{badBucket, badRole, missingKeys} {
+		checker := &fakeS3Checker{}
+		if err := ValidateS3Destination(d, checker); err == nil {
+			t.Errorf("ValidateS3Destination() did not return an error for %v", d)
+		}
+		if len(checker.checked) != 0 {
+			t.Errorf("Expected invalid settings to fail before any AWS call, got %v", checker.checked)
+		}
+	}
+}
+
+func TestValidateS3DestinationUnreachable(t *testing.T) {
+	checker := &fakeS3Checker{bucketErr: errors.New("403 Forbidden")}
+	if err := ValidateS3Destination(defaultDestination, checker); err == nil {
+		t.Errorf("ValidateS3Destination() did not return an error for an unreachable bucket")
+	}
+}
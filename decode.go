@@ -0,0 +1,88 @@
+package sumologic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// DecodeError is returned when a response of the API can't be decoded or doesn't hold
+// what the call expects, such as a search job without an ID or a null message.
+type DecodeError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding response to %s %s: %s", e.Method, e.Path, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// validator is implemented by response types that check their decoded content.
+type validator interface {
+	validate() error
+}
+
+// decodeJSON unmarshals the response body to the request into v, returning a
+// *DecodeError when it's malformed or null.
+func (c *Client) decodeJSON(req *http.Request, body []byte, v interface{}) error {
+	if bytes.Equal(bytes.TrimSpace(body), []byte("null")) {
+		return &DecodeError{Method: req.Method, Path: req.URL.Path, Err: errors.New("response body is null")}
+	}
+	var err error
+	c.profile(req, "decode", func(context.Context) {
+		err = json.Unmarshal(body, v)
+		if vv, ok := v.(validator); ok && err == nil {
+			err = vv.validate()
+		}
+	})
+	if err != nil {
+		return &DecodeError{Method: req.Method, Path: req.URL.Path, Err: err}
+	}
+	return nil
+}
+
+func (sj *SearchJob) validate() error {
+	if sj.ID == "" {
+		return errors.New("search job has no id")
+	}
+	return nil
+}
+
+func (s *SearchJobStatusResponse) validate() error {
+	if s.MessageCount < 0 || s.RecordCount < 0 {
+		return fmt.Errorf("negative message count %d or record count %d", s.MessageCount, s.RecordCount)
+	}
+	return validateElements(s.HistgramBuckets, "histogram bucket")
+}
+
+func (r *SearchJobResult) validate() error {
+	if err := validateElements(r.Fields, "field"); err != nil {
+		return err
+	}
+	return validateElements(r.Messages, "message")
+}
+
+func (r *SearchJobRecordsResult) validate() error {
+	if err := validateElements(r.Fields, "field"); err != nil {
+		return err
+	}
+	return validateElements(r.Records, "record")
+}
+
+// validateElements returns an error when an element of the list is null.
+func validateElements[T any](list []*T, kind string) error {
+	for i, e := range list {
+		if e == nil {
+			return fmt.Errorf("%s %d is null", kind, i)
+		}
+	}
+	return nil
+}
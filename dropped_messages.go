@@ -0,0 +1,118 @@
+package sumologic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultDroppedMessagesQuery searches the audit and system event indexes for ingest budget
+// and throttling events. It extracts the `source`, `reason` and `dropped_bytes` fields that
+// FindDroppedMessages summarizes; custom queries must extract the same fields.
+const DefaultDroppedMessagesQuery = `(_index=sumologic_audit OR _index=sumologic_system_events)
+("budget" OR "throttl") ("exceeded" OR "dropped" OR "throttled")
+| parse regex "(?i)(?<reason>budget|throttl)" nodrop
+| parse regex "(?i)source(?:category)?[\"'\s:=]+(?<source>[^\"',\s]+)" nodrop
+| parse regex "(?i)(?<dropped_bytes>\d+)\s*bytes" nodrop`
+
+// DroppedMessagesRequest describes the time range to check for dropped data.
+type DroppedMessagesRequest struct {
+	From     string
+	To       string
+	TimeZone string
+
+	// Query overrides DefaultDroppedMessagesQuery.
+	Query string
+
+	// PollInterval is the delay between search status checks, 5 seconds by default.
+	PollInterval time.Duration
+}
+
+// DroppedVolume summarizes the budget-exceeded or throttling events for one source.
+type DroppedVolume struct {
+	Source       string
+	Reason       string
+	Events       int
+	DroppedBytes int64
+}
+
+// DroppedMessagesReport is the per-source summary of data lost to budgets and throttling.
+type DroppedMessagesReport struct {
+	Sources      []DroppedVolume
+	Events       int
+	DroppedBytes int64
+}
+
+// FindDroppedMessages searches for budget-exceeded and throttling events in the requested
+// time range and summarizes the dropped volume per source, largest first.
+func (c *Client) FindDroppedMessages(dmr DroppedMessagesRequest, opts ...CallOption) (*DroppedMessagesReport, error) {
+	query := dmr.Query
+	if query == "" {
+		query = DefaultDroppedMessagesQuery
+	}
+	pollInterval := dmr.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	messages, err := c.searchMessages(StartSearchRequest{
+		Query:    query,
+		From:     dmr.From,
+		To:       dmr.To,
+		TimeZone: dmr.TimeZone,
+	}, pollInterval, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeDroppedMessages(messages), nil
+}
+
+func summarizeDroppedMessages(messages []*SearchJobResultMessage) *DroppedMessagesReport {
+	report := new(DroppedMessagesReport)
+	bySource := make(map[string]*DroppedVolume)
+	var keys []string
+
+	for _, m := range messages {
+		source := messageField(m, "source")
+		reason := messageField(m, "reason")
+		key := source + "\x00" + reason
+
+		dv, ok := bySource[key]
+		if !ok {
+			dv = &DroppedVolume{Source: source, Reason: reason}
+			bySource[key] = dv
+			keys = append(keys, key)
+		}
+		dv.Events++
+		report.Events++
+		if b, err := strconv.ParseInt(messageField(m, "dropped_bytes"), 10, 64); err == nil {
+			dv.DroppedBytes += b
+			report.DroppedBytes += b
+		}
+	}
+
+	for _, key := range keys {
+		report.Sources = append(report.Sources, *bySource[key])
+	}
+	sort.Stable(byDroppedBytes(report.Sources))
+	return report
+}
+
+type byDroppedBytes []DroppedVolume
+
+func (s byDroppedBytes) Len() int           { return len(s) }
+func (s byDroppedBytes) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byDroppedBytes) Less(i, j int) bool { return s[i].DroppedBytes > s[j].DroppedBytes }
+
+// messageField returns a message field as a string, or an empty string when it's missing.
+func messageField(m *SearchJobResultMessage, name string) string {
+	v, ok := m.Map[name]
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,66 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFindDroppedMessages(t *testing.T) {
+	var deleted int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/search/jobs":
+			w.WriteHeader(http.StatusAccepted)
+			body, _ := json.Marshal(SearchJob{ID: "dropped"})
+			w.Write(body)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/search/jobs/dropped":
+			w.WriteHeader(http.StatusOK)
+			body, _ := json.Marshal(SearchJobStatusResponse{State: "DONE GATHERING RESULTS", MessageCount: 3})
+			w.Write(body)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/search/jobs/dropped/messages":
+			w.WriteHeader(http.StatusOK)
+			body, _ := json.Marshal(SearchJobResult{
+				Messages: []*SearchJobResultMessage{
+					{Map: map[string]interface{}{"source": "prod/app", "reason": "budget", "dropped_bytes": "100"}},
+					{Map: map[string]interface{}{"source": "prod/db", "reason": "throttl", "dropped_bytes": "500"}},
+					{Map: map[string]interface{}{"source": "prod/app", "reason": "budget", "dropped_bytes": "50"}},
+				},
+			})
+			w.Write(body)
+		case r.Method == "DELETE" && r.URL.EscapedPath() == "/search/jobs/dropped":
+			atomic.AddInt32(&deleted, 1)
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected ‘%s’ request to ‘%s’", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	report, err := c.FindDroppedMessages(DroppedMessagesRequest{From: "2017-01-01T00:00:00", To: "2017-01-02T00:00:00"})
+	if err != nil {
+		t.Errorf("FindDroppedMessages() returned an error: %s", err)
+		return
+	}
+	if atomic.LoadInt32(&deleted) != 1 {
+		t.Errorf("FindDroppedMessages() expected to delete its search job, deleted it %d times", deleted)
+	}
+	if report.Events != 3 || report.DroppedBytes != 650 {
+		t.Errorf("FindDroppedMessages() expected 3 events and 650 bytes, got %d and %d", report.Events, report.DroppedBytes)
+	}
+	if len(report.Sources) != 2 {
+		t.Errorf("FindDroppedMessages() expected 2 sources, got %v", report.Sources)
+		return
+	}
+	if report.Sources[0].Source != "prod/db" || report.Sources[1].DroppedBytes != 150 {
+		t.Errorf("FindDroppedMessages() returned an unexpected summary: %v", report.Sources)
+	}
+}
@@ -0,0 +1,195 @@
+package sumologic
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Encrypted results are written as a header followed by chunks. The header is the magic
+// bytes and a random nonce prefix; each chunk is its ciphertext length followed by the
+// AES-GCM sealed plaintext of up to encryptedChunkSize bytes. A chunk's nonce is the prefix
+// and the chunk's sequence number, and the last chunk is sealed with different additional
+// data so a truncated file fails to decrypt.
+const (
+	encryptedMagic     = "SLE1"
+	encryptedChunkSize = 64 * 1024
+)
+
+var (
+	encryptedChunkData = []byte{0}
+	encryptedFinalData = []byte{1}
+)
+
+// ErrEncryptedFormat is returned when encrypted results are corrupt, truncated or were
+// encrypted with a different key.
+var ErrEncryptedFormat = errors.New("Encrypted results are corrupt or the key is wrong")
+
+type encryptingWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	prefix []byte
+	seq    uint32
+	buf    []byte
+	closed bool
+}
+
+// NewEncryptingWriter returns a writer that encrypts everything written to it with AES-GCM
+// before writing it to w, e.g. to encrypt exported results stored on disk. The key must be
+// 16, 24 or 32 bytes long. Close must be called to write the final chunk; it doesn't close w.
+func NewEncryptingWriter(w io.Writer, key []byte) (io.WriteCloser, error) {
+	aead, err := newResultsAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, aead.NonceSize()-4)
+	if _, err := io.ReadFull(rand.Reader, prefix); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append([]byte(encryptedMagic), prefix...)); err != nil {
+		return nil, err
+	}
+	return &encryptingWriter{
+		w:      w,
+		aead:   aead,
+		prefix: prefix,
+		buf:    make([]byte, 0, encryptedChunkSize),
+	}, nil
+}
+
+func (e *encryptingWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed encrypting writer")
+	}
+	n := 0
+	for len(p) > 0 {
+		room := encryptedChunkSize - len(e.buf)
+		if room > len(p) {
+			room = len(p)
+		}
+		e.buf = append(e.buf, p[:room]...)
+		p = p[room:]
+		n += room
+		// Keep a full chunk buffered until more data arrives, since the last chunk
+		// can only be sealed once Close says it's the last.
+		if len(e.buf) == encryptedChunkSize && len(p) > 0 {
+			if err := e.flush(encryptedChunkData); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (e *encryptingWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.flush(encryptedFinalData)
+}
+
+func (e *encryptingWriter) flush(additionalData []byte) error {
+	sealed := e.aead.Seal(nil, chunkNonce(e.prefix, e.seq), e.buf, additionalData)
+	e.seq++
+	e.buf = e.buf[:0]
+
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(sealed)))
+	if _, err := e.w.Write(length[:]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(sealed)
+	return err
+}
+
+type decryptingReader struct {
+	r      io.Reader
+	aead   cipher.AEAD
+	prefix []byte
+	seq    uint32
+	buf    []byte
+	final  bool
+}
+
+// NewDecryptingReader returns a reader that decrypts results written by NewEncryptingWriter.
+// Reads return ErrEncryptedFormat if the data was tampered with, truncated or encrypted
+// with another key.
+func NewDecryptingReader(r io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newResultsAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, len(encryptedMagic)+aead.NonceSize()-4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrEncryptedFormat
+	}
+	if string(header[:len(encryptedMagic)]) != encryptedMagic {
+		return nil, ErrEncryptedFormat
+	}
+	return &decryptingReader{
+		r:      r,
+		aead:   aead,
+		prefix: header[len(encryptedMagic):],
+	}, nil
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptingReader) next() error {
+	var length [4]byte
+	if _, err := io.ReadFull(d.r, length[:]); err != nil {
+		return ErrEncryptedFormat
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size > encryptedChunkSize+uint32(d.aead.Overhead()) {
+		return ErrEncryptedFormat
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return ErrEncryptedFormat
+	}
+
+	nonce := chunkNonce(d.prefix, d.seq)
+	plain, err := d.aead.Open(nil, nonce, sealed, encryptedChunkData)
+	if err != nil {
+		plain, err = d.aead.Open(nil, nonce, sealed, encryptedFinalData)
+		if err != nil {
+			return ErrEncryptedFormat
+		}
+		d.final = true
+	}
+	d.seq++
+	d.buf = plain
+	return nil
+}
+
+func newResultsAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(prefix []byte, seq uint32) []byte {
+	nonce := make([]byte, len(prefix)+4)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[len(prefix):], seq)
+	return nonce
+}
@@ -0,0 +1,81 @@
+package sumologic
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+var testEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func encryptForTest(t *testing.T, plain []byte) []byte {
+	var out bytes.Buffer
+	w, err := NewEncryptingWriter(&out, testEncryptionKey)
+	if err != nil {
+		t.Fatalf("NewEncryptingWriter() returned an error: %s", err)
+	}
+	if _, err := w.Write(plain); err != nil {
+		t.Fatalf("Write() returned an error: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() returned an error: %s", err)
+	}
+	return out.Bytes()
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 10, encryptedChunkSize, 3*encryptedChunkSize + 7} {
+		plain := bytes.Repeat([]byte("{\"_raw\":\"message\"}\n"), size/19+1)[:size]
+		encrypted := encryptForTest(t, plain)
+		if size > 0 && bytes.Contains(encrypted, plain[:10]) {
+			t.Errorf("Encrypted output contains plaintext")
+		}
+
+		r, err := NewDecryptingReader(bytes.NewReader(encrypted), testEncryptionKey)
+		if err != nil {
+			t.Errorf("NewDecryptingReader() returned an error: %s", err)
+			continue
+		}
+		decrypted, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Errorf("ReadAll() returned an error for %d bytes: %s", size, err)
+			continue
+		}
+		if !bytes.Equal(decrypted, plain) {
+			t.Errorf("Round trip of %d bytes returned %d bytes", size, len(decrypted))
+		}
+	}
+}
+
+func TestDecryptionDetectsTampering(t *testing.T) {
+	plain := bytes.Repeat([]byte("x"), 2*encryptedChunkSize+1)
+	encrypted := encryptForTest(t, plain)
+
+	tampered := append([]byte(nil), encrypted...)
+	tampered[len(tampered)/2] ^= 1
+	truncated := encrypted[:len(encrypted)-len(plain)%encryptedChunkSize-30]
+
+	wrongKey := []byte("fedcba9876543210fedcba9876543210")
+	for name, tc := range map[string]struct {
+		data []byte
+		key  []byte
+	}{
+		"tampered":  {tampered, testEncryptionKey},
+		"truncated": {truncated, testEncryptionKey},
+		"wrong key": {encrypted, wrongKey},
+	} {
+		r, err := NewDecryptingReader(bytes.NewReader(tc.data), tc.key)
+		if err == nil {
+			_, err = ioutil.ReadAll(r)
+		}
+		if err != ErrEncryptedFormat {
+			t.Errorf("Expected ErrEncryptedFormat for %s data, got %v", name, err)
+		}
+	}
+}
+
+func TestEncryptingWriterInvalidKey(t *testing.T) {
+	if _, err := NewEncryptingWriter(ioutil.Discard, []byte("short")); err == nil {
+		t.Errorf("NewEncryptingWriter() did not return an error for an invalid key")
+	}
+}
@@ -0,0 +1,130 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Entity is a service or host discovered by Sumo Logic from the telemetry it receives.
+type Entity struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Environment string            `json:"environment,omitempty"`
+	Application string            `json:"application,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	FirstSeenAt string            `json:"firstSeenAt,omitempty"`
+	LastSeenAt  string            `json:"lastSeenAt,omitempty"`
+}
+
+// Entity types understood by the entities API.
+const (
+	EntityTypeService = "service"
+	EntityTypeHost    = "host"
+)
+
+// ListEntitiesRequest holds the filters for listing entities.
+// Empty fields are not sent.
+type ListEntitiesRequest struct {
+	Type        string
+	Name        string
+	Environment string
+	Limit       int
+	Token       string
+}
+
+// EntityList is one page of entities. Next is the token for the following page
+// and is empty on the last page.
+type EntityList struct {
+	Data []Entity `json:"data"`
+	Next string   `json:"next,omitempty"`
+}
+
+// ErrEntityNotFound is returned when an entity doesn't exist on a Read.
+var ErrEntityNotFound = errors.New("Entity not found")
+
+// ListEntities returns one page of entities matching the request filters.
+func (c *Client) ListEntities(ler ListEntitiesRequest, opts ...CallOption) (*EntityList, error) {
+	q := url.Values{}
+	if ler.Type != "" {
+		q.Set("type", ler.Type)
+	}
+	if ler.Name != "" {
+		q.Set("name", ler.Name)
+	}
+	if ler.Environment != "" {
+		q.Set("environment", ler.Environment)
+	}
+	if ler.Limit > 0 {
+		q.Set("limit", strconv.Itoa(ler.Limit))
+	}
+	if ler.Token != "" {
+		q.Set("token", ler.Token)
+	}
+
+	path := "entities"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var el = new(EntityList)
+	if err := c.do(call{method: "GET", path: path, out: el}, opts); err != nil {
+		return nil, err
+	}
+	return el, nil
+}
+
+// ListAllEntities follows the pagination tokens and returns every entity matching
+// the request filters. It's intended for reconciling a service catalog against what
+// Sumo Logic has discovered.
+func (c *Client) ListAllEntities(ler ListEntitiesRequest, opts ...CallOption) ([]Entity, error) {
+	var entities []Entity
+	for {
+		el, err := c.ListEntities(ler, opts...)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, el.Data...)
+		if el.Next == "" {
+			return entities, nil
+		}
+		ler.Token = el.Next
+	}
+}
+
+// ListServices returns every service entity in the given environment.
+// An empty environment lists services in all environments.
+func (c *Client) ListServices(environment string, opts ...CallOption) ([]Entity, error) {
+	return c.ListAllEntities(ListEntitiesRequest{
+		Type:        EntityTypeService,
+		Environment: environment,
+	}, opts...)
+}
+
+// GetEntity gets the entity with the specified ID.
+func (c *Client) GetEntity(id string, opts ...CallOption) (*Entity, error) {
+	var e = new(Entity)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("entities/%s", id),
+		out:      e,
+		notFound: ErrEntityNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Entities returns a read-only ResourceClient for entities of every type.
+func (c *Client) Entities(opts ...CallOption) ResourceClient[Entity] {
+	return &resourceClient[Entity]{
+		list: func() ([]Entity, error) {
+			return c.ListAllEntities(ListEntitiesRequest{}, opts...)
+		},
+		get: func(id string) (*Entity, error) {
+			return c.GetEntity(id, opts...)
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListEntitiesFilters(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/entities" {
+			t.Errorf("Expected request to ‘/entities’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("type") != EntityTypeService {
+			t.Errorf("Expected type filter ‘service’, got ‘%s’", r.URL.Query().Get("type"))
+		}
+		if r.URL.Query().Get("environment") != "prod" {
+			t.Errorf("Expected environment filter ‘prod’, got ‘%s’", r.URL.Query().Get("environment"))
+		}
+		if r.URL.Query().Get("name") != "" {
+			t.Errorf("Expected no name filter, got ‘%s’", r.URL.Query().Get("name"))
+		}
+		body, _ := json.Marshal(EntityList{
+			Data: []Entity{{ID: "1", Name: "checkout", Type: EntityTypeService}},
+		})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	el, err := c.ListEntities(ListEntitiesRequest{Type: EntityTypeService, Environment: "prod"})
+	if err != nil {
+		t.Errorf("ListEntities() returned an error: %s", err)
+		return
+	}
+	if len(el.Data) != 1 || el.Data[0].Name != "checkout" {
+		t.Errorf("ListEntities() returned unexpected entities: %v", el.Data)
+		return
+	}
+}
+
+func TestListAllEntitiesFollowsToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var el EntityList
+		switch r.URL.Query().Get("token") {
+		case "":
+			el = EntityList{Data: []Entity{{ID: "1"}}, Next: "page2"}
+		case "page2":
+			el = EntityList{Data: []Entity{{ID: "2"}}}
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		body, _ := json.Marshal(el)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	entities, err := c.ListServices("")
+	if err != nil {
+		t.Errorf("ListServices() returned an error: %s", err)
+		return
+	}
+	if len(entities) != 2 {
+		t.Errorf("ListServices() expected 2 entities, got %d", len(entities))
+		return
+	}
+}
+
+func TestGetEntityDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.URL.EscapedPath() != "/entities/abc" {
+			t.Errorf("Expected request to ‘/entities/abc’, got ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.GetEntity("abc")
+	if err != ErrEntityNotFound {
+		t.Errorf("GetEntity() returned the wrong error: %s", err)
+		return
+	}
+}
@@ -0,0 +1,8 @@
+// Package experimental holds API groups that aren't part of the stable client yet. Their
+// API may change or be removed in any release. Each group is registered as an
+// Experimental feature, so consumers can check for it with Client.Supports before use.
+//
+// A group is promoted once its API has settled: it moves into the sumologic package
+// with the same names, its feature becomes Stable, and the functions here forward to
+// the stable ones, deprecated, for one minor release before they're removed.
+package experimental
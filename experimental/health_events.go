@@ -0,0 +1,81 @@
+package experimental
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	sumologic "github.com/brandonstevens/sumologic-sdk-go"
+)
+
+// HealthEvent is a problem detected with a collector, source or ingest budget, such as
+// a source failing to read its data.
+type HealthEvent struct {
+	EventID          string              `json:"eventId"`
+	EventName        string              `json:"eventName"`
+	Details          HealthEventDetails  `json:"details"`
+	ResourceIdentity HealthEventResource `json:"resourceIdentity"`
+	EventTime        string              `json:"eventTime"`
+	Subsystem        string              `json:"subsystem"`
+	SeverityLevel    string              `json:"severityLevel"`
+}
+
+// HealthEventDetails describes the error behind a health event.
+type HealthEventDetails struct {
+	TrackerID   string `json:"trackerId"`
+	Error       string `json:"error"`
+	Description string `json:"description"`
+}
+
+// HealthEventResource identifies the resource a health event is about.
+type HealthEventResource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// HealthEventList is one page of health events. Next is the token for the following page
+// and is empty on the last page.
+type HealthEventList struct {
+	Data []HealthEvent `json:"data"`
+	Next string        `json:"next,omitempty"`
+}
+
+// ListHealthEvents returns one page of the organization's open health events. A limit of
+// 0 uses the API default.
+func ListHealthEvents(ctx context.Context, c *sumologic.Client, limit int, token string, opts ...sumologic.CallOption) (*HealthEventList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "healthEvents"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var hel = new(HealthEventList)
+	if err := c.Do(ctx, "GET", path, nil, hel, opts...); err != nil {
+		return nil, err
+	}
+	return hel, nil
+}
+
+// ListAllHealthEvents follows the pagination tokens and returns every open health event.
+func ListAllHealthEvents(ctx context.Context, c *sumologic.Client, opts ...sumologic.CallOption) ([]HealthEvent, error) {
+	var events []HealthEvent
+	token := ""
+	for {
+		hel, err := ListHealthEvents(ctx, c, 0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, hel.Data...)
+		if hel.Next == "" {
+			return events, nil
+		}
+		token = hel.Next
+	}
+}
@@ -0,0 +1,43 @@
+package experimental
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sumologic "github.com/brandonstevens/sumologic-sdk-go"
+)
+
+func TestListAllHealthEventsFollowsToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/healthEvents" {
+			t.Errorf("Expected request to ‘/healthEvents’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var hel HealthEventList
+		switch r.URL.Query().Get("token") {
+		case "":
+			hel = HealthEventList{Data: []HealthEvent{{EventID: "1", EventName: "SourceFailure"}}, Next: "page2"}
+		case "page2":
+			hel = HealthEventList{Data: []HealthEvent{{EventID: "2", EventName: "IngestBudgetExceeded"}}}
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		json.NewEncoder(w).Encode(hel)
+	}))
+	defer ts.Close()
+
+	c, _ := sumologic.NewClient("accessToken", ts.URL)
+	if !c.Supports(sumologic.FeatureHealthEvents) {
+		t.Errorf("Expected the client to support health events")
+	}
+	events, err := ListAllHealthEvents(context.Background(), c)
+	if err != nil {
+		t.Errorf("ListAllHealthEvents() returned an error: %s", err)
+		return
+	}
+	if len(events) != 2 {
+		t.Errorf("ListAllHealthEvents() expected 2 events, got %d", len(events))
+	}
+}
@@ -0,0 +1,202 @@
+package sumologic
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ObjectStore creates objects in a bucket, e.g. on S3 or GCS. The object is complete
+// once the returned writer is closed without error. When an export fails, writers that
+// implement ObjectAborter are aborted so the unfinished object is discarded; other writers
+// are closed, which leaves a truncated object behind. Since the manifest is only written
+// once every part is complete, such objects are never listed in one.
+type ObjectStore interface {
+	Create(ctx context.Context, name string) (io.WriteCloser, error)
+}
+
+// ObjectAborter is implemented by object writers that can discard the object instead of
+// completing it, e.g. by aborting a multipart upload.
+type ObjectAborter interface {
+	Abort() error
+}
+
+// ExportOptions configures ExportMessages.
+type ExportOptions struct {
+	// Prefix is prepended to the names of the parts and manifest, e.g. "exports/2017-03-01/".
+	Prefix string
+	// PartSize is the number of messages in each part, 100000 by default.
+	PartSize int
+	// EncryptionKey, when set, encrypts each part with NewEncryptingWriter after it's
+	// gzipped, and ".enc" is appended to the part names. It must be 16, 24 or 32 bytes
+	// long. The manifest isn't encrypted.
+	EncryptionKey []byte
+}
+
+// ExportManifest describes an export. It's written last, so its presence shows the
+// export is complete.
+type ExportManifest struct {
+	Parts    []ExportPart `json:"parts"`
+	Messages int          `json:"messages"`
+	Created  time.Time    `json:"created"`
+}
+
+// ExportPart is one gzipped JSON Lines object of an export.
+type ExportPart struct {
+	Name     string `json:"name"`
+	Messages int    `json:"messages"`
+	// Bytes is the stored size of the part, after compression and encryption.
+	Bytes int64 `json:"bytes"`
+}
+
+// ExportManifestName is the name of the manifest object, after the prefix.
+const ExportManifestName = "manifest.json"
+
+// ExportMessages streams the messages of the iterator to the store as gzipped JSON Lines
+// parts named part-00000.jsonl.gz and so on, followed by the manifest. Nothing is
+// buffered on local disk, so results of any size can be exported.
+func ExportMessages(ctx context.Context, it MessageIterator, store ObjectStore, eo ExportOptions) (*ExportManifest, error) {
+	partSize := eo.PartSize
+	if partSize <= 0 {
+		partSize = 100000
+	}
+	if eo.EncryptionKey != nil {
+		if _, err := newResultsAEAD(eo.EncryptionKey); err != nil {
+			return nil, err
+		}
+	}
+	manifest := &ExportManifest{Parts: []ExportPart{}}
+
+	var part *exportPartWriter
+	for it.Next() {
+		if part == nil {
+			name := fmt.Sprintf("%spart-%05d.jsonl.gz", eo.Prefix, len(manifest.Parts))
+			if eo.EncryptionKey != nil {
+				name += ".enc"
+			}
+			w, err := store.Create(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			if part, err = newExportPartWriter(name, w, eo.EncryptionKey); err != nil {
+				abortObject(w)
+				return nil, err
+			}
+		}
+		if err := part.write(it.Message().Map); err != nil {
+			part.abort()
+			return nil, err
+		}
+		if part.messages == partSize {
+			if err := part.close(manifest); err != nil {
+				return nil, err
+			}
+			part = nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		if part != nil {
+			part.abort()
+		}
+		return nil, err
+	}
+	if part != nil {
+		if err := part.close(manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest.Created = time.Now().UTC()
+	w, err := store.Create(ctx, eo.Prefix+ExportManifestName)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewEncoder(w).Encode(manifest); err != nil {
+		abortObject(w)
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+type exportPartWriter struct {
+	name    string
+	object  io.WriteCloser
+	counter *countingWriter
+	// crypt encrypts the part when the export has an encryption key.
+	crypt    io.WriteCloser
+	gz       *gzip.Writer
+	enc      *json.Encoder
+	messages int
+}
+
+func newExportPartWriter(name string, object io.WriteCloser, key []byte) (*exportPartWriter, error) {
+	p := &exportPartWriter{name: name, object: object, counter: &countingWriter{w: object}}
+	var w io.Writer = p.counter
+	if key != nil {
+		crypt, err := NewEncryptingWriter(p.counter, key)
+		if err != nil {
+			return nil, err
+		}
+		p.crypt, w = crypt, crypt
+	}
+	p.gz = gzip.NewWriter(w)
+	p.enc = json.NewEncoder(p.gz)
+	return p, nil
+}
+
+func (p *exportPartWriter) write(fields map[string]interface{}) error {
+	if err := p.enc.Encode(fields); err != nil {
+		return err
+	}
+	p.messages++
+	return nil
+}
+
+func (p *exportPartWriter) close(manifest *ExportManifest) error {
+	if err := p.gz.Close(); err != nil {
+		p.abort()
+		return err
+	}
+	if p.crypt != nil {
+		if err := p.crypt.Close(); err != nil {
+			p.abort()
+			return err
+		}
+	}
+	if err := p.object.Close(); err != nil {
+		return err
+	}
+	manifest.Parts = append(manifest.Parts, ExportPart{Name: p.name, Messages: p.messages, Bytes: p.counter.n})
+	manifest.Messages += p.messages
+	return nil
+}
+
+func (p *exportPartWriter) abort() {
+	abortObject(p.object)
+}
+
+// abortObject discards an unfinished object, or closes it when its writer can't abort.
+func abortObject(w io.WriteCloser) {
+	if a, ok := w.(ObjectAborter); ok {
+		a.Abort()
+		return
+	}
+	w.Close()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
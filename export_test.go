@@ -0,0 +1,141 @@
+package sumologic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+type memoryObjectStore map[string]*bytes.Buffer
+
+type memoryObject struct {
+	*bytes.Buffer
+}
+
+func (memoryObject) Close() error { return nil }
+
+func (s memoryObjectStore) Create(ctx context.Context, name string) (io.WriteCloser, error) {
+	b := new(bytes.Buffer)
+	s[name] = b
+	return memoryObject{b}, nil
+}
+
+func TestExportMessages(t *testing.T) {
+	store := make(memoryObjectStore)
+	manifest, err := ExportMessages(context.Background(), NewSliceMessageIterator(sinkMessages()), store, ExportOptions{Prefix: "exports/", PartSize: 2})
+	if err != nil {
+		t.Errorf("ExportMessages() returned error: %v", err)
+		return
+	}
+	if manifest.Messages != 3 || len(manifest.Parts) != 2 {
+		t.Errorf("Expected 3 messages in 2 parts, got %+v", manifest)
+		return
+	}
+
+	for i, name := range []string{"exports/part-00000.jsonl.gz", "exports/part-00001.jsonl.gz"} {
+		part := manifest.Parts[i]
+		b, ok := store[name]
+		if part.Name != name || !ok || int64(b.Len()) != part.Bytes {
+			t.Errorf("Expected part %s of %d bytes, got %+v", name, b.Len(), part)
+			continue
+		}
+		gz, err := gzip.NewReader(b)
+		if err != nil {
+			t.Errorf("Part %s isn't gzipped: %v", name, err)
+			continue
+		}
+		lines, _ := ioutil.ReadAll(gz)
+		if n := strings.Count(string(lines), "\n"); n != part.Messages {
+			t.Errorf("Expected %d lines in %s, got %d", part.Messages, name, n)
+		}
+	}
+
+	var written ExportManifest
+	if err := json.Unmarshal(store["exports/manifest.json"].Bytes(), &written); err != nil || written.Messages != 3 {
+		t.Errorf("Expected the manifest to be written, got %v and %+v", err, written)
+	}
+}
+
+func TestExportMessagesEncrypted(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	store := make(memoryObjectStore)
+	manifest, err := ExportMessages(context.Background(), NewSliceMessageIterator(sinkMessages()), store, ExportOptions{EncryptionKey: key})
+	if err != nil {
+		t.Errorf("ExportMessages() returned error: %v", err)
+		return
+	}
+	b, ok := store["part-00000.jsonl.gz.enc"]
+	if len(manifest.Parts) != 1 || !ok || int64(b.Len()) != manifest.Parts[0].Bytes {
+		t.Errorf("Expected one encrypted part, got %+v", manifest)
+		return
+	}
+	r, err := NewDecryptingReader(b, key)
+	if err != nil {
+		t.Errorf("NewDecryptingReader() returned error: %v", err)
+		return
+	}
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Errorf("The decrypted part isn't gzipped: %v", err)
+		return
+	}
+	if lines, err := ioutil.ReadAll(gz); err != nil || strings.Count(string(lines), "\n") != 3 {
+		t.Errorf("Expected 3 lines in the decrypted part, got %q and %v", lines, err)
+	}
+
+	if _, err := ExportMessages(context.Background(), NewSliceMessageIterator(sinkMessages()), store, ExportOptions{EncryptionKey: []byte("short")}); err == nil {
+		t.Errorf("Expected an invalid encryption key to fail")
+	}
+}
+
+type abortableObjectStore struct {
+	closed, aborted []string
+}
+
+type abortableObject struct {
+	name  string
+	store *abortableObjectStore
+}
+
+func (o abortableObject) Write(b []byte) (int, error) { return len(b), nil }
+
+func (o abortableObject) Close() error {
+	o.store.closed = append(o.store.closed, o.name)
+	return nil
+}
+
+func (o abortableObject) Abort() error {
+	o.store.aborted = append(o.store.aborted, o.name)
+	return nil
+}
+
+func (s *abortableObjectStore) Create(ctx context.Context, name string) (io.WriteCloser, error) {
+	return abortableObject{name: name, store: s}, nil
+}
+
+type failingMessageIterator struct {
+	MessageIterator
+}
+
+func (failingMessageIterator) Err() error { return errors.New("search job expired") }
+
+func TestExportMessagesAbortsFailedPart(t *testing.T) {
+	store := new(abortableObjectStore)
+	it := failingMessageIterator{NewSliceMessageIterator(sinkMessages())}
+	if _, err := ExportMessages(context.Background(), it, store, ExportOptions{PartSize: 2}); err == nil {
+		t.Errorf("Expected the iterator's error")
+		return
+	}
+	if len(store.closed) != 1 || store.closed[0] != "part-00000.jsonl.gz" {
+		t.Errorf("Expected only the full part to be completed, got %v", store.closed)
+	}
+	if len(store.aborted) != 1 || store.aborted[0] != "part-00001.jsonl.gz" {
+		t.Errorf("Expected the unfinished part to be aborted, got %v", store.aborted)
+	}
+}
@@ -0,0 +1,136 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ExtractionRule is a field extraction rule, which parses fields out of the messages
+// matching its scope at ingest time.
+type ExtractionRule struct {
+	ID              string   `json:"id,omitempty"`
+	Name            string   `json:"name"`
+	Scope           string   `json:"scope"`
+	ParseExpression string   `json:"parseExpression"`
+	Enabled         bool     `json:"enabled"`
+	FieldNames      []string `json:"fieldNames,omitempty"`
+	CreatedAt       string   `json:"createdAt,omitempty"`
+	CreatedBy       string   `json:"createdBy,omitempty"`
+	ModifiedAt      string   `json:"modifiedAt,omitempty"`
+	ModifiedBy      string   `json:"modifiedBy,omitempty"`
+}
+
+// ExtractionRuleList is one page of field extraction rules. Next is the token for the
+// following page and is empty on the last page.
+type ExtractionRuleList struct {
+	Data []ExtractionRule `json:"data"`
+	Next string           `json:"next,omitempty"`
+}
+
+// ErrExtractionRuleNotFound is returned when a field extraction rule doesn't exist.
+var ErrExtractionRuleNotFound = errors.New("Extraction rule not found")
+
+// ListExtractionRules returns one page of field extraction rules. A limit of 0 uses the
+// API default.
+func (c *Client) ListExtractionRules(limit int, token string, opts ...CallOption) (*ExtractionRuleList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "extractionRules"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var erl = new(ExtractionRuleList)
+	if err := c.do(call{method: "GET", path: path, out: erl}, opts); err != nil {
+		return nil, err
+	}
+	return erl, nil
+}
+
+// ListAllExtractionRules follows the pagination tokens and returns every field extraction rule.
+func (c *Client) ListAllExtractionRules(opts ...CallOption) ([]ExtractionRule, error) {
+	var rules []ExtractionRule
+	token := ""
+	for {
+		erl, err := c.ListExtractionRules(0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, erl.Data...)
+		if erl.Next == "" {
+			return rules, nil
+		}
+		token = erl.Next
+	}
+}
+
+// GetExtractionRule gets the field extraction rule with the specified ID.
+func (c *Client) GetExtractionRule(id string, opts ...CallOption) (*ExtractionRule, error) {
+	return c.sendExtractionRule(call{method: "GET", path: fmt.Sprintf("extractionRules/%s", id)}, opts)
+}
+
+// CreateExtractionRule creates a field extraction rule. Fields it parses that aren't
+// defined yet are created.
+func (c *Client) CreateExtractionRule(rule ExtractionRule, opts ...CallOption) (*ExtractionRule, error) {
+	return c.sendExtractionRule(call{
+		method:     "POST",
+		path:       "extractionRules",
+		in:         rule,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if an extraction rule with this name `%s` already exists and its parse expression is valid", rule.Name),
+	}, opts)
+}
+
+// UpdateExtractionRule replaces the field extraction rule with the same ID.
+func (c *Client) UpdateExtractionRule(rule ExtractionRule, opts ...CallOption) (*ExtractionRule, error) {
+	return c.sendExtractionRule(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("extractionRules/%s", rule.ID),
+		in:         rule,
+		badRequest: fmt.Errorf("Bad Request. Please check the parse expression of extraction rule `%s` is valid", rule.Name),
+	}, opts)
+}
+
+// DeleteExtractionRule deletes the field extraction rule with the specified ID.
+func (c *Client) DeleteExtractionRule(id string, opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("extractionRules/%s", id), notFound: ErrExtractionRuleNotFound}, opts)
+}
+
+// ExtractionRules returns a ResourceClient for field extraction rules.
+func (c *Client) ExtractionRules(opts ...CallOption) ResourceClient[ExtractionRule] {
+	return &resourceClient[ExtractionRule]{
+		list: func() ([]ExtractionRule, error) {
+			return c.ListAllExtractionRules(opts...)
+		},
+		get: func(id string) (*ExtractionRule, error) {
+			return c.GetExtractionRule(id, opts...)
+		},
+		create: func(r ExtractionRule) (*ExtractionRule, error) {
+			return c.CreateExtractionRule(r, opts...)
+		},
+		update: func(r ExtractionRule) (*ExtractionRule, error) {
+			return c.UpdateExtractionRule(r, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteExtractionRule(id, opts...)
+		},
+	}
+}
+
+// sendExtractionRule makes a call returning an extraction rule.
+func (c *Client) sendExtractionRule(cl call, opts []CallOption) (*ExtractionRule, error) {
+	var r = new(ExtractionRule)
+	cl.out = r
+	cl.notFound = ErrExtractionRuleNotFound
+	if err := c.do(cl, opts); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
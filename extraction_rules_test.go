@@ -0,0 +1,86 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListAllExtractionRulesFollowsToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/extractionRules" {
+			t.Errorf("Expected request to ‘/extractionRules’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var erl ExtractionRuleList
+		switch r.URL.Query().Get("token") {
+		case "":
+			erl = ExtractionRuleList{Data: []ExtractionRule{{ID: "1", Name: "one"}}, Next: "page2"}
+		case "page2":
+			erl = ExtractionRuleList{Data: []ExtractionRule{{ID: "2", Name: "two"}}}
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		json.NewEncoder(w).Encode(erl)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	rules, err := c.ListAllExtractionRules()
+	if err != nil {
+		t.Errorf("ListAllExtractionRules() returned an error: %s", err)
+		return
+	}
+	if len(rules) != 2 {
+		t.Errorf("ListAllExtractionRules() expected 2 rules, got %d", len(rules))
+	}
+}
+
+func TestUpdateExtractionRule(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected ‘PUT’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/extractionRules/r1" {
+			t.Errorf("Expected request to ‘/extractionRules/r1’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var rule ExtractionRule
+		if err := json.Unmarshal(body, &rule); err != nil {
+			t.Errorf("Unable to unmarshal ExtractionRule, got `%s`", body)
+		}
+		rule.FieldNames = []string{"status"}
+		json.NewEncoder(w).Encode(rule)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	rule, err := c.UpdateExtractionRule(ExtractionRule{
+		ID:              "r1",
+		Name:            "access logs",
+		Scope:           "_sourceCategory=prod/nginx",
+		ParseExpression: `parse "status=*" as status`,
+		Enabled:         true,
+	})
+	if err != nil {
+		t.Errorf("UpdateExtractionRule() returned an error: %s", err)
+		return
+	}
+	if len(rule.FieldNames) != 1 || rule.FieldNames[0] != "status" {
+		t.Errorf("Expected the updated rule's fields, got %v", rule.FieldNames)
+	}
+}
+
+func TestCreateExtractionRuleBadRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors": [{"code": "fer:invalid_parse_expression", "message": "Invalid parse expression"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if _, err := c.CreateExtractionRule(ExtractionRule{Name: "broken", ParseExpression: "parse"}); err == nil {
+		t.Errorf("Expected an error for a bad request")
+	}
+}
@@ -0,0 +1,137 @@
+package sumologic
+
+// Feature names an API group or capability of the SDK, so consumers built against
+// different versions of it can detect what's available with Client.Supports.
+type Feature string
+
+// Features of the stable client.
+const (
+	FeatureSearch             Feature = "search"
+	FeatureMetrics            Feature = "metrics"
+	FeatureMetricsQueries     Feature = "metricsQueries"
+	FeatureCollectors         Feature = "collectors"
+	FeatureSources            Feature = "sources"
+	FeatureContent            Feature = "content"
+	FeatureFolders            Feature = "folders"
+	FeatureDashboards         Feature = "dashboards"
+	FeatureMonitors           Feature = "monitors"
+	FeatureSLOs               Feature = "slos"
+	FeatureConnections        Feature = "connections"
+	FeatureUsers              Feature = "users"
+	FeatureFields             Feature = "fields"
+	FeatureExtractionRules    Feature = "extractionRules"
+	FeaturePartitions         Feature = "partitions"
+	FeatureScheduledViews     Feature = "scheduledViews"
+	FeatureLookupTables       Feature = "lookupTables"
+	FeatureIngestBudgets      Feature = "ingestBudgets"
+	FeatureMutingSchedules    Feature = "mutingSchedules"
+	FeatureSearchBackend      Feature = "searchBackend"
+	FeatureLatencyBudgets     Feature = "latencyBudgets"
+	FeatureJournal            Feature = "journal"
+	FeatureSimulation         Feature = "simulation"
+	FeatureProfileLabels      Feature = "profileLabels"
+	FeatureResourceClient     Feature = "resourceClient"
+	FeatureRetryPolicies      Feature = "retryPolicies"
+	FeatureReadOnly           Feature = "readOnly"
+	FeatureContentTemplates   Feature = "contentTemplates"
+	FeatureQueryLibrary       Feature = "queryLibrary"
+	FeatureTokens             Feature = "tokens"
+	FeaturePasswordPolicy     Feature = "passwordPolicy"
+	FeatureAccount            Feature = "account"
+	FeatureSearchQuota        Feature = "searchQuota"
+	FeatureDataForwarding     Feature = "dataForwarding"
+	FeatureAlertEnrichment    Feature = "alertEnrichment"
+	FeatureApps               Feature = "apps"
+	FeatureSearchCoalescing   Feature = "searchCoalescing"
+	FeatureContentPermissions Feature = "contentPermissions"
+	FeatureSearchUsage        Feature = "searchUsage"
+	FeatureSpanQueries        Feature = "spanQueries"
+)
+
+// Features of the experimental package, whose API may change in any release.
+const (
+	FeatureHealthEvents Feature = "healthEvents"
+)
+
+// Stability is the compatibility promise of a feature.
+type Stability int
+
+const (
+	// Stable features keep their API until the next major version.
+	Stable Stability = iota + 1
+	// Experimental features live in the experimental package and may change or be removed
+	// in any release. Once promoted, a feature moves into this package with the same
+	// names and becomes Stable; the experimental API then forwards to it for one minor
+	// release before it's removed.
+	Experimental
+)
+
+func (s Stability) String() string {
+	switch s {
+	case Stable:
+		return "stable"
+	case Experimental:
+		return "experimental"
+	default:
+		return "unknown"
+	}
+}
+
+// features is the registry of the features built into this version of the SDK.
+var features = map[Feature]Stability{
+	FeatureSearch:             Stable,
+	FeatureMetrics:            Stable,
+	FeatureMetricsQueries:     Stable,
+	FeatureCollectors:         Stable,
+	FeatureSources:            Stable,
+	FeatureContent:            Stable,
+	FeatureFolders:            Stable,
+	FeatureDashboards:         Stable,
+	FeatureMonitors:           Stable,
+	FeatureSLOs:               Stable,
+	FeatureConnections:        Stable,
+	FeatureUsers:              Stable,
+	FeatureFields:             Stable,
+	FeatureExtractionRules:    Stable,
+	FeaturePartitions:         Stable,
+	FeatureScheduledViews:     Stable,
+	FeatureLookupTables:       Stable,
+	FeatureIngestBudgets:      Stable,
+	FeatureMutingSchedules:    Stable,
+	FeatureSearchBackend:      Stable,
+	FeatureLatencyBudgets:     Stable,
+	FeatureJournal:            Stable,
+	FeatureSimulation:         Stable,
+	FeatureProfileLabels:      Stable,
+	FeatureResourceClient:     Stable,
+	FeatureRetryPolicies:      Stable,
+	FeatureReadOnly:           Stable,
+	FeatureContentTemplates:   Stable,
+	FeatureQueryLibrary:       Stable,
+	FeatureTokens:             Stable,
+	FeaturePasswordPolicy:     Stable,
+	FeatureAccount:            Stable,
+	FeatureSearchQuota:        Stable,
+	FeatureDataForwarding:     Stable,
+	FeatureAlertEnrichment:    Stable,
+	FeatureApps:               Stable,
+	FeatureSearchCoalescing:   Stable,
+	FeatureContentPermissions: Stable,
+	FeatureSearchUsage:        Stable,
+	FeatureSpanQueries:        Stable,
+
+	FeatureHealthEvents: Experimental,
+}
+
+// Supports reports whether this version of the SDK has the feature, stable or experimental.
+func (c *Client) Supports(feature Feature) bool {
+	_, ok := features[feature]
+	return ok
+}
+
+// FeatureStability returns the stability of the feature, and false when this version of
+// the SDK doesn't have it.
+func FeatureStability(feature Feature) (Stability, bool) {
+	s, ok := features[feature]
+	return s, ok
+}
@@ -0,0 +1,20 @@
+package sumologic
+
+import "testing"
+
+func TestSupports(t *testing.T) {
+	c, _ := NewClient("accessToken", "http://localhost")
+
+	if !c.Supports(FeatureMonitors) {
+		t.Errorf("Expected monitors to be supported")
+	}
+	if c.Supports(Feature("teleportation")) {
+		t.Errorf("Expected an unknown feature not to be supported")
+	}
+	if s, ok := FeatureStability(FeatureHealthEvents); !ok || s != Experimental {
+		t.Errorf("Expected health events to be experimental, got %s", s)
+	}
+	if s, ok := FeatureStability(FeatureLookupTables); !ok || s != Stable {
+		t.Errorf("Expected lookup tables to be stable, got %s", s)
+	}
+}
@@ -0,0 +1,157 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Field states.
+const (
+	FieldStateEnabled  = "Enabled"
+	FieldStateDisabled = "Disabled"
+)
+
+// Field is a custom or built-in field of the organization's schema.
+type Field struct {
+	FieldID   string `json:"fieldId,omitempty"`
+	FieldName string `json:"fieldName"`
+	DataType  string `json:"dataType,omitempty"`
+	State     string `json:"state,omitempty"`
+}
+
+// DroppedField is a field the organization received but dropped because it isn't defined.
+type DroppedField struct {
+	FieldName string `json:"fieldName"`
+}
+
+// FieldQuota is how many custom fields the organization may define and how many are left.
+type FieldQuota struct {
+	Quota     int `json:"quota"`
+	Remaining int `json:"remaining"`
+}
+
+// fieldList is the response to listing fields.
+type fieldList struct {
+	Data []Field `json:"data"`
+}
+
+// droppedFieldList is the response to listing dropped fields.
+type droppedFieldList struct {
+	Data []DroppedField `json:"data"`
+}
+
+// ErrFieldNotFound is returned when a field doesn't exist.
+var ErrFieldNotFound = errors.New("Field not found")
+
+// ListFields lists the custom fields.
+func (c *Client) ListFields(opts ...CallOption) ([]Field, error) {
+	return c.listFields("fields", opts...)
+}
+
+// ListBuiltInFields lists the built-in fields, such as _sourceCategory.
+func (c *Client) ListBuiltInFields(opts ...CallOption) ([]Field, error) {
+	return c.listFields("fields/builtin", opts...)
+}
+
+// ListDroppedFields lists the fields that were received but dropped because they aren't
+// defined, which are candidates for CreateField.
+func (c *Client) ListDroppedFields(opts ...CallOption) ([]DroppedField, error) {
+	var dfl = new(droppedFieldList)
+	if err := c.do(call{method: "GET", path: "fields/dropped", out: dfl}, opts); err != nil {
+		return nil, err
+	}
+	return dfl.Data, nil
+}
+
+// GetFieldQuota gets the custom field quota of the organization.
+func (c *Client) GetFieldQuota(opts ...CallOption) (*FieldQuota, error) {
+	var fq = new(FieldQuota)
+	if err := c.do(call{method: "GET", path: "fields/quota", out: fq}, opts); err != nil {
+		return nil, err
+	}
+	return fq, nil
+}
+
+// GetField gets the custom field with the specified ID.
+func (c *Client) GetField(id string, opts ...CallOption) (*Field, error) {
+	var f = new(Field)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("fields/%s", id),
+		out:      f,
+		notFound: ErrFieldNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// CreateField adds a custom field with the specified name.
+func (c *Client) CreateField(name string, opts ...CallOption) (*Field, error) {
+	var f = new(Field)
+	err := c.do(call{
+		method:     "POST",
+		path:       "fields",
+		in:         Field{FieldName: name},
+		out:        f,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if a field with this name `%s` already exists or the quota is used up", name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// EnableField starts extracting the custom field with the specified ID.
+func (c *Client) EnableField(id string, opts ...CallOption) error {
+	return c.fieldAction("PUT", fmt.Sprintf("fields/%s/enable", id), opts)
+}
+
+// DisableField stops extracting the custom field with the specified ID. A field must be
+// disabled before it's deleted.
+func (c *Client) DisableField(id string, opts ...CallOption) error {
+	return c.fieldAction("DELETE", fmt.Sprintf("fields/%s/disable", id), opts)
+}
+
+// DeleteField deletes the custom field with the specified ID.
+func (c *Client) DeleteField(id string, opts ...CallOption) error {
+	return c.fieldAction("DELETE", fmt.Sprintf("fields/%s", id), opts)
+}
+
+// Fields returns a ResourceClient for custom fields. Fields can't be updated through it.
+func (c *Client) Fields(opts ...CallOption) ResourceClient[Field] {
+	return &resourceClient[Field]{
+		list: func() ([]Field, error) {
+			return c.ListFields(opts...)
+		},
+		get: func(id string) (*Field, error) {
+			return c.GetField(id, opts...)
+		},
+		create: func(f Field) (*Field, error) {
+			return c.CreateField(f.FieldName, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteField(id, opts...)
+		},
+	}
+}
+
+func (c *Client) listFields(path string, opts ...CallOption) ([]Field, error) {
+	var fl = new(fieldList)
+	if err := c.do(call{method: "GET", path: path, out: fl}, opts); err != nil {
+		return nil, err
+	}
+	return fl.Data, nil
+}
+
+// fieldAction sends a request that has no response body.
+func (c *Client) fieldAction(method, path string, opts []CallOption) error {
+	return c.do(call{
+		method:     method,
+		path:       path,
+		notFound:   ErrFieldNotFound,
+		badRequest: errors.New("Bad Request. Please check the field can be changed; it must be disabled before it's deleted"),
+	}, opts)
+}
@@ -0,0 +1,85 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateField(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/fields" {
+			t.Errorf("Expected request to ‘/fields’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var f Field
+		if err := json.Unmarshal(body, &f); err != nil {
+			t.Errorf("Unable to unmarshal Field, got `%s`", body)
+		}
+		f.FieldID, f.DataType, f.State = "f1", "String", FieldStateEnabled
+		json.NewEncoder(w).Encode(f)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	f, err := c.CreateField("cluster")
+	if err != nil {
+		t.Errorf("CreateField() returned an error: %s", err)
+		return
+	}
+	if f.FieldID != "f1" || f.FieldName != "cluster" {
+		t.Errorf("Expected the created field, got %+v", f)
+	}
+}
+
+func TestListDroppedFieldsAndQuota(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/fields/dropped":
+			w.Write([]byte(`{"data": [{"fieldName": "pod"}, {"fieldName": "namespace"}]}`))
+		case "/fields/quota":
+			w.Write([]byte(`{"quota": 200, "remaining": 150}`))
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	dropped, err := c.ListDroppedFields()
+	if err != nil {
+		t.Errorf("ListDroppedFields() returned an error: %s", err)
+		return
+	}
+	if len(dropped) != 2 || dropped[0].FieldName != "pod" {
+		t.Errorf("Expected 2 dropped fields, got %+v", dropped)
+	}
+	quota, err := c.GetFieldQuota()
+	if err != nil {
+		t.Errorf("GetFieldQuota() returned an error: %s", err)
+		return
+	}
+	if quota.Quota != 200 || quota.Remaining != 150 {
+		t.Errorf("Expected a quota of 200 with 150 remaining, got %+v", quota)
+	}
+}
+
+func TestDeleteFieldDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.DeleteField("f1"); err != ErrFieldNotFound {
+		t.Errorf("Expected ErrFieldNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package sumologic
+
+import (
+	"fmt"
+)
+
+// ContentItemTypeFolder is the item type of folders in the content library.
+const ContentItemTypeFolder = "Folder"
+
+// ContentItem is an item of the content library, such as a folder, dashboard or saved search.
+type ContentItem struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	ParentID    string   `json:"parentId,omitempty"`
+	ItemType    string   `json:"itemType,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	CreatedAt   string   `json:"createdAt,omitempty"`
+	CreatedBy   string   `json:"createdBy,omitempty"`
+	ModifiedAt  string   `json:"modifiedAt,omitempty"`
+	ModifiedBy  string   `json:"modifiedBy,omitempty"`
+}
+
+// Folder is a folder of the content library with the items directly in it.
+type Folder struct {
+	ContentItem
+	Children []ContentItem `json:"children,omitempty"`
+}
+
+// CreateFolderRequest creates a folder in the folder with ParentID.
+type CreateFolderRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ParentID    string `json:"parentId"`
+}
+
+// UpdateFolderRequest renames a folder or changes its description.
+type UpdateFolderRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// globalFolderList is the result of the global folder job.
+type globalFolderList struct {
+	Data []ContentItem `json:"data"`
+}
+
+// GetPersonalFolder gets the caller's personal folder.
+func (c *Client) GetPersonalFolder(opts ...CallOption) (*Folder, error) {
+	return c.getFolder("../v2/content/folders/personal", opts...)
+}
+
+// GetFolder gets the folder with the specified ID.
+func (c *Client) GetFolder(id string, opts ...CallOption) (*Folder, error) {
+	return c.getFolder(fmt.Sprintf("../v2/content/folders/%s", id), opts...)
+}
+
+// GetGlobalFolder lists the top-level folders of every user, waiting for the job that
+// collects them. Call it in admin mode to include folders not shared with the caller.
+func (c *Client) GetGlobalFolder(opts ...CallOption) ([]ContentItem, error) {
+	var gfl = new(globalFolderList)
+	if err := c.runFolderJob("global", gfl, opts...); err != nil {
+		return nil, err
+	}
+	return gfl.Data, nil
+}
+
+// GetAdminRecommendedFolder gets the folder of content recommended by administrators,
+// waiting for the job that collects it.
+func (c *Client) GetAdminRecommendedFolder(opts ...CallOption) (*Folder, error) {
+	var f = new(Folder)
+	if err := c.runFolderJob("adminRecommended", f, opts...); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// CreateFolder creates a folder.
+func (c *Client) CreateFolder(cfr CreateFolderRequest, opts ...CallOption) (*Folder, error) {
+	var f = new(Folder)
+	err := c.do(call{
+		method:     "POST",
+		path:       "../v2/content/folders",
+		in:         cfr,
+		out:        f,
+		create:     true,
+		notFound:   ErrContentNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check if a folder with this name `%s` already exists", cfr.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UpdateFolder updates the folder with the specified ID.
+func (c *Client) UpdateFolder(id string, ufr UpdateFolderRequest, opts ...CallOption) (*Folder, error) {
+	var f = new(Folder)
+	err := c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("../v2/content/folders/%s", id),
+		in:         ufr,
+		out:        f,
+		notFound:   ErrContentNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check if a folder with this name `%s` already exists", ufr.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// WalkFolder calls fn for every item under the folder with the specified ID, depth
+// first, getting each subfolder as it's reached. path holds the names of the folders
+// between the starting folder and the item. An error from fn stops the walk.
+func (c *Client) WalkFolder(id string, fn func(item ContentItem, path []string) error, opts ...CallOption) error {
+	return c.walkFolder(id, nil, fn, opts)
+}
+
+func (c *Client) walkFolder(id string, path []string, fn func(ContentItem, []string) error, opts []CallOption) error {
+	f, err := c.GetFolder(id, opts...)
+	if err != nil {
+		return err
+	}
+	for _, item := range f.Children {
+		if err := fn(item, path); err != nil {
+			return err
+		}
+		if item.ItemType == ContentItemTypeFolder {
+			sub := append(path[:len(path):len(path)], item.Name)
+			if err := c.walkFolder(item.ID, sub, fn, opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) getFolder(path string, opts ...CallOption) (*Folder, error) {
+	var f = new(Folder)
+	if err := c.do(call{method: "GET", path: path, out: f, notFound: ErrContentNotFound}, opts); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// runFolderJob starts the job collecting the global or admin recommended folder, waits
+// for it and decodes its result into out.
+func (c *Client) runFolderJob(folder string, out interface{}, opts ...CallOption) error {
+	var job = new(contentJob)
+	if err := c.do(call{method: "GET", path: fmt.Sprintf("../v2/content/folders/%s", folder), out: job}, opts); err != nil {
+		return err
+	}
+	err := c.waitForContentJob(job.ID, func() (*ContentJobStatus, error) {
+		return c.getContentJobStatus(fmt.Sprintf("../v2/content/folders/%s/%s/status", folder, job.ID), opts...)
+	}, opts)
+	if err != nil {
+		return err
+	}
+	return c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("../v2/content/folders/%s/%s/result", folder, job.ID),
+		out:      out,
+		notFound: ErrContentNotFound,
+	}, opts)
+}
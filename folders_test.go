@@ -0,0 +1,102 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetGlobalFolder(t *testing.T) {
+	contentJobPollInterval = time.Millisecond
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		switch r.URL.EscapedPath() {
+		case "/v2/content/folders/global":
+			w.Write([]byte(`{"id": "job1"}`))
+		case "/v2/content/folders/global/job1/status":
+			w.Write([]byte(`{"status": "Success"}`))
+		case "/v2/content/folders/global/job1/result":
+			w.Write([]byte(`{"data": [{"id": "f1", "name": "Alice", "itemType": "Folder"}, {"id": "f2", "name": "Bob", "itemType": "Folder"}]}`))
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	folders, err := c.GetGlobalFolder()
+	if err != nil {
+		t.Errorf("GetGlobalFolder() returned an error: %s", err)
+		return
+	}
+	if len(folders) != 2 || folders[1].Name != "Bob" {
+		t.Errorf("Expected the 2 global folders, got %+v", folders)
+	}
+}
+
+func TestCreateFolder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/v2/content/folders" {
+			t.Errorf("Expected request to ‘/v2/content/folders’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var cfr CreateFolderRequest
+		json.Unmarshal(body, &cfr)
+		if cfr.ParentID != "p1" {
+			t.Errorf("Expected parent ‘p1’, got ‘%s’", cfr.ParentID)
+		}
+		json.NewEncoder(w).Encode(Folder{ContentItem: ContentItem{ID: "f3", Name: cfr.Name, ParentID: cfr.ParentID, ItemType: ContentItemTypeFolder}})
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	f, err := c.CreateFolder(CreateFolderRequest{Name: "checkout", ParentID: "p1"})
+	if err != nil {
+		t.Errorf("CreateFolder() returned an error: %s", err)
+		return
+	}
+	if f.ID != "f3" || f.Name != "checkout" {
+		t.Errorf("Unexpected folder %+v", f)
+	}
+}
+
+func TestWalkFolder(t *testing.T) {
+	folders := map[string]string{
+		"root": `{"id": "root", "name": "Root", "children": [{"id": "a", "name": "A", "itemType": "Folder"}, {"id": "s1", "name": "Errors", "itemType": "Search"}]}`,
+		"a":    `{"id": "a", "name": "A", "children": [{"id": "d1", "name": "Overview", "itemType": "Dashboard"}]}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.EscapedPath(), "/v2/content/folders/")
+		body, ok := folders[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	var visited []string
+	err := c.WalkFolder("root", func(item ContentItem, path []string) error {
+		visited = append(visited, strings.Join(append(path, item.Name), "/"))
+		return nil
+	})
+	if err != nil {
+		t.Errorf("WalkFolder() returned an error: %s", err)
+		return
+	}
+	expected := []string{"A", "A/Overview", "Errors"}
+	if strings.Join(visited, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected to visit %v, got %v", expected, visited)
+	}
+}
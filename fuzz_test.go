@@ -0,0 +1,232 @@
+package sumologic
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// bodyTransport answers every request with its status code and body, without a server.
+type bodyTransport struct {
+	status int
+	body   []byte
+}
+
+func (t *bodyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: t.status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func fuzzClient(status int, body []byte) *Client {
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/",
+		WithHTTPClient(&http.Client{Transport: &bodyTransport{status: status, body: body}}),
+		WithoutRetries())
+	return c
+}
+
+// checkDecodeError fails the test unless err is nil or one of the SDK's typed errors.
+func checkDecodeError(t *testing.T, err error) {
+	var de *DecodeError
+	var ae *APIError
+	if err != nil && !errors.As(err, &de) && !errors.As(err, &ae) {
+		t.Errorf("Expected a *DecodeError or *APIError, got %T: %v", err, err)
+	}
+}
+
+func FuzzStartSearch(f *testing.F) {
+	f.Add([]byte(`{"id": "123", "link": {"rel": "self", "href": "https://api.sumologic.com/api/v1/search/jobs/123"}}`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusAccepted, body)
+		sj, _, err := c.StartSearch(StartSearchRequest{Query: "error"})
+		checkDecodeError(t, err)
+		if err == nil && sj.ID == "" {
+			t.Errorf("Expected a search job with an ID")
+		}
+	})
+}
+
+func FuzzGetSearchJobStatus(f *testing.F) {
+	f.Add([]byte(`{"state": "DONE GATHERING RESULTS", "messageCount": 90, "histogramBuckets": [{"length": 60000, "count": 1, "startTimestamp": 1359404820000}], "pendingErrors": [], "pendingWarnings": [], "recordCount": 1}`))
+	f.Add([]byte(`{"histogramBuckets": [null]}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		_, err := c.GetSearchJobStatus("123", nil)
+		checkDecodeError(t, err)
+	})
+}
+
+func FuzzGetSearchResults(f *testing.F) {
+	f.Add([]byte(`{"fields": [{"name": "_messagetime", "fieldType": "long", "keyField": false}], "messages": [{"map": {"_messagetime": "1359407350899", "_raw": "error"}}]}`))
+	f.Add([]byte(`{"messages": [null]}`))
+	f.Add([]byte(`{"messages": [{"map": {"_raw": 1}}]}`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		c.Redactor = NewRedactor(RedactionRule{Fields: []string{"_raw"}})
+		result, err := c.GetSearchResults(SearchJobResultsRequest{ID: "123", Limit: 100}, nil)
+		checkDecodeError(t, err)
+		if err != nil {
+			return
+		}
+		columns := SinkSchema(result.Messages)
+		for _, m := range result.Messages {
+			sinkRow(m, columns)
+		}
+	})
+}
+
+func FuzzGetSearchRecords(f *testing.F) {
+	f.Add([]byte(`{"fields": [{"name": "_count", "fieldType": "int", "keyField": false}], "records": [{"map": {"_count": "90"}}]}`))
+	f.Add([]byte(`{"fields": [null], "records": [null]}`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		result, err := c.GetSearchRecords(SearchJobRecordsRequest{ID: "123", Limit: 100}, nil)
+		checkDecodeError(t, err)
+		if err != nil {
+			return
+		}
+		for _, r := range result.Records {
+			for name := range r.Map {
+				result.Typed(r, name)
+			}
+		}
+	})
+}
+
+func FuzzListSources(f *testing.F) {
+	f.Add([]byte(`{"sources": [{"id": 1, "name": "http", "sourceType": "HTTP"}, {"id": 2, "sourceType": "Polling", "contentType": "AwsS3Bucket", "thirdPartyRef": {"resources": []}}]}`))
+	f.Add([]byte(`{"sources": [null, 1, "x"]}`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		sources, err := c.ListSources(1)
+		if err != nil {
+			return
+		}
+		for _, s := range sources {
+			if s.Base() == nil {
+				t.Errorf("Expected every source to have a base")
+			}
+		}
+	})
+}
+
+func FuzzAPIError(f *testing.F) {
+	f.Add(400, []byte(`{"id": "ABC", "errors": [{"code": "field:invalid", "message": "Invalid field", "meta": {"field": "name"}}]}`))
+	f.Add(429, []byte(`{"status": 429, "code": "rate.limit.exceeded", "message": "Rate limit exceeded"}`))
+	f.Add(500, []byte(`<html>`))
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		if status < 400 || status > 599 {
+			return
+		}
+		c := fuzzClient(status, body)
+		_, err := c.GetMonitor("m1")
+		if err == nil {
+			t.Errorf("Expected an error for status %d", status)
+		}
+		_, err = c.CreateDashboard(Dashboard{Title: "d"})
+		if err == nil {
+			t.Errorf("Expected an error for status %d", status)
+		}
+	})
+}
+
+func FuzzGetHostedCollector(f *testing.F) {
+	f.Add([]byte(`{"collector": {"id": 1, "name": "hosted", "collectorType": "Hosted"}}`))
+	f.Add([]byte(`{"collector": null}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		collector, _, err := c.GetHostedCollector(1)
+		checkDecodeError(t, err)
+		if err == nil && collector == nil {
+			t.Errorf("Expected a collector or an error")
+		}
+	})
+}
+
+func FuzzGetEntity(f *testing.F) {
+	f.Add([]byte(`{"id": "e1", "name": "checkout", "type": "service"}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		e, err := c.GetEntity("e1")
+		checkDecodeError(t, err)
+		if err == nil && e == nil {
+			t.Errorf("Expected an entity or an error")
+		}
+	})
+}
+
+func FuzzGetMonitor(f *testing.F) {
+	f.Add([]byte(`{"id": "m1", "name": "errors", "type": "MonitorsLibraryMonitor", "monitorType": "Logs"}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		m, err := c.GetMonitor("m1")
+		checkDecodeError(t, err)
+		if err == nil && m == nil {
+			t.Errorf("Expected a monitor or an error")
+		}
+	})
+}
+
+func FuzzGetUser(f *testing.F) {
+	f.Add([]byte(`{"id": "u1", "firstName": "Ada", "lastName": "Lovelace", "email": "ada@example.com", "roleIds": ["r1"]}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		u, err := c.GetUser("u1")
+		checkDecodeError(t, err)
+		if err == nil && u == nil {
+			t.Errorf("Expected a user or an error")
+		}
+	})
+}
+
+func FuzzListEndpoints(f *testing.F) {
+	f.Add([]byte(`{"data": [{"id": "1", "name": "one"}], "collectors": [{"id": 1, "name": "one"}]}`))
+	f.Add([]byte(`{"data": null, "next": "token"}`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		c := fuzzClient(http.StatusOK, body)
+		el, err := c.ListEntities(ListEntitiesRequest{})
+		checkDecodeError(t, err)
+		if err == nil && el == nil {
+			t.Errorf("Expected an entity list or an error")
+		}
+		// Every request gets the same body, so following a next token wouldn't end.
+		if err == nil && el.Next == "" {
+			_, err = c.ListAllEntities(ListEntitiesRequest{})
+			checkDecodeError(t, err)
+		}
+		ul, err := c.ListUsers(ListUsersFilter{}, 0, "")
+		checkDecodeError(t, err)
+		if err == nil && ul == nil {
+			t.Errorf("Expected a user list or an error")
+		}
+		cl, err := c.ListCollectors(ListCollectorsRequest{})
+		checkDecodeError(t, err)
+		if err == nil && cl == nil {
+			t.Errorf("Expected a collector list or an error")
+		}
+		connections, err := c.ListConnections(0, "")
+		checkDecodeError(t, err)
+		if err == nil && connections == nil {
+			t.Errorf("Expected a connection list or an error")
+		}
+		dl, err := c.ListDashboards(0, "")
+		checkDecodeError(t, err)
+		if err == nil && dl == nil {
+			t.Errorf("Expected a dashboard list or an error")
+		}
+	})
+}
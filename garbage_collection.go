@@ -0,0 +1,117 @@
+package sumologic
+
+import (
+	"sort"
+	"time"
+)
+
+// GCResource is what garbage collection needs to know about a resource.
+type GCResource struct {
+	ID          string
+	Name        string
+	Description string
+	// CreatedAt is when the resource was created. Resources without it are never collected.
+	CreatedAt time.Time
+}
+
+// GCTarget is one type of resource for garbage collection to look at.
+type GCTarget struct {
+	Kind   string
+	List   func() ([]GCResource, error)
+	Delete func(id string) error
+}
+
+// NewGCTarget returns a GCTarget for the resources of rc. describe returns what
+// garbage collection needs to know about a resource.
+func NewGCTarget[T any](kind string, rc ResourceClient[T], describe func(T) GCResource) GCTarget {
+	return GCTarget{
+		Kind: kind,
+		List: func() ([]GCResource, error) {
+			resources, err := rc.List()
+			if err != nil {
+				return nil, err
+			}
+			described := make([]GCResource, len(resources))
+			for i, r := range resources {
+				described[i] = describe(r)
+			}
+			return described, nil
+		},
+		Delete: rc.Delete,
+	}
+}
+
+// OrphanedResource is a resource garbage collection would delete.
+type OrphanedResource struct {
+	Kind     string
+	Resource GCResource
+	// MissingLabels are the required labels the resource doesn't have.
+	MissingLabels []string
+}
+
+// GCPlan lists the orphaned resources found by PlanGarbageCollection. Nothing is deleted
+// until it's applied, so printing the plan is a dry run.
+type GCPlan struct {
+	Orphans []OrphanedResource
+}
+
+// PlanGarbageCollection finds the resources of the targets created before the cutoff that
+// lack any of the required labels, such as test collectors nobody claimed. With no
+// required labels, resources without an owner label are orphaned.
+func PlanGarbageCollection(targets []GCTarget, createdBefore time.Time, requiredLabels ...string) (*GCPlan, error) {
+	if len(requiredLabels) == 0 {
+		requiredLabels = []string{LabelOwner}
+	}
+	plan := new(GCPlan)
+	for _, target := range targets {
+		resources, err := target.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			if r.CreatedAt.IsZero() || !r.CreatedAt.Before(createdBefore) {
+				continue
+			}
+			_, labels := ParseLabels(r.Description)
+			var missing []string
+			for _, key := range requiredLabels {
+				if labels[key] == "" {
+					missing = append(missing, key)
+				}
+			}
+			if len(missing) > 0 {
+				plan.Orphans = append(plan.Orphans, OrphanedResource{Kind: target.Kind, Resource: r, MissingLabels: missing})
+			}
+		}
+	}
+	sort.SliceStable(plan.Orphans, func(i, j int) bool {
+		return plan.Orphans[i].Resource.CreatedAt.Before(plan.Orphans[j].Resource.CreatedAt)
+	})
+	return plan, nil
+}
+
+// ApplyGarbageCollection deletes the orphans in a plan. confirm is called before each
+// deletion and the orphan is kept when it returns false; a nil confirm deletes everything.
+// The orphans that were deleted are returned, including when a deletion fails part way through.
+func ApplyGarbageCollection(plan *GCPlan, targets []GCTarget, confirm func(OrphanedResource) bool) ([]OrphanedResource, error) {
+	deleters := make(map[string]func(string) error)
+	for _, target := range targets {
+		deleters[target.Kind] = target.Delete
+	}
+
+	var deleted []OrphanedResource
+	for _, orphan := range plan.Orphans {
+		if confirm != nil && !confirm(orphan) {
+			continue
+		}
+		del := deleters[orphan.Kind]
+		if del == nil {
+			return deleted, ErrOperationNotSupported
+		}
+		if err := del(orphan.Resource.ID); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, orphan)
+	}
+	return deleted, nil
+}
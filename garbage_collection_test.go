@@ -0,0 +1,58 @@
+package sumologic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGarbageCollection(t *testing.T) {
+	cutoff := time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)
+	partitions := []Partition{
+		{ID: "1", Name: "old-test", CreatedAt: "2017-01-01T00:00:00Z"},
+		{ID: "2", Name: "owned", CreatedAt: "2017-01-01T00:00:00Z"},
+		{ID: "3", Name: "new", CreatedAt: "2017-04-01T00:00:00Z"},
+		{ID: "4", Name: "unknown-age"},
+		{ID: "5", Name: "older-test", CreatedAt: "2016-01-01T00:00:00Z"},
+	}
+	var deletedIDs []string
+	rc := &resourceClient[Partition]{
+		list: func() ([]Partition, error) { return partitions, nil },
+		delete: func(id string) error {
+			deletedIDs = append(deletedIDs, id)
+			return nil
+		},
+	}
+	target := NewGCTarget[Partition]("partition", rc, func(p Partition) GCResource {
+		created, _ := time.Parse(time.RFC3339, p.CreatedAt)
+		description := ""
+		if p.Name == "owned" {
+			description = WithLabels("", Labels{LabelOwner: "alice"})
+		}
+		return GCResource{ID: p.ID, Name: p.Name, Description: description, CreatedAt: created}
+	})
+	targets := []GCTarget{target}
+
+	plan, err := PlanGarbageCollection(targets, cutoff)
+	if err != nil {
+		t.Errorf("PlanGarbageCollection() returned error: %v", err)
+		return
+	}
+	if len(plan.Orphans) != 2 || plan.Orphans[0].Resource.ID != "5" || plan.Orphans[1].Resource.ID != "1" {
+		t.Errorf("Expected orphans 5 and 1, oldest first, got %+v", plan.Orphans)
+		return
+	}
+	if len(deletedIDs) != 0 {
+		t.Errorf("Expected planning not to delete anything, deleted %v", deletedIDs)
+	}
+
+	deleted, err := ApplyGarbageCollection(plan, targets, func(o OrphanedResource) bool {
+		return o.Resource.Name != "older-test"
+	})
+	if err != nil {
+		t.Errorf("ApplyGarbageCollection() returned error: %v", err)
+		return
+	}
+	if len(deleted) != 1 || len(deletedIDs) != 1 || deletedIDs[0] != "1" {
+		t.Errorf("Expected only the approved orphan 1 to be deleted, got %v", deletedIDs)
+	}
+}
@@ -1,13 +1,10 @@
 package sumologic
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/url"
+	"strconv"
 )
 
 // CollectorRequest is a necessary wrapper for collector API calls.
@@ -41,148 +38,119 @@ type CollectorLinks struct {
 // It's useful for ignoring errors (e.g. delete if exists).
 var ErrCollectorNotFound = errors.New("Collector not found")
 
-// GetHostedCollector gets the collector with the specified ID.
-func (s *Client) GetHostedCollector(id int) (*Collector, string, error) {
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d", id))
-	url := s.EndpointURL.ResolveReference(relativeURL)
+// ErrCollectorModified is returned by UpdateHostedCollector when the collector changed
+// since its ETag was read.
+var ErrCollectorModified = errors.New("Collector was modified since it was read")
 
-	req, err := http.NewRequest("GET", url.String(), nil)
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// GetHostedCollector gets the collector with the specified ID.
+func (s *Client) GetHostedCollector(id int, opts ...CallOption) (*Collector, string, error) {
+	var cr = new(CollectorRequest)
+	var header http.Header
+	err := s.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("collectors/%d", id),
+		out:      cr,
+		notFound: ErrCollectorNotFound,
+		header:   &header,
+	}, opts)
 	if err != nil {
 		return nil, "", err
 	}
-	defer resp.Body.Close()
-
-	ResponseBody, _ := ioutil.ReadAll(resp.Body)
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var cr = new(CollectorRequest)
-		err = json.Unmarshal(ResponseBody, &cr)
-		if err != nil {
-			return nil, "", err
-		}
-
-		return &cr.Collector, resp.Header.Get("ETag"), nil
-	case http.StatusUnauthorized:
-		return nil, "", ErrClientAuthenticationError
-	case http.StatusNotFound:
-		return nil, "", ErrCollectorNotFound
-	default:
-		return nil, "", fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
-	}
+	return &cr.Collector, header.Get("ETag"), nil
 }
 
 // CreateHostedCollector creates a new Hosted Collector.
-func (s *Client) CreateHostedCollector(collector Collector) (*Collector, error) {
-
+func (s *Client) CreateHostedCollector(collector Collector, opts ...CallOption) (*Collector, error) {
 	collectorRequest := CollectorRequest{
 		Collector: collector,
 	}
-
-	body, _ := json.Marshal(collectorRequest)
-
-	relativeURL, _ := url.Parse("collectors")
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("POST", url.String(), bytes.NewBuffer(body))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-
-	resp, err := http.DefaultClient.Do(req)
+	var cr = new(CollectorRequest)
+	err := s.do(call{
+		method:     "POST",
+		path:       "collectors",
+		in:         collectorRequest,
+		out:        cr,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if a collector with this name `%s` already exists", collector.Name),
+	}, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	responseBody, _ := ioutil.ReadAll(resp.Body)
-
-	switch resp.StatusCode {
-	case http.StatusCreated:
-		var cr = new(CollectorRequest)
-		err = json.Unmarshal(responseBody, &cr)
-		if err != nil {
-			return nil, err
-		}
-
-		return &cr.Collector, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		return nil, fmt.Errorf("Bad Request. Please check if a collector with this name `%s` already exists", collector.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
-	}
+	return &cr.Collector, nil
 }
 
-// UpdateHostedCollector updates an existing hosted collector.
-func (s *Client) UpdateHostedCollector(collector Collector, etag string) (*Collector, error) {
+// UpdateHostedCollector updates an existing hosted collector. The update only succeeds if
+// the collector hasn't changed since etag was read with GetHostedCollector; otherwise it
+// returns ErrCollectorModified.
+func (s *Client) UpdateHostedCollector(collector Collector, etag string, opts ...CallOption) (*Collector, error) {
 	collectorRequest := CollectorRequest{
 		Collector: collector,
 	}
-
-	body, _ := json.Marshal(collectorRequest)
-
-	relativeURL, _ := url.Parse(fmt.Sprintf("collectors/%d", collector.ID))
-	url := s.EndpointURL.ResolveReference(relativeURL)
-
-	req, err := http.NewRequest("PUT", url.String(), bytes.NewBuffer((body)))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
-	req.Header.Add("If-Match", etag)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	var cr = new(CollectorRequest)
+	err := s.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("collectors/%d", collector.ID),
+		in:         collectorRequest,
+		out:        cr,
+		badRequest: fmt.Errorf("Bad Request. Please check if a collector with this name `%s` already exists", collector.Name),
+		modified:   ErrCollectorModified,
+	}, append(append([]CallOption(nil), opts...), WithHeader("If-Match", etag)))
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	ResponseBody, _ := ioutil.ReadAll(resp.Body)
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var cr = new(CollectorRequest)
-		err = json.Unmarshal(ResponseBody, &cr)
-		if err != nil {
-			return nil, err
-		}
-
-		return &cr.Collector, nil
-	case http.StatusUnauthorized:
-		return nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		return nil, fmt.Errorf("Bad Request. Please check if a collector with this name `%s` already exists", collector.Name)
-	default:
-		return nil, fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
-	}
+	return &cr.Collector, nil
 }
 
 // DeleteHostedCollector deletes the collector with the specified ID.
-func (s *Client) DeleteHostedCollector(id int) error {
-	c, _ := url.Parse(fmt.Sprintf("collectors/%d", id))
-	req, err := http.NewRequest("DELETE", s.EndpointURL.ResolveReference(c).String(), nil)
-	req.Header.Add("Authorization", "Basic "+s.AuthToken)
+func (s *Client) DeleteHostedCollector(id int, opts ...CallOption) error {
+	return s.do(call{method: "DELETE", path: fmt.Sprintf("collectors/%d", id), notFound: ErrCollectorNotFound}, opts)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+// HostedCollectors returns a ResourceClient for hosted collectors. Update uses the ETag
+// of the collector's last Get, so it returns ErrCollectorModified if the collector changed
+// since; collectors that weren't read with Get first are updated whatever their state.
+func (s *Client) HostedCollectors(opts ...CallOption) ResourceClient[Collector] {
+	return s.collectorResourceClient(CollectorFilterHosted, opts...)
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return nil
-	case http.StatusNotFound:
-		return ErrCollectorNotFound
-	case http.StatusUnauthorized:
-		return ErrClientAuthenticationError
-	default:
-		return fmt.Errorf("Unknown Response with Sumo Logic: `%d`", resp.StatusCode)
+// collectorResourceClient returns a ResourceClient for collectors, listing those matching the filter.
+func (s *Client) collectorResourceClient(filter string, opts ...CallOption) *resourceClient[Collector] {
+	var etags etagCache
+	return &resourceClient[Collector]{
+		list: func() ([]Collector, error) {
+			return s.ListAllCollectors(ListCollectorsRequest{Filter: filter}, opts...)
+		},
+		get: func(id string) (*Collector, error) {
+			i, err := strconv.Atoi(id)
+			if err != nil {
+				return nil, err
+			}
+			collector, etag, err := s.GetHostedCollector(i, opts...)
+			if err != nil {
+				return nil, err
+			}
+			etags.remember(strconv.Itoa(i), etag)
+			return collector, nil
+		},
+		create: func(collector Collector) (*Collector, error) {
+			return s.CreateHostedCollector(collector, opts...)
+		},
+		update: func(collector Collector) (*Collector, error) {
+			etag, ok := etags.take(strconv.Itoa(collector.ID))
+			if !ok {
+				var err error
+				if _, etag, err = s.GetHostedCollector(collector.ID, opts...); err != nil {
+					return nil, err
+				}
+			}
+			return s.UpdateHostedCollector(collector, etag, opts...)
+		},
+		delete: func(id string) error {
+			i, err := strconv.Atoi(id)
+			if err != nil {
+				return err
+			}
+			return s.DeleteHostedCollector(i, opts...)
+		},
 	}
 }
@@ -0,0 +1,147 @@
+package sumologic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyRecord is what an IdempotencyStore keeps about a create call.
+type IdempotencyRecord struct {
+	// Pending is set while the call is in flight, and stays set when the call failed
+	// without a response, since the resource may or may not have been created.
+	Pending    bool
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Created    time.Time
+}
+
+// IdempotencyStore records the outcome of create calls by a fingerprint of their
+// request, so a repeated create doesn't make a duplicate resource.
+type IdempotencyStore interface {
+	Get(key string) (IdempotencyRecord, bool)
+	Put(key string, record IdempotencyRecord)
+	Delete(key string)
+}
+
+// CreateOutcomeUnknownError is returned for a create call repeating one that failed
+// without a response, e.g. because it timed out. Check whether the resource was created,
+// then delete Key from the store to allow the create again.
+type CreateOutcomeUnknownError struct {
+	Key string
+}
+
+func (e *CreateOutcomeUnknownError) Error() string {
+	return fmt.Sprintf("an identical create call failed without a response, so the resource may already exist (idempotency key %s)", e.Key)
+}
+
+// WithIdempotencyStore makes the client record create calls in store. A create repeating
+// one that succeeded returns the original result instead of creating a duplicate, and
+// one repeating a create with an unknown outcome fails with a *CreateOutcomeUnknownError.
+func WithIdempotencyStore(store IdempotencyStore) ClientOption {
+	return func(c *Client) {
+		c.Idempotency = store
+	}
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore that keeps records in memory for a TTL.
+type MemoryIdempotencyStore struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+// NewMemoryIdempotencyStore returns a store that forgets records after ttl.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{ttl: ttl, records: make(map[string]IdempotencyRecord)}
+}
+
+// Get returns the record for the key, unless it has expired.
+func (s *MemoryIdempotencyStore) Get(key string) (IdempotencyRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[key]
+	if ok && time.Since(r.Created) > s.ttl {
+		delete(s.records, key)
+		return r, false
+	}
+	return r, ok
+}
+
+// Put records the outcome of a create call.
+func (s *MemoryIdempotencyStore) Put(key string, record IdempotencyRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = record
+}
+
+// Delete forgets a create call.
+func (s *MemoryIdempotencyStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+}
+
+// idempotencyKey fingerprints a request by its credentials, method, URL and body. The
+// credentials keep clients of different accounts sharing a store apart.
+func idempotencyKey(req *http.Request) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s %s\n", req.Header.Get("Authorization"), req.Method, req.URL.String())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return "", err
+		}
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sendCreate sends a request creating a resource, recording it in the client's
+// idempotency store when there is one.
+func (c *Client) sendCreate(req *http.Request) (*http.Response, []byte, error) {
+	store := c.Idempotency
+	if store == nil {
+		return c.send(req)
+	}
+	key, err := idempotencyKey(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r, ok := store.Get(key); ok {
+		if r.Pending {
+			return nil, nil, &CreateOutcomeUnknownError{Key: key}
+		}
+		resp := &http.Response{
+			Status:     http.StatusText(r.StatusCode),
+			StatusCode: r.StatusCode,
+			Header:     r.Header,
+			Body:       ioutil.NopCloser(bytes.NewReader(r.Body)),
+			Request:    req,
+		}
+		return resp, r.Body, nil
+	}
+
+	store.Put(key, IdempotencyRecord{Pending: true, Created: time.Now()})
+	resp, body, err := c.send(req)
+	switch {
+	case err != nil:
+		// The pending record stays: the request may have been processed.
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		store.Put(key, IdempotencyRecord{StatusCode: resp.StatusCode, Header: resp.Header, Body: body, Created: time.Now()})
+	default:
+		store.Delete(key)
+	}
+	return resp, body, err
+}
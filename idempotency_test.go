@@ -0,0 +1,80 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIdempotentCreate(t *testing.T) {
+	var creates int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&creates, 1)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"collector": {"id": 1, "name": "test"}}`))
+	}))
+	defer ts.Close()
+
+	store := NewMemoryIdempotencyStore(time.Hour)
+	c, err := NewClient("accessToken", ts.URL, WithIdempotencyStore(store))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	for i := 0; i < 2; i++ {
+		collector, err := c.CreateHostedCollector(Collector{Name: "test"})
+		if err != nil || collector.ID != 1 {
+			t.Errorf("CreateHostedCollector() returned %v, %v", collector, err)
+			return
+		}
+	}
+	if n := atomic.LoadInt32(&creates); n != 1 {
+		t.Errorf("Expected a repeated create to reuse the first result, got %d creates", n)
+	}
+	if _, err := c.CreateHostedCollector(Collector{Name: "other"}); err != nil || atomic.LoadInt32(&creates) != 2 {
+		t.Errorf("Expected a different create to be sent, got %d creates and %v", atomic.LoadInt32(&creates), err)
+	}
+
+	other, _ := NewClient("otherAccessToken", ts.URL, WithIdempotencyStore(store))
+	if _, err := other.CreateHostedCollector(Collector{Name: "test"}); err != nil || atomic.LoadInt32(&creates) != 3 {
+		t.Errorf("Expected the same create by another account to be sent, got %d creates and %v", atomic.LoadInt32(&creates), err)
+	}
+}
+
+func TestIdempotentCreateOutcomeUnknown(t *testing.T) {
+	var creates int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&creates, 1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"collector": {"id": 1, "name": "test"}}`))
+	}))
+	defer ts.Close()
+
+	store := NewMemoryIdempotencyStore(time.Hour)
+	c, err := NewClient("accessToken", ts.URL, WithIdempotencyStore(store))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if _, err := c.CreateHostedCollector(Collector{Name: "test"}, WithTimeout(20*time.Millisecond)); err == nil {
+		t.Errorf("Expected CreateHostedCollector() to time out")
+		return
+	}
+	_, err = c.CreateHostedCollector(Collector{Name: "test"})
+	unknown, ok := err.(*CreateOutcomeUnknownError)
+	if !ok {
+		t.Errorf("Expected a *CreateOutcomeUnknownError, got %v", err)
+		return
+	}
+
+	store.Delete(unknown.Key)
+	if _, err := c.CreateHostedCollector(Collector{Name: "test"}); err != nil {
+		t.Errorf("Expected the create to be allowed once forgotten, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IngestBudget caps the daily volume ingested for the collectors and sources in its scope.
+type IngestBudget struct {
+	ID             string `json:"id,omitempty"`
+	Name           string `json:"name"`
+	Scope          string `json:"scope"`
+	CapacityBytes  int64  `json:"capacityBytes"`
+	Timezone       string `json:"timezone"`
+	ResetTime      string `json:"resetTime"`
+	Description    string `json:"description,omitempty"`
+	Action         string `json:"action"`
+	AuditThreshold int    `json:"auditThreshold,omitempty"`
+	UsageBytes     int64  `json:"usageBytes,omitempty"`
+	UsageStatus    string `json:"usageStatus,omitempty"`
+	BudgetVersion  int    `json:"budgetVersion,omitempty"`
+}
+
+// Actions an ingest budget takes once its capacity is reached.
+const (
+	IngestBudgetActionStopCollecting = "stopCollecting"
+	IngestBudgetActionKeepCollecting = "keepCollecting"
+)
+
+// UsagePercent returns the consumed share of the budget's capacity as a percentage.
+func (b *IngestBudget) UsagePercent() float64 {
+	if b.CapacityBytes <= 0 {
+		return 0
+	}
+	return float64(b.UsageBytes) / float64(b.CapacityBytes) * 100
+}
+
+// ErrIngestBudgetNotFound is returned when an ingest budget doesn't exist on a Read or Update.
+var ErrIngestBudgetNotFound = errors.New("Ingest budget not found")
+
+// GetIngestBudget gets the ingest budget with the specified ID, including its current usage.
+func (c *Client) GetIngestBudget(id string, opts ...CallOption) (*IngestBudget, error) {
+	var b = new(IngestBudget)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("../v2/ingestBudgets/%s", id),
+		out:      b,
+		notFound: ErrIngestBudgetNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// UpdateIngestBudget updates an existing ingest budget.
+func (c *Client) UpdateIngestBudget(budget IngestBudget, opts ...CallOption) (*IngestBudget, error) {
+	var b = new(IngestBudget)
+	err := c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("../v2/ingestBudgets/%s", budget.ID),
+		in:         budget,
+		out:        b,
+		notFound:   ErrIngestBudgetNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the ingest budget `%s`", budget.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// IngestBudgets returns a ResourceClient for ingest budgets.
+func (c *Client) IngestBudgets(opts ...CallOption) ResourceClient[IngestBudget] {
+	return &resourceClient[IngestBudget]{
+		get: func(id string) (*IngestBudget, error) {
+			return c.GetIngestBudget(id, opts...)
+		},
+		update: func(budget IngestBudget) (*IngestBudget, error) {
+			return c.UpdateIngestBudget(budget, opts...)
+		},
+	}
+}
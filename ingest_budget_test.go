@@ -0,0 +1,40 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetIngestBudgetDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.URL.EscapedPath() != "/v2/ingestBudgets/abc" {
+			t.Errorf("Expected request to ‘/v2/ingestBudgets/abc’, got ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.GetIngestBudget("abc")
+	if err != ErrIngestBudgetNotFound {
+		t.Errorf("GetIngestBudget() returned the wrong error: %s", err)
+		return
+	}
+}
+
+func TestIngestBudgetUsagePercent(t *testing.T) {
+	b := IngestBudget{CapacityBytes: 200, UsageBytes: 50}
+	if b.UsagePercent() != 25 {
+		t.Errorf("UsagePercent() expected 25, got %v", b.UsagePercent())
+	}
+	b = IngestBudget{UsageBytes: 50}
+	if b.UsagePercent() != 0 {
+		t.Errorf("UsagePercent() expected 0 without capacity, got %v", b.UsagePercent())
+	}
+}
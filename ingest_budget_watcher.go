@@ -0,0 +1,98 @@
+package sumologic
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// DefaultIngestBudgetThresholds are the usage percentages watched when none are configured.
+var DefaultIngestBudgetThresholds = []float64{80, 100}
+
+// IngestBudgetWatcher polls the usage of an ingest budget and calls OnThreshold
+// each time usage crosses one of the configured percentages.
+// A threshold fires once and is re-armed when usage drops back below it,
+// which normally happens when the budget resets.
+type IngestBudgetWatcher struct {
+	Client   *Client
+	BudgetID string
+	Interval time.Duration
+
+	// Thresholds are usage percentages, e.g. 80 and 100.
+	Thresholds []float64
+
+	// OnThreshold is called with the polled budget and the threshold crossed.
+	OnThreshold func(budget *IngestBudget, threshold float64)
+
+	// StopCollecting switches the budget's action to stop collecting once
+	// usage reaches 100%, for budgets configured to keep collecting.
+	StopCollecting bool
+
+	// Options are applied to every API call the watcher makes.
+	Options []CallOption
+
+	fired map[float64]bool
+}
+
+// Check polls the budget once and fires the callbacks for newly crossed thresholds.
+func (w *IngestBudgetWatcher) Check() (*IngestBudget, error) {
+	budget, err := w.Client.GetIngestBudget(w.BudgetID, w.Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds := w.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = DefaultIngestBudgetThresholds
+	}
+	thresholds = append([]float64(nil), thresholds...)
+	sort.Float64s(thresholds)
+
+	if w.fired == nil {
+		w.fired = make(map[float64]bool)
+	}
+	usage := budget.UsagePercent()
+	for _, threshold := range thresholds {
+		if usage < threshold {
+			w.fired[threshold] = false
+			continue
+		}
+		if w.fired[threshold] {
+			continue
+		}
+		w.fired[threshold] = true
+		if w.OnThreshold != nil {
+			w.OnThreshold(budget, threshold)
+		}
+	}
+
+	if w.StopCollecting && usage >= 100 && budget.Action != IngestBudgetActionStopCollecting {
+		budget.Action = IngestBudgetActionStopCollecting
+		budget, err = w.Client.UpdateIngestBudget(*budget, w.Options...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return budget, nil
+}
+
+// Watch polls the budget every Interval until the context is done or a poll fails.
+func (w *IngestBudgetWatcher) Watch(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Check(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIngestBudgetWatcherThresholds(t *testing.T) {
+	usage := int64(50)
+	var updated *IngestBudget
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v2/ingestBudgets/budget" {
+			t.Errorf("Expected request to ‘/v2/ingestBudgets/budget’, got ‘%s’", r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			body, _ := json.Marshal(IngestBudget{
+				ID:            "budget",
+				CapacityBytes: 100,
+				UsageBytes:    usage,
+				Action:        IngestBudgetActionKeepCollecting,
+			})
+			w.Write(body)
+		case "PUT":
+			w.WriteHeader(http.StatusOK)
+			body, _ := ioutil.ReadAll(r.Body)
+			updated = new(IngestBudget)
+			json.Unmarshal(body, updated)
+			w.Write(body)
+		default:
+			t.Errorf("Unexpected ‘%s’ request", r.Method)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var crossed []float64
+	w := &IngestBudgetWatcher{
+		Client:   c,
+		BudgetID: "budget",
+		OnThreshold: func(budget *IngestBudget, threshold float64) {
+			crossed = append(crossed, threshold)
+		},
+		StopCollecting: true,
+	}
+
+	for _, u := range []int64{50, 85, 90, 100, 10, 85} {
+		usage = u
+		if _, err := w.Check(); err != nil {
+			t.Errorf("Check() returned an error: %s", err)
+			return
+		}
+	}
+
+	expected := []float64{80, 100, 80}
+	if len(crossed) != len(expected) {
+		t.Errorf("Expected thresholds %v, got %v", expected, crossed)
+		return
+	}
+	for i := range expected {
+		if crossed[i] != expected[i] {
+			t.Errorf("Expected thresholds %v, got %v", expected, crossed)
+			return
+		}
+	}
+	if updated == nil || updated.Action != IngestBudgetActionStopCollecting {
+		t.Errorf("Expected the budget to be switched to stop collecting, got %v", updated)
+	}
+}
@@ -0,0 +1,142 @@
+package sumologic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Outcomes of a journaled call.
+const (
+	JournalOutcomeSucceeded = "succeeded"
+	JournalOutcomeFailed    = "failed"
+	JournalOutcomeError     = "error"
+)
+
+// JournalEntry records one mutating API call. PayloadHash is the hex SHA-256 of the request
+// body, empty when there was none. Outcome is JournalOutcomeSucceeded for a 2xx response,
+// JournalOutcomeFailed for any other response and JournalOutcomeError when no response was received,
+// in which case Error says why.
+type JournalEntry struct {
+	Time        time.Time `json:"time"`
+	Actor       string    `json:"actor,omitempty"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	PayloadHash string    `json:"payloadHash,omitempty"`
+	StatusCode  int       `json:"statusCode,omitempty"`
+	Outcome     string    `json:"outcome"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// JournalWriter appends entries to a journal. Entries are only ever appended, never
+// changed or removed.
+type JournalWriter interface {
+	WriteEntry(entry JournalEntry) error
+}
+
+// JournalWriteError is returned when a mutating call was made but couldn't be recorded in
+// the journal. Entry holds what would have been recorded, including the call's outcome.
+type JournalWriteError struct {
+	Entry JournalEntry
+	Err   error
+}
+
+func (e *JournalWriteError) Error() string {
+	return fmt.Sprintf("%s %s %s but couldn't be journaled: %s", e.Entry.Method, e.Entry.Path, e.Entry.Outcome, e.Err)
+}
+
+func (e *JournalWriteError) Unwrap() error {
+	return e.Err
+}
+
+// WithJournal makes the client record every mutating call (POST, PUT, PATCH and DELETE)
+// in the journal, labelled with actor, apart from queries sent as a POST, which only read.
+// WithJournalActor overrides the label for a call.
+func WithJournal(journal JournalWriter, actor string) ClientOption {
+	return func(c *Client) {
+		c.Journal = journal
+		c.JournalActor = actor
+	}
+}
+
+// WithJournalActor labels the call's journal entry with actor instead of Client.JournalActor.
+func WithJournalActor(actor string) CallOption {
+	return func(o *callOptions) {
+		o.actor = actor
+	}
+}
+
+// jsonLinesJournal writes each entry as a line of JSON.
+type jsonLinesJournal struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLinesJournal returns a JournalWriter appending entries to w as JSON lines.
+// It's safe for concurrent use.
+func NewJSONLinesJournal(w io.Writer) JournalWriter {
+	return &jsonLinesJournal{enc: json.NewEncoder(w)}
+}
+
+func (j *jsonLinesJournal) WriteEntry(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.enc.Encode(entry)
+}
+
+// isMutating reports whether requests with the method change the account.
+func isMutating(method string) bool {
+	switch method {
+	case "POST", "PUT", "PATCH", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+// journal records the outcome of a mutating call in the client's journal.
+func (c *Client) journal(req *http.Request, resp *http.Response, callErr error) error {
+	entry := JournalEntry{
+		Time:   time.Now().UTC(),
+		Actor:  c.JournalActor,
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+	if o := requestCallOptions(req); o != nil && o.actor != "" {
+		entry.Actor = o.actor
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(b)
+		entry.PayloadHash = hex.EncodeToString(sum[:])
+	}
+	switch {
+	case callErr != nil:
+		entry.Outcome = JournalOutcomeError
+		entry.Error = callErr.Error()
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		entry.StatusCode = resp.StatusCode
+		entry.Outcome = JournalOutcomeSucceeded
+	default:
+		entry.StatusCode = resp.StatusCode
+		entry.Outcome = JournalOutcomeFailed
+	}
+
+	if err := c.Journal.WriteEntry(entry); err != nil {
+		return &JournalWriteError{Entry: entry, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package sumologic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type failingJournal struct{}
+
+func (failingJournal) WriteEntry(JournalEntry) error {
+	return errors.New("disk full")
+}
+
+func TestJournalRecordsMutatingCalls(t *testing.T) {
+	var payload []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			payload, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"collector": {"id": 1, "name": "test"}}`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(`{"collector": {"id": 1, "name": "test"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	c, _ := NewClient("accessToken", ts.URL, WithJournal(NewJSONLinesJournal(&buf), "deployer"))
+	if _, err := c.CreateHostedCollector(Collector{Name: "test"}); err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+		return
+	}
+	if _, _, err := c.GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	c.DeleteHostedCollector(1, WithJournalActor("cleanup"))
+
+	var entries []JournalEntry
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var e JournalEntry
+		if err := dec.Decode(&e); err != nil {
+			t.Errorf("Unable to decode journal entry: %s", err)
+			return
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 journal entries, got %d", len(entries))
+		return
+	}
+
+	sum := sha256.Sum256(payload)
+	create := entries[0]
+	if create.Method != "POST" || create.Path != "/collectors" || create.Actor != "deployer" ||
+		create.Outcome != JournalOutcomeSucceeded || create.StatusCode != http.StatusCreated ||
+		create.PayloadHash != hex.EncodeToString(sum[:]) || create.Time.IsZero() {
+		t.Errorf("Unexpected create entry: %+v", create)
+	}
+	del := entries[1]
+	if del.Method != "DELETE" || del.Actor != "cleanup" || del.Outcome != JournalOutcomeFailed || del.PayloadHash != "" {
+		t.Errorf("Unexpected delete entry: %+v", del)
+	}
+}
+
+func TestJournalWriteError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL, WithJournal(failingJournal{}, ""))
+	err := c.DeleteHostedCollector(1)
+	var jerr *JournalWriteError
+	if !errors.As(err, &jerr) || jerr.Entry.Outcome != JournalOutcomeSucceeded {
+		t.Errorf("Expected a *JournalWriteError for a successful call, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// KafkaRecord is a message to publish to a Kafka topic.
+type KafkaRecord struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// KafkaProducer publishes records to Kafka. Produce must only return nil once every
+// record has been acknowledged by the brokers.
+type KafkaProducer interface {
+	Produce(ctx context.Context, records []KafkaRecord) error
+}
+
+// KafkaSink publishes search result messages to a Kafka topic as JSON objects of their
+// fields. Delivery is at least once: a batch that fails is published again in full, so
+// consumers may see some messages twice.
+type KafkaSink struct {
+	Producer KafkaProducer
+	Topic    string
+	// KeyField is the field used as the record key, e.g. _sourcehost to keep each host's
+	// messages in order. Records have no key when it's empty.
+	KeyField string
+	// BatchSize is the number of records produced at once, 100 by default.
+	BatchSize int
+	// Retry is how failed batches are retried. By default they're tried 3 times, a second apart.
+	Retry *RetryPolicy
+}
+
+// Publish produces every message of the iterator and returns the number acknowledged.
+// When a batch still fails after its retries, the messages before it have been
+// acknowledged and the count can be used to resume.
+func (s *KafkaSink) Publish(ctx context.Context, it MessageIterator) (int, error) {
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	retry := RetryPolicy{MaxAttempts: 3, Backoff: time.Second}
+	if s.Retry != nil {
+		retry = *s.Retry
+	}
+
+	published := 0
+	batch := make([]KafkaRecord, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		var err error
+		for attempt := 1; ; attempt++ {
+			if err = s.Producer.Produce(ctx, batch); err == nil {
+				break
+			}
+			if attempt >= retry.MaxAttempts {
+				return err
+			}
+			if err := sleepContext(ctx, retry.Backoff); err != nil {
+				return err
+			}
+		}
+		published += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for it.Next() {
+		m := it.Message()
+		value, err := json.Marshal(m.Map)
+		if err != nil {
+			return published, err
+		}
+		record := KafkaRecord{Topic: s.Topic, Value: value}
+		if s.KeyField != "" {
+			if key := messageField(m, s.KeyField); key != "" {
+				record.Key = []byte(key)
+			}
+		}
+		batch = append(batch, record)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return published, err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return published, err
+	}
+	return published, flush()
+}
@@ -0,0 +1,58 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type flakyKafkaProducer struct {
+	calls    int
+	failures map[int]bool
+	records  []KafkaRecord
+}
+
+func (p *flakyKafkaProducer) Produce(ctx context.Context, records []KafkaRecord) error {
+	p.calls++
+	if p.failures[p.calls] {
+		return errors.New("broker unavailable")
+	}
+	p.records = append(p.records, records...)
+	return nil
+}
+
+func TestKafkaSinkPublish(t *testing.T) {
+	p := &flakyKafkaProducer{failures: map[int]bool{2: true}}
+	s := &KafkaSink{
+		Producer:  p,
+		Topic:     "logs",
+		KeyField:  "_sourceCategory",
+		BatchSize: 2,
+		Retry:     &RetryPolicy{MaxAttempts: 2},
+	}
+	n, err := s.Publish(context.Background(), NewSliceMessageIterator(sinkMessages()))
+	if err != nil {
+		t.Errorf("Publish() returned error: %v", err)
+		return
+	}
+	if n != 3 || len(p.records) != 3 || p.calls != 3 {
+		t.Errorf("Expected 3 records in 3 produce calls, got %d records in %d calls", len(p.records), p.calls)
+		return
+	}
+	r := p.records[2]
+	if r.Topic != "logs" || string(r.Key) != "prod/db" {
+		t.Errorf("Expected record keyed prod/db on topic logs, got %+v", r)
+	}
+	if string(p.records[0].Value) != `{"_messagetime":"1488326400000","_sourceCategory":"prod/app","bytes":"10","latency":"1"}` {
+		t.Errorf("Unexpected record value %s", p.records[0].Value)
+	}
+}
+
+func TestKafkaSinkPublishFails(t *testing.T) {
+	p := &flakyKafkaProducer{failures: map[int]bool{2: true, 3: true}}
+	s := &KafkaSink{Producer: p, Topic: "logs", BatchSize: 2, Retry: &RetryPolicy{MaxAttempts: 2}}
+	n, err := s.Publish(context.Background(), NewSliceMessageIterator(sinkMessages()))
+	if err == nil || n != 2 {
+		t.Errorf("Expected an error after the first batch of 2, got %d and %v", n, err)
+	}
+}
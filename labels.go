@@ -0,0 +1,97 @@
+package sumologic
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Labels are key/value pairs recording e.g. who owns a resource. Most resources have no
+// tags, so labels are kept on the last line of their description, as in
+//
+//	Collects the payments service's logs.
+//	labels: env=prod&owner=alice&team=payments
+type Labels map[string]string
+
+// Conventional label keys.
+const (
+	LabelOwner = "owner"
+	LabelEnv   = "env"
+	LabelTeam  = "team"
+)
+
+// labelsPrefix starts the description line holding the labels.
+const labelsPrefix = "labels: "
+
+// ParseLabels splits a description into its text and its labels. A description without
+// a labels line has no labels.
+func ParseLabels(description string) (string, Labels) {
+	labels := make(Labels)
+	text, line := "", description
+	if i := strings.LastIndex(description, "\n"); i >= 0 {
+		text, line = description[:i], description[i+1:]
+	}
+	if !strings.HasPrefix(line, labelsPrefix) {
+		return description, labels
+	}
+	values, err := url.ParseQuery(strings.TrimPrefix(line, labelsPrefix))
+	if err != nil {
+		return description, labels
+	}
+	for k := range values {
+		labels[k] = values.Get(k)
+	}
+	return text, labels
+}
+
+// WithLabels returns the description with its labels replaced by labels. The labels
+// line is removed when labels is empty.
+func WithLabels(description string, labels Labels) string {
+	text, _ := ParseLabels(description)
+	if len(labels) == 0 {
+		return text
+	}
+	values := url.Values{}
+	for k, v := range labels {
+		values.Set(k, v)
+	}
+	line := labelsPrefix + values.Encode()
+	if text == "" {
+		return line
+	}
+	return text + "\n" + line
+}
+
+// Matches reports whether the labels have every key and value of the selector. An empty
+// selector value matches any value, as long as the key is present.
+func (l Labels) Matches(selector Labels) bool {
+	for k, v := range selector {
+		value, ok := l[k]
+		if !ok || (v != "" && value != v) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterByLabels returns the resources whose labels match the selector. description
+// returns the description of a resource, e.g. func(c Collector) string { return c.Description }.
+func FilterByLabels[T any](resources []T, description func(T) string, selector Labels) []T {
+	var matched []T
+	for _, r := range resources {
+		if _, labels := ParseLabels(description(r)); labels.Matches(selector) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// Labels returns the labels of the collector.
+func (c Collector) Labels() Labels {
+	_, labels := ParseLabels(c.Description)
+	return labels
+}
+
+// SetLabels replaces the labels of the collector.
+func (c *Collector) SetLabels(labels Labels) {
+	c.Description = WithLabels(c.Description, labels)
+}
@@ -0,0 +1,59 @@
+package sumologic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLabelsRoundTrip(t *testing.T) {
+	description := WithLabels("Collects the payments service's logs.\nSee the runbook.", Labels{LabelOwner: "alice", LabelEnv: "prod", "cost center": "a&b"})
+	expected := "Collects the payments service's logs.\nSee the runbook.\nlabels: cost+center=a%26b&env=prod&owner=alice"
+	if description != expected {
+		t.Errorf("Expected description %q, got %q", expected, description)
+	}
+
+	text, labels := ParseLabels(description)
+	if text != "Collects the payments service's logs.\nSee the runbook." {
+		t.Errorf("Unexpected text %q", text)
+	}
+	if !reflect.DeepEqual(labels, Labels{"owner": "alice", "env": "prod", "cost center": "a&b"}) {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+
+	if d := WithLabels(description, Labels{LabelTeam: "core"}); d != "Collects the payments service's logs.\nSee the runbook.\nlabels: team=core" {
+		t.Errorf("Expected the labels to be replaced, got %q", d)
+	}
+	if d := WithLabels(description, nil); d != text {
+		t.Errorf("Expected the labels line to be removed, got %q", d)
+	}
+	if d := WithLabels("", Labels{LabelTeam: "core"}); d != "labels: team=core" {
+		t.Errorf("Expected only the labels line, got %q", d)
+	}
+}
+
+func TestParseLabelsWithoutLabels(t *testing.T) {
+	text, labels := ParseLabels("Just a description")
+	if text != "Just a description" || len(labels) != 0 {
+		t.Errorf("Expected the description unchanged and no labels, got %q and %v", text, labels)
+	}
+}
+
+func TestFilterByLabels(t *testing.T) {
+	collectors := []Collector{{ID: 1}, {ID: 2}, {ID: 3}}
+	collectors[0].SetLabels(Labels{LabelOwner: "alice", LabelEnv: "prod"})
+	collectors[1].SetLabels(Labels{LabelOwner: "bob", LabelEnv: "prod"})
+
+	description := func(c Collector) string { return c.Description }
+	if matched := FilterByLabels(collectors, description, Labels{LabelEnv: "prod"}); len(matched) != 2 {
+		t.Errorf("Expected 2 prod collectors, got %v", matched)
+	}
+	if matched := FilterByLabels(collectors, description, Labels{LabelOwner: "bob"}); len(matched) != 1 || matched[0].ID != 2 {
+		t.Errorf("Expected bob's collector, got %v", matched)
+	}
+	if matched := FilterByLabels(collectors, description, Labels{LabelOwner: ""}); len(matched) != 2 {
+		t.Errorf("Expected the 2 owned collectors, got %v", matched)
+	}
+	if collectors[0].Labels()[LabelOwner] != "alice" {
+		t.Errorf("Expected alice to own collector 1, got %v", collectors[0].Labels())
+	}
+}
@@ -0,0 +1,97 @@
+package sumologic
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// LatencyBudget is how long calls to a group of endpoints should take. Groups are keyed
+// like Client.EndpointRetryPolicies, e.g. "search" or "collectors".
+type LatencyBudget struct {
+	// Budget is the longest a request to the group should take.
+	Budget time.Duration
+	// Consecutive is how many requests in a row must exceed the budget before the group
+	// counts as slow, DefaultLatencyBudgetConsecutive when zero.
+	Consecutive int
+	// ShedFor, when positive, makes low priority calls to a slow group fail with
+	// ErrCallShed for that long, so they don't compete with interactive calls.
+	ShedFor time.Duration
+}
+
+// DefaultLatencyBudgetConsecutive is how many slow requests in a row make a group slow
+// when its LatencyBudget doesn't say.
+const DefaultLatencyBudgetConsecutive = 3
+
+// ErrCallShed is returned for a low priority call that wasn't made because its endpoint
+// group is over its latency budget.
+var ErrCallShed = errors.New("Call shed: the endpoint is over its latency budget")
+
+// WithLowPriority marks the call as one that can be shed while its endpoint group is
+// over its latency budget. Search job status polls are low priority and skip a poll
+// when shed.
+func WithLowPriority() CallOption {
+	return func(o *callOptions) {
+		o.lowPriority = true
+	}
+}
+
+// latencyState tracks a group's requests against its budget.
+type latencyState struct {
+	exceeded  int
+	shedUntil time.Time
+}
+
+// observeLatency compares a request's latency with the budget of its endpoint group.
+// Once the group is slow, every request over budget calls OnLatencyBudgetExceeded and
+// starts shedding low priority calls when the budget says to.
+func (c *Client) observeLatency(req *http.Request, latency time.Duration) {
+	group := endpointGroup(req.URL.Path)
+	budget, ok := c.LatencyBudgets[group]
+	if !ok || budget.Budget <= 0 {
+		return
+	}
+	consecutive := budget.Consecutive
+	if consecutive <= 0 {
+		consecutive = DefaultLatencyBudgetConsecutive
+	}
+
+	c.mu.Lock()
+	if c.latency == nil {
+		c.latency = make(map[string]*latencyState)
+	}
+	state, ok := c.latency[group]
+	if !ok {
+		state = new(latencyState)
+		c.latency[group] = state
+	}
+	if latency <= budget.Budget {
+		state.exceeded = 0
+		c.mu.Unlock()
+		return
+	}
+	state.exceeded++
+	exceeded := state.exceeded
+	if exceeded >= consecutive && budget.ShedFor > 0 {
+		state.shedUntil = time.Now().Add(budget.ShedFor)
+	}
+	c.mu.Unlock()
+
+	if exceeded >= consecutive && c.OnLatencyBudgetExceeded != nil {
+		c.OnLatencyBudgetExceeded(group, latency, exceeded)
+	}
+}
+
+// checkShed returns ErrCallShed for a low priority request to a group that's shedding.
+func (c *Client) checkShed(req *http.Request) error {
+	o := requestCallOptions(req)
+	if o == nil || !o.lowPriority {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if state, ok := c.latency[endpointGroup(req.URL.Path)]; ok && time.Now().Before(state.shedUntil) {
+		return ErrCallShed
+	}
+	return nil
+}
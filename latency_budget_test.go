@@ -0,0 +1,51 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLatencyBudget(t *testing.T) {
+	slow := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow {
+			time.Sleep(20 * time.Millisecond)
+		}
+		w.Write([]byte(`{"state": "GATHERING RESULTS"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	c.LatencyBudgets = map[string]LatencyBudget{
+		"search": {Budget: 10 * time.Millisecond, Consecutive: 2, ShedFor: time.Minute},
+	}
+	var warnings []int
+	c.OnLatencyBudgetExceeded = func(group string, latency time.Duration, consecutive int) {
+		if group != "search" || latency < 10*time.Millisecond {
+			t.Errorf("Unexpected warning for %s after %v", group, latency)
+		}
+		warnings = append(warnings, consecutive)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetSearchJobStatus("job", nil, WithLowPriority()); err != nil {
+			t.Errorf("Expected calls to go through before the group is slow, got %v", err)
+		}
+	}
+	if len(warnings) != 1 || warnings[0] != 2 {
+		t.Errorf("Expected one warning after 2 slow requests, got %v", warnings)
+	}
+
+	if _, err := c.GetSearchJobStatus("job", nil, WithLowPriority()); err != ErrCallShed {
+		t.Errorf("Expected low priority calls to be shed, got %v", err)
+	}
+	slow = false
+	if _, err := c.GetSearchJobStatus("job", nil); err != nil {
+		t.Errorf("Expected other calls to go through while shedding, got %v", err)
+	}
+	if _, _, err := c.GetHostedCollector(1, WithLowPriority()); err == ErrCallShed {
+		t.Errorf("Expected groups without a budget not to be shed, got %v", err)
+	}
+}
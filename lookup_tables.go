@@ -0,0 +1,318 @@
+package sumologic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Actions taken when a lookup table reaches its size limit.
+const (
+	LookupTableStopIncomingMessages = "StopIncomingMessages"
+	LookupTableDeleteOldData        = "DeleteOldData"
+)
+
+// LookupTable is a table of enrichment data that queries join messages with through its
+// primary key columns. Tables with a TTL, in minutes, drop rows not updated for that long.
+type LookupTable struct {
+	ID              string             `json:"id,omitempty"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	Fields          []LookupTableField `json:"fields"`
+	PrimaryKeys     []string           `json:"primaryKeys"`
+	TTL             int                `json:"ttl,omitempty"`
+	SizeLimitAction string             `json:"sizeLimitAction,omitempty"`
+	ParentFolderID  string             `json:"parentFolderId,omitempty"`
+	Size            int64              `json:"size,omitempty"`
+	CreatedAt       string             `json:"createdAt,omitempty"`
+	CreatedBy       string             `json:"createdBy,omitempty"`
+	ModifiedAt      string             `json:"modifiedAt,omitempty"`
+	ModifiedBy      string             `json:"modifiedBy,omitempty"`
+}
+
+// LookupTableField is a column of a lookup table. FieldType is one of boolean, int, long,
+// double or string.
+type LookupTableField struct {
+	FieldName string `json:"fieldName"`
+	FieldType string `json:"fieldType"`
+}
+
+// UpdateLookupTableRequest holds the mutable settings of a lookup table.
+type UpdateLookupTableRequest struct {
+	Description     string `json:"description"`
+	TTL             int    `json:"ttl"`
+	SizeLimitAction string `json:"sizeLimitAction,omitempty"`
+}
+
+// LookupTableColumn is the value of one column of a lookup table row.
+type LookupTableColumn struct {
+	ColumnName  string `json:"columnName"`
+	ColumnValue string `json:"columnValue"`
+}
+
+// Statuses of a lookup table job.
+const (
+	LookupTableJobStatusPending    = "Pending"
+	LookupTableJobStatusInProgress = "InProgress"
+	LookupTableJobStatusSuccess    = "Success"
+	LookupTableJobStatusFailed     = "Failed"
+)
+
+// LookupTableJobStatus is the status of a lookup table upload or truncate job.
+type LookupTableJobStatus struct {
+	JobID          string                 `json:"jobId"`
+	Status         string                 `json:"status"`
+	StatusMessages []string               `json:"statusMessages,omitempty"`
+	Error          *ContentJobStatusError `json:"error,omitempty"`
+	TableID        string                 `json:"contentId,omitempty"`
+	StartTime      string                 `json:"startTime,omitempty"`
+	EndTime        string                 `json:"endTime,omitempty"`
+}
+
+// LookupTableJobError is returned when a lookup table job fails.
+type LookupTableJobError struct {
+	JobID   string
+	Code    string
+	Message string
+}
+
+func (e *LookupTableJobError) Error() string {
+	return fmt.Sprintf("lookup table job %s failed: %s (%s)", e.JobID, e.Message, e.Code)
+}
+
+// ErrLookupTableNotFound is returned when a lookup table or lookup table job doesn't exist.
+var ErrLookupTableNotFound = errors.New("Lookup table not found")
+
+// lookupTableJobPollInterval is the delay between status checks while waiting on a lookup
+// table job.
+var lookupTableJobPollInterval = time.Second
+
+// GetLookupTable gets the lookup table with the specified ID.
+func (c *Client) GetLookupTable(id string, opts ...CallOption) (*LookupTable, error) {
+	return c.sendLookupTable(call{method: "GET", path: fmt.Sprintf("lookupTables/%s", id)}, opts)
+}
+
+// CreateLookupTable creates a lookup table in the folder with its ParentFolderID.
+func (c *Client) CreateLookupTable(table LookupTable, opts ...CallOption) (*LookupTable, error) {
+	return c.sendLookupTable(call{
+		method:     "POST",
+		path:       "lookupTables",
+		in:         table,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if a lookup table with this name `%s` already exists and its primary keys are fields", table.Name),
+	}, opts)
+}
+
+// UpdateLookupTable updates the lookup table with the specified ID.
+func (c *Client) UpdateLookupTable(id string, ultr UpdateLookupTableRequest, opts ...CallOption) (*LookupTable, error) {
+	return c.sendLookupTable(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("lookupTables/%s", id),
+		in:         ultr,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for lookup table `%s`", id),
+	}, opts)
+}
+
+// DeleteLookupTable deletes the lookup table with the specified ID.
+func (c *Client) DeleteLookupTable(id string, opts ...CallOption) error {
+	return c.lookupTableAction("DELETE", fmt.Sprintf("lookupTables/%s", id), nil, opts)
+}
+
+// UpsertLookupTableRow inserts a row into the lookup table with the specified ID, or
+// replaces the row with the same primary key.
+func (c *Client) UpsertLookupTableRow(id string, row []LookupTableColumn, opts ...CallOption) error {
+	return c.lookupTableAction("PUT", fmt.Sprintf("lookupTables/%s/row", id), struct {
+		Row []LookupTableColumn `json:"row"`
+	}{row}, opts)
+}
+
+// DeleteLookupTableRow deletes the row with the primary key from the lookup table with
+// the specified ID.
+func (c *Client) DeleteLookupTableRow(id string, primaryKey []LookupTableColumn, opts ...CallOption) error {
+	return c.lookupTableAction("PUT", fmt.Sprintf("lookupTables/%s/deleteTableRow", id), struct {
+		PrimaryKey []LookupTableColumn `json:"primaryKey"`
+	}{primaryKey}, opts)
+}
+
+// StartLookupTableTruncate starts deleting every row of the lookup table with the
+// specified ID and returns the ID of the job.
+func (c *Client) StartLookupTableTruncate(id string, opts ...CallOption) (string, error) {
+	req, err := c.newRequest("POST", fmt.Sprintf("lookupTables/%s/truncate", id), nil, opts...)
+	if err != nil {
+		return "", err
+	}
+	return c.startLookupTableJob(req)
+}
+
+// TruncateLookupTable deletes every row of the lookup table with the specified ID,
+// waiting for the job to finish. A failed job returns a *LookupTableJobError.
+func (c *Client) TruncateLookupTable(id string, opts ...CallOption) error {
+	jobID, err := c.StartLookupTableTruncate(id, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = c.WaitForLookupTableJob(jobID, opts...)
+	return err
+}
+
+// StartLookupTableUpload starts uploading a CSV file, whose header row names the table's
+// fields, to the lookup table with the specified ID and returns the ID of the job. With
+// merge, the rows are upserted into the table; otherwise they replace its content.
+func (c *Client) StartLookupTableUpload(id string, csv io.Reader, merge bool, opts ...CallOption) (string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("file", "lookup.csv")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, csv); err != nil {
+		return "", err
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("merge", strconv.FormatBool(merge))
+	q.Set("fileEncoding", "UTF-8")
+	req, err := c.newRequest("POST", fmt.Sprintf("lookupTables/%s/upload?%s", id, q.Encode()), nil, opts...)
+	if err != nil {
+		return "", err
+	}
+	// The body is set after newRequest, which only encodes JSON, and can be read again
+	// when the upload is retried.
+	b := buf.Bytes()
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.ContentLength = int64(len(b))
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return c.startLookupTableJob(req)
+}
+
+// UploadLookupTable uploads a CSV file to the lookup table with the specified ID, waiting
+// for the job to finish. A failed job returns a *LookupTableJobError.
+func (c *Client) UploadLookupTable(id string, csv io.Reader, merge bool, opts ...CallOption) (*LookupTableJobStatus, error) {
+	jobID, err := c.StartLookupTableUpload(id, csv, merge, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitForLookupTableJob(jobID, opts...)
+}
+
+// GetLookupTableJobStatus gets the status of a lookup table upload or truncate job.
+func (c *Client) GetLookupTableJobStatus(jobID string, opts ...CallOption) (*LookupTableJobStatus, error) {
+	var status = new(LookupTableJobStatus)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("lookupTables/jobs/%s/status", jobID),
+		out:      status,
+		notFound: ErrLookupTableNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// WaitForLookupTableJob polls the status of a lookup table job until it's done and
+// returns its final status. A failed job returns a *LookupTableJobError.
+func (c *Client) WaitForLookupTableJob(jobID string, opts ...CallOption) (*LookupTableJobStatus, error) {
+	for {
+		s, err := c.GetLookupTableJobStatus(jobID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		switch s.Status {
+		case LookupTableJobStatusSuccess:
+			return s, nil
+		case LookupTableJobStatusFailed:
+			e := &LookupTableJobError{JobID: jobID}
+			if s.Error != nil {
+				e.Code, e.Message = s.Error.Code, s.Error.Message
+			} else if len(s.StatusMessages) > 0 {
+				e.Message = s.StatusMessages[len(s.StatusMessages)-1]
+			}
+			return nil, e
+		}
+		if err := sleepCallOptions(lookupTableJobPollInterval, opts); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// LookupTables returns a ResourceClient for lookup tables. Lookup tables can't be listed.
+func (c *Client) LookupTables(opts ...CallOption) ResourceClient[LookupTable] {
+	return &resourceClient[LookupTable]{
+		get: func(id string) (*LookupTable, error) {
+			return c.GetLookupTable(id, opts...)
+		},
+		create: func(t LookupTable) (*LookupTable, error) {
+			return c.CreateLookupTable(t, opts...)
+		},
+		update: func(t LookupTable) (*LookupTable, error) {
+			return c.UpdateLookupTable(t.ID, UpdateLookupTableRequest{
+				Description:     t.Description,
+				TTL:             t.TTL,
+				SizeLimitAction: t.SizeLimitAction,
+			}, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteLookupTable(id, opts...)
+		},
+	}
+}
+
+func (c *Client) startLookupTableJob(req *http.Request) (string, error) {
+	resp, body, err := c.send(req)
+	if err != nil {
+		return "", err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		var job = new(contentJob)
+		if err := c.decodeJSON(req, body, job); err != nil {
+			return "", err
+		}
+		return job.ID, nil
+	case http.StatusUnauthorized:
+		return "", ErrClientAuthenticationError
+	case http.StatusNotFound:
+		return "", ErrLookupTableNotFound
+	case http.StatusBadRequest:
+		return "", validationError(body, errors.New("Bad Request. Please check the file matches the lookup table's fields"))
+	default:
+		return "", newAPIError(resp, body)
+	}
+}
+
+// lookupTableAction sends a request that has no response body.
+func (c *Client) lookupTableAction(method, path string, in interface{}, opts []CallOption) error {
+	return c.do(call{
+		method:     method,
+		path:       path,
+		in:         in,
+		notFound:   ErrLookupTableNotFound,
+		badRequest: errors.New("Bad Request. Please check the row matches the lookup table's fields"),
+	}, opts)
+}
+
+// sendLookupTable makes a call returning a lookup table.
+func (c *Client) sendLookupTable(cl call, opts []CallOption) (*LookupTable, error) {
+	var t = new(LookupTable)
+	cl.out = t
+	cl.notFound = ErrLookupTableNotFound
+	if err := c.do(cl, opts); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,106 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUploadLookupTable(t *testing.T) {
+	lookupTableJobPollInterval = time.Millisecond
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/lookupTables/t1/upload":
+			if r.Method != "POST" {
+				t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+			}
+			if r.URL.Query().Get("merge") != "true" {
+				t.Errorf("Expected merge to be true, got ‘%s’", r.URL.RawQuery)
+			}
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("Expected a file in the form, got %s", err)
+				return
+			}
+			csv, _ := ioutil.ReadAll(file)
+			if string(csv) != "ip,owner\n10.0.0.1,web\n" {
+				t.Errorf("Expected the CSV file to be uploaded, got `%s`", csv)
+			}
+			w.Write([]byte(`{"id": "job1"}`))
+		case "/lookupTables/jobs/job1/status":
+			polls++
+			status := LookupTableJobStatusInProgress
+			if polls > 1 {
+				status = LookupTableJobStatusSuccess
+			}
+			json.NewEncoder(w).Encode(LookupTableJobStatus{JobID: "job1", Status: status})
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	status, err := c.UploadLookupTable("t1", strings.NewReader("ip,owner\n10.0.0.1,web\n"), true)
+	if err != nil {
+		t.Errorf("UploadLookupTable() returned an error: %s", err)
+		return
+	}
+	if status.Status != LookupTableJobStatusSuccess || polls != 2 {
+		t.Errorf("Expected to wait for the job to succeed, got %+v after %d polls", status, polls)
+	}
+}
+
+func TestTruncateLookupTableFails(t *testing.T) {
+	lookupTableJobPollInterval = time.Millisecond
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/lookupTables/t1/truncate":
+			w.Write([]byte(`{"id": "job2"}`))
+		case "/lookupTables/jobs/job2/status":
+			w.Write([]byte(`{"jobId": "job2", "status": "Failed", "statusMessages": ["Table is locked"]}`))
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	err := c.TruncateLookupTable("t1")
+	jobErr, ok := err.(*LookupTableJobError)
+	if !ok {
+		t.Errorf("Expected a *LookupTableJobError, got %v", err)
+		return
+	}
+	if jobErr.JobID != "job2" || jobErr.Message != "Table is locked" {
+		t.Errorf("Expected the job's status message, got %+v", jobErr)
+	}
+}
+
+func TestUpsertLookupTableRow(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected ‘PUT’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/lookupTables/t1/row" {
+			t.Errorf("Expected request to ‘/lookupTables/t1/row’, got ‘%s’", r.URL.EscapedPath())
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		expected := `{"row":[{"columnName":"ip","columnValue":"10.0.0.1"}]}`
+		if strings.TrimSpace(string(body)) != expected {
+			t.Errorf("Expected body `%s`, got `%s`", expected, body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.UpsertLookupTableRow("t1", []LookupTableColumn{{ColumnName: "ip", ColumnValue: "10.0.0.1"}}); err != nil {
+		t.Errorf("UpsertLookupTableRow() returned an error: %s", err)
+	}
+}
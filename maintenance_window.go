@@ -0,0 +1,101 @@
+package sumologic
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaintenanceWindowNamePrefix prefixes the name of the muting schedules created for
+// maintenance windows; the rest of the name is the window's key.
+const MaintenanceWindowNamePrefix = "maintenance-window-"
+
+// MaintenanceWindow mutes a set of monitors and monitor folders for a period of time,
+// e.g. during a deploy.
+type MaintenanceWindow struct {
+	// Key identifies the window so starting or ending it more than once is safe,
+	// e.g. a deploy or pipeline run ID.
+	Key         string
+	Description string
+	Start       time.Time
+	Duration    time.Duration
+
+	// MonitorIDs are the monitors and monitor folders to mute. All mutes every monitor.
+	MonitorIDs []string
+	All        bool
+}
+
+// StartMaintenanceWindow creates the muting schedule for a maintenance window.
+// If a schedule already exists for the window's key it's returned instead of creating another.
+func (c *Client) StartMaintenanceWindow(mw MaintenanceWindow, opts ...CallOption) (*MutingSchedule, error) {
+	if mw.Key == "" {
+		return nil, fmt.Errorf("maintenance window needs a key")
+	}
+	if len(mw.MonitorIDs) == 0 && !mw.All {
+		return nil, fmt.Errorf("maintenance window `%s` has no monitors in scope", mw.Key)
+	}
+
+	existing, err := c.findMaintenanceWindow(mw.Key, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	root, err := c.GetMutingSchedulesRootFolder(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	start := mw.Start.UTC()
+	minutes := int(mw.Duration / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	return c.CreateMutingSchedule(root.ID, MutingSchedule{
+		Name:        MaintenanceWindowNamePrefix + mw.Key,
+		Description: mw.Description,
+		Monitor: MutingScheduleScope{
+			IDs: mw.MonitorIDs,
+			All: mw.All,
+		},
+		Schedule: MutingScheduleDuration{
+			TimeZone:  "UTC",
+			StartDate: start.Format("2006-01-02"),
+			StartTime: start.Format("15:04"),
+			Duration:  minutes,
+		},
+	}, opts...)
+}
+
+// EndMaintenanceWindow deletes the muting schedule for the maintenance window with the
+// specified key. Ending a window that doesn't exist is not an error.
+func (c *Client) EndMaintenanceWindow(key string, opts ...CallOption) error {
+	existing, err := c.findMaintenanceWindow(key, opts...)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	err = c.DeleteMutingSchedule(existing.ID, opts...)
+	if err == ErrMutingScheduleNotFound {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) findMaintenanceWindow(key string, opts ...CallOption) (*MutingSchedule, error) {
+	name := MaintenanceWindowNamePrefix + key
+	results, err := c.SearchMutingSchedules(name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		if r.Item.Name == name && r.Item.Type == MutingScheduleType {
+			ms := r.Item
+			return &ms, nil
+		}
+	}
+	return nil, nil
+}
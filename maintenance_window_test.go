@@ -0,0 +1,104 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newMutingSchedulesServer(t *testing.T, schedules map[string]MutingSchedule) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.EscapedPath() == "/mutingSchedules/root":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "root", "type": "MutingSchedulesLibraryFolder"}`))
+		case r.Method == "GET" && r.URL.EscapedPath() == "/mutingSchedules/search":
+			w.WriteHeader(http.StatusOK)
+			results := []MutingScheduleSearchResult{}
+			for _, ms := range schedules {
+				if strings.Contains(ms.Name, r.URL.Query().Get("query")) {
+					results = append(results, MutingScheduleSearchResult{Item: ms})
+				}
+			}
+			body, _ := json.Marshal(results)
+			w.Write(body)
+		case r.Method == "POST" && r.URL.EscapedPath() == "/mutingSchedules":
+			if r.URL.Query().Get("parentId") != "root" {
+				t.Errorf("Expected parentId ‘root’, got ‘%s’", r.URL.Query().Get("parentId"))
+			}
+			w.WriteHeader(http.StatusOK)
+			body, _ := ioutil.ReadAll(r.Body)
+			var ms MutingSchedule
+			json.Unmarshal(body, &ms)
+			ms.ID = ms.Name
+			schedules[ms.ID] = ms
+			body, _ = json.Marshal(ms)
+			w.Write(body)
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.EscapedPath(), "/mutingSchedules/"):
+			id := strings.TrimPrefix(r.URL.EscapedPath(), "/mutingSchedules/")
+			if _, ok := schedules[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(schedules, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected ‘%s’ request to ‘%s’", r.Method, r.URL.EscapedPath())
+		}
+	}))
+}
+
+func TestMaintenanceWindowIsIdempotent(t *testing.T) {
+	schedules := make(map[string]MutingSchedule)
+	ts := newMutingSchedulesServer(t, schedules)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	mw := MaintenanceWindow{
+		Key:        "deploy-42",
+		Start:      time.Date(2017, 3, 1, 22, 30, 0, 0, time.UTC),
+		Duration:   90 * time.Minute,
+		MonitorIDs: []string{"folder1"},
+	}
+	first, err := c.StartMaintenanceWindow(mw)
+	if err != nil {
+		t.Errorf("StartMaintenanceWindow() returned an error: %s", err)
+		return
+	}
+	second, err := c.StartMaintenanceWindow(mw)
+	if err != nil {
+		t.Errorf("StartMaintenanceWindow() returned an error: %s", err)
+		return
+	}
+	if len(schedules) != 1 || first.ID != second.ID {
+		t.Errorf("Expected a single muting schedule, got %v", schedules)
+	}
+	if s := first.Schedule; s.StartDate != "2017-03-01" || s.StartTime != "22:30" || s.Duration != 90 {
+		t.Errorf("Unexpected schedule %v", s)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.EndMaintenanceWindow("deploy-42"); err != nil {
+			t.Errorf("EndMaintenanceWindow() returned an error: %s", err)
+		}
+	}
+	if len(schedules) != 0 {
+		t.Errorf("Expected the muting schedule to be removed, got %v", schedules)
+	}
+}
+
+func TestMaintenanceWindowNeedsScope(t *testing.T) {
+	c, _ := NewClient("accessToken", "http://localhost")
+	if _, err := c.StartMaintenanceWindow(MaintenanceWindow{Key: "deploy"}); err == nil {
+		t.Errorf("StartMaintenanceWindow() did not return an error without monitors in scope")
+	}
+}
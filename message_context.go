@@ -0,0 +1,122 @@
+package sumologic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultMessageContextWindow is how far before and after a message GetMessageContext
+// looks for the messages around it.
+const DefaultMessageContextWindow = 5 * time.Minute
+
+// MessageContextRequest asks for the messages logged around Message by the same source:
+// the same _sourceHost on the same _collector.
+type MessageContextRequest struct {
+	// Message is a search result message; it must have its _messagetime, _sourcehost and
+	// _collector fields.
+	Message *SearchJobResultMessage
+	// Before and After are the number of messages wanted on either side.
+	Before int
+	After  int
+	// Window is how far either side of the message is searched, DefaultMessageContextWindow
+	// when zero. Fewer messages are returned when the source logged less in the window.
+	Window time.Duration
+	// PollInterval is the delay between search status checks.
+	PollInterval time.Duration
+}
+
+// MessageContext is the messages around a message, oldest first.
+type MessageContext struct {
+	Before  []*SearchJobResultMessage
+	Message *SearchJobResultMessage
+	After   []*SearchJobResultMessage
+}
+
+// GetMessageContext searches the source of a message around its time and returns the
+// messages just before and after it, like the surrounding messages of a log viewer.
+// The message is found among the results by its _messageid, or by its time and _raw
+// text when it has none.
+func (c *Client) GetMessageContext(mcr MessageContextRequest, opts ...CallOption) (*MessageContext, error) {
+	m := mcr.Message
+	if m == nil {
+		return nil, fmt.Errorf("message context needs a message")
+	}
+	ms, err := strconv.ParseInt(messageField(m, "_messagetime"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("message has no valid _messagetime")
+	}
+	host, collector := messageField(m, "_sourcehost"), messageField(m, "_collector")
+	if host == "" || collector == "" {
+		return nil, fmt.Errorf("message needs its _sourcehost and _collector for its context")
+	}
+	window := mcr.Window
+	if window <= 0 {
+		window = DefaultMessageContextWindow
+	}
+	pollInterval := mcr.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = searchJobPollInterval
+	}
+
+	at := time.UnixMilli(ms)
+	ssr := StartSearchRequest{Query: "_sourceHost=" + quoteSearchPhrase(host) + " _collector=" + quoteSearchPhrase(collector)}
+	// Ranges are to the second and exclude their end, so a second is added to include it.
+	if err := ssr.SetTimeRange(TimeRange{From: at.Add(-window), To: at.Add(window + time.Second)}); err != nil {
+		return nil, err
+	}
+	messages, err := c.searchMessages(ssr, pollInterval, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return messageContext(messages, m, ms, mcr.Before, mcr.After), nil
+}
+
+// messageContext sorts the messages by time and returns the before and after messages
+// on either side of m.
+func messageContext(messages []*SearchJobResultMessage, m *SearchJobResultMessage, ms int64, before, after int) *MessageContext {
+	times := make(map[*SearchJobResultMessage]int64, len(messages))
+	for _, message := range messages {
+		times[message], _ = strconv.ParseInt(messageField(message, "_messagetime"), 10, 64)
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return times[messages[i]] < times[messages[j]]
+	})
+
+	id, raw := messageField(m, "_messageid"), messageField(m, "_raw")
+	pos, found := -1, false
+	for i, message := range messages {
+		if id != "" && messageField(message, "_messageid") == id ||
+			id == "" && times[message] == ms && messageField(message, "_raw") == raw {
+			pos, found = i, true
+			break
+		}
+		if times[message] <= ms {
+			pos = i
+		}
+	}
+
+	mc := &MessageContext{Message: m}
+	// Without the message among the results, pos is the last message not after it.
+	start := pos - before
+	if !found {
+		start = pos + 1 - before
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := pos + 1
+	if found {
+		mc.Message = messages[pos]
+		mc.Before = messages[start:pos]
+	} else {
+		mc.Before = messages[start:end]
+	}
+	stop := end + after
+	if stop > len(messages) {
+		stop = len(messages)
+	}
+	mc.After = messages[end:stop]
+	return mc
+}
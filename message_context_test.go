@@ -0,0 +1,89 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func contextMessage(id string, ms int64) *SearchJobResultMessage {
+	return &SearchJobResultMessage{Map: map[string]interface{}{
+		"_messageid":   id,
+		"_messagetime": strconv.FormatInt(ms, 10),
+		"_raw":         "message " + id,
+		"_sourcehost":  "web-1",
+		"_collector":   "prod",
+	}}
+}
+
+func TestGetMessageContext(t *testing.T) {
+	var ssr StartSearchRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.EscapedPath()
+		switch {
+		case r.Method == "POST" && path == "/search/jobs":
+			json.NewDecoder(r.Body).Decode(&ssr)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+		case strings.HasSuffix(path, "/messages"):
+			// Results come newest first.
+			var messages []*SearchJobResultMessage
+			for i := 6; i >= 1; i-- {
+				messages = append(messages, contextMessage(strconv.Itoa(i), int64(i)*1000))
+			}
+			json.NewEncoder(w).Encode(SearchJobResult{Messages: messages})
+		default:
+			json.NewEncoder(w).Encode(SearchJobStatusResponse{State: "DONE GATHERING RESULTS", MessageCount: 6})
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	mc, err := c.GetMessageContext(MessageContextRequest{
+		Message:      contextMessage("3", 3000),
+		Before:       1,
+		After:        2,
+		Window:       time.Minute,
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Errorf("GetMessageContext() returned an error: %s", err)
+		return
+	}
+	if ssr.Query != `_sourceHost="web-1" _collector="prod"` {
+		t.Errorf("Expected a search of the message's source, got %s", ssr.Query)
+	}
+	if ssr.From != "1969-12-31T23:59:03Z" || ssr.To != "1970-01-01T00:01:04Z" {
+		t.Errorf("Expected the window around the message, got %s to %s", ssr.From, ssr.To)
+	}
+	if ids := contextIDs(mc); ids != "2 [3] 4 5" {
+		t.Errorf("Expected messages 2 [3] 4 5, got %s", ids)
+	}
+}
+
+func TestMessageContextWithoutMessage(t *testing.T) {
+	var messages []*SearchJobResultMessage
+	for _, i := range []int64{1, 2, 4, 5} {
+		messages = append(messages, contextMessage(strconv.FormatInt(i, 10), i*1000))
+	}
+	mc := messageContext(messages, contextMessage("3", 3000), 3000, 2, 1)
+	if ids := contextIDs(mc); ids != "1 2 [3] 4" {
+		t.Errorf("Expected messages 1 2 [3] 4, got %s", ids)
+	}
+}
+
+func contextIDs(mc *MessageContext) string {
+	var ids []string
+	for _, m := range mc.Before {
+		ids = append(ids, messageField(m, "_messageid"))
+	}
+	ids = append(ids, "["+messageField(mc.Message, "_messageid")+"]")
+	for _, m := range mc.After {
+		ids = append(ids, messageField(m, "_messageid"))
+	}
+	return strings.Join(ids, " ")
+}
@@ -0,0 +1,584 @@
+package sumologic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MessageQueryResult is the table returned by QueryMessages.
+type MessageQueryResult struct {
+	Columns []string
+	// Rows hold field values as strings and aggregates as float64. An aggregate with no
+	// numeric values to work on is nil.
+	Rows [][]interface{}
+}
+
+// QueryMessages runs a small SQL-like query over messages that have already been
+// fetched, for exploring results without running another search. It supports
+//
+//	SELECT * | item, ... [WHERE condition] [GROUP BY field, ...]
+//
+// where an item is a field or one of count(*), count(field), sum(field), avg(field),
+// min(field) and max(field), optionally followed by AS name. Conditions compare fields
+// and 'string' or numeric literals with =, !=, <>, <, <=, > and >=, and combine with
+// AND, OR, NOT and parentheses. Values that both parse as numbers compare numerically.
+// Keywords are case-insensitive.
+func QueryMessages(messages []*SearchJobResultMessage, query string) (*MessageQueryResult, error) {
+	tokens, err := tokenizeMessageQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &messageQueryParser{tokens: tokens}
+	q, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return q.run(messages)
+}
+
+type messageQueryTokenKind int
+
+const (
+	tokenEnd messageQueryTokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+type messageQueryToken struct {
+	kind  messageQueryTokenKind
+	text  string
+	value float64
+}
+
+func tokenizeMessageQuery(query string) ([]messageQueryToken, error) {
+	var tokens []messageQueryToken
+	r := []rune(query)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '\'':
+			var b strings.Builder
+			i++
+			for ; ; i++ {
+				if i >= len(r) {
+					return nil, fmt.Errorf("unterminated string in query")
+				}
+				if r[i] == '\'' {
+					// A doubled quote is a literal quote, as in SQL.
+					if i+1 < len(r) && r[i+1] == '\'' {
+						b.WriteRune('\'')
+						i++
+						continue
+					}
+					break
+				}
+				b.WriteRune(r[i])
+			}
+			i++
+			tokens = append(tokens, messageQueryToken{kind: tokenString, text: b.String()})
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(r) && unicode.IsDigit(r[i+1])):
+			start := i
+			for i++; i < len(r) && (unicode.IsDigit(r[i]) || r[i] == '.'); i++ {
+			}
+			v, err := strconv.ParseFloat(string(r[start:i]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q in query", string(r[start:i]))
+			}
+			tokens = append(tokens, messageQueryToken{kind: tokenNumber, text: string(r[start:i]), value: v})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i++; i < len(r) && (unicode.IsLetter(r[i]) || unicode.IsDigit(r[i]) || r[i] == '_' || r[i] == '.'); i++ {
+			}
+			tokens = append(tokens, messageQueryToken{kind: tokenIdent, text: string(r[start:i])})
+		default:
+			if i+1 < len(r) {
+				switch two := string(r[i : i+2]); two {
+				case "!=", "<>", "<=", ">=":
+					tokens = append(tokens, messageQueryToken{kind: tokenSymbol, text: two})
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune(",()*=<>", c) {
+				return nil, fmt.Errorf("unexpected %q in query", c)
+			}
+			tokens = append(tokens, messageQueryToken{kind: tokenSymbol, text: string(c)})
+			i++
+		}
+	}
+	return append(tokens, messageQueryToken{kind: tokenEnd}), nil
+}
+
+type messageQueryParser struct {
+	tokens []messageQueryToken
+	pos    int
+}
+
+func (p *messageQueryParser) peek() messageQueryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *messageQueryParser) next() messageQueryToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it's the keyword.
+func (p *messageQueryParser) keyword(k string) bool {
+	t := p.peek()
+	if t.kind == tokenIdent && strings.EqualFold(t.text, k) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// symbol consumes the next token if it's the symbol.
+func (p *messageQueryParser) symbol(s string) bool {
+	t := p.peek()
+	if t.kind == tokenSymbol && t.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *messageQueryParser) field() (string, error) {
+	t := p.next()
+	if t.kind != tokenIdent || isMessageQueryKeyword(t.text) {
+		return "", unexpectedMessageQueryToken(t, "a field name")
+	}
+	return t.text, nil
+}
+
+func isMessageQueryKeyword(s string) bool {
+	switch strings.ToUpper(s) {
+	case "SELECT", "WHERE", "GROUP", "BY", "AND", "OR", "NOT", "AS":
+		return true
+	}
+	return false
+}
+
+func unexpectedMessageQueryToken(t messageQueryToken, expected string) error {
+	if t.kind == tokenEnd {
+		return fmt.Errorf("unexpected end of query, expected %s", expected)
+	}
+	return fmt.Errorf("unexpected %q in query, expected %s", t.text, expected)
+}
+
+type messageQuery struct {
+	all     bool
+	items   []selectItem
+	where   messageCondition
+	groupBy []string
+}
+
+type selectItem struct {
+	name string
+	// aggregate is empty for a plain field.
+	aggregate string
+	field     string
+}
+
+func (p *messageQueryParser) parse() (*messageQuery, error) {
+	q := new(messageQuery)
+	if !p.keyword("SELECT") {
+		return nil, unexpectedMessageQueryToken(p.peek(), "SELECT")
+	}
+	if p.symbol("*") {
+		q.all = true
+	} else {
+		for {
+			item, err := p.selectItem()
+			if err != nil {
+				return nil, err
+			}
+			q.items = append(q.items, item)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+	if p.keyword("WHERE") {
+		cond, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		q.where = cond
+	}
+	if p.keyword("GROUP") {
+		if !p.keyword("BY") {
+			return nil, unexpectedMessageQueryToken(p.peek(), "BY")
+		}
+		for {
+			f, err := p.field()
+			if err != nil {
+				return nil, err
+			}
+			q.groupBy = append(q.groupBy, f)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, unexpectedMessageQueryToken(t, "end of query")
+	}
+	return q, q.check()
+}
+
+func (p *messageQueryParser) selectItem() (selectItem, error) {
+	var item selectItem
+	f, err := p.field()
+	if err != nil {
+		return item, err
+	}
+	if p.symbol("(") {
+		item.aggregate = strings.ToLower(f)
+		switch item.aggregate {
+		case "count", "sum", "avg", "min", "max":
+		default:
+			return item, fmt.Errorf("unknown aggregate %s", f)
+		}
+		if item.aggregate == "count" && p.symbol("*") {
+			item.field = "*"
+		} else if item.field, err = p.field(); err != nil {
+			return item, err
+		}
+		if !p.symbol(")") {
+			return item, unexpectedMessageQueryToken(p.peek(), ")")
+		}
+		item.name = item.aggregate + "(" + item.field + ")"
+	} else {
+		item.field, item.name = f, f
+	}
+	if p.keyword("AS") {
+		if item.name, err = p.field(); err != nil {
+			return item, err
+		}
+	}
+	return item, nil
+}
+
+func (p *messageQueryParser) or() (messageCondition, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orCondition{left, right}
+	}
+	return left, nil
+}
+
+func (p *messageQueryParser) and() (messageCondition, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = andCondition{left, right}
+	}
+	return left, nil
+}
+
+func (p *messageQueryParser) not() (messageCondition, error) {
+	if p.keyword("NOT") {
+		cond, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{cond}, nil
+	}
+	if p.symbol("(") {
+		cond, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.symbol(")") {
+			return nil, unexpectedMessageQueryToken(p.peek(), ")")
+		}
+		return cond, nil
+	}
+	return p.comparison()
+}
+
+func (p *messageQueryParser) comparison() (messageCondition, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind != tokenSymbol {
+		// A lone operand is true when it's not empty, e.g. WHERE error.
+		return comparisonCondition{left: left, op: "!=", right: operand{literal: true}}, nil
+	}
+	switch t.text {
+	case "=", "!=", "<>", "<", "<=", ">", ">=":
+	default:
+		return nil, unexpectedMessageQueryToken(t, "a comparison")
+	}
+	p.next()
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return comparisonCondition{left: left, op: t.text, right: right}, nil
+}
+
+func (p *messageQueryParser) operand() (operand, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString, t.kind == tokenNumber:
+		return operand{literal: true, value: t.text}, nil
+	case t.kind == tokenIdent && !isMessageQueryKeyword(t.text):
+		return operand{value: t.text}, nil
+	}
+	return operand{}, unexpectedMessageQueryToken(t, "a field or value")
+}
+
+// check rejects selected fields whose value isn't the same across their group.
+func (q *messageQuery) check() error {
+	grouped := len(q.groupBy) > 0
+	for _, item := range q.items {
+		if item.aggregate != "" {
+			grouped = true
+		}
+	}
+	if !grouped {
+		return nil
+	}
+	if q.all {
+		return fmt.Errorf("SELECT * can't be used with GROUP BY")
+	}
+	for _, item := range q.items {
+		if item.aggregate != "" {
+			continue
+		}
+		found := false
+		for _, f := range q.groupBy {
+			found = found || f == item.field
+		}
+		if !found {
+			return fmt.Errorf("field %s must be aggregated or in GROUP BY", item.field)
+		}
+	}
+	return nil
+}
+
+func (q *messageQuery) run(messages []*SearchJobResultMessage) (*MessageQueryResult, error) {
+	var matched []*SearchJobResultMessage
+	for _, m := range messages {
+		if q.where == nil || q.where.match(m) {
+			matched = append(matched, m)
+		}
+	}
+
+	result := new(MessageQueryResult)
+	if q.all {
+		seen := make(map[string]bool)
+		for _, m := range matched {
+			for f := range m.Map {
+				if !seen[f] {
+					seen[f] = true
+					result.Columns = append(result.Columns, f)
+				}
+			}
+		}
+		sort.Strings(result.Columns)
+		for _, m := range matched {
+			row := make([]interface{}, len(result.Columns))
+			for i, f := range result.Columns {
+				row[i] = messageField(m, f)
+			}
+			result.Rows = append(result.Rows, row)
+		}
+		return result, nil
+	}
+
+	for _, item := range q.items {
+		result.Columns = append(result.Columns, item.name)
+	}
+	if len(q.groupBy) == 0 && !q.hasAggregate() {
+		for _, m := range matched {
+			row := make([]interface{}, len(q.items))
+			for i, item := range q.items {
+				row[i] = messageField(m, item.field)
+			}
+			result.Rows = append(result.Rows, row)
+		}
+		return result, nil
+	}
+
+	// Groups are returned in the order they're first seen.
+	var keys []string
+	groups := make(map[string][]*SearchJobResultMessage)
+	for _, m := range matched {
+		var values []string
+		for _, f := range q.groupBy {
+			values = append(values, messageField(m, f))
+		}
+		key := strings.Join(values, "\x00")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], m)
+	}
+	if len(q.groupBy) == 0 && len(keys) == 0 {
+		// Aggregates over no messages still return one row, as in SQL.
+		keys = append(keys, "")
+	}
+	for _, key := range keys {
+		group := groups[key]
+		row := make([]interface{}, len(q.items))
+		for i, item := range q.items {
+			if item.aggregate == "" {
+				row[i] = messageField(group[0], item.field)
+				continue
+			}
+			row[i] = aggregateMessages(group, item)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+func (q *messageQuery) hasAggregate() bool {
+	for _, item := range q.items {
+		if item.aggregate != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func aggregateMessages(group []*SearchJobResultMessage, item selectItem) interface{} {
+	if item.aggregate == "count" {
+		count := 0
+		for _, m := range group {
+			if item.field == "*" || messageField(m, item.field) != "" {
+				count++
+			}
+		}
+		return float64(count)
+	}
+
+	var values []float64
+	for _, m := range group {
+		if v, err := strconv.ParseFloat(messageField(m, item.field), 64); err == nil {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	agg := values[0]
+	for _, v := range values[1:] {
+		switch item.aggregate {
+		case "sum", "avg":
+			agg += v
+		case "min":
+			if v < agg {
+				agg = v
+			}
+		case "max":
+			if v > agg {
+				agg = v
+			}
+		}
+	}
+	if item.aggregate == "avg" {
+		agg /= float64(len(values))
+	}
+	return agg
+}
+
+type messageCondition interface {
+	match(m *SearchJobResultMessage) bool
+}
+
+type andCondition struct{ left, right messageCondition }
+
+func (c andCondition) match(m *SearchJobResultMessage) bool {
+	return c.left.match(m) && c.right.match(m)
+}
+
+type orCondition struct{ left, right messageCondition }
+
+func (c orCondition) match(m *SearchJobResultMessage) bool {
+	return c.left.match(m) || c.right.match(m)
+}
+
+type notCondition struct{ cond messageCondition }
+
+func (c notCondition) match(m *SearchJobResultMessage) bool {
+	return !c.cond.match(m)
+}
+
+// operand is a field of the message, or a literal value.
+type operand struct {
+	literal bool
+	value   string
+}
+
+func (o operand) eval(m *SearchJobResultMessage) string {
+	if o.literal {
+		return o.value
+	}
+	return messageField(m, o.value)
+}
+
+type comparisonCondition struct {
+	left  operand
+	op    string
+	right operand
+}
+
+func (c comparisonCondition) match(m *SearchJobResultMessage) bool {
+	l, r := c.left.eval(m), c.right.eval(m)
+	cmp := strings.Compare(l, r)
+	if lf, err := strconv.ParseFloat(l, 64); err == nil {
+		if rf, err := strconv.ParseFloat(r, 64); err == nil {
+			switch {
+			case lf < rf:
+				cmp = -1
+			case lf > rf:
+				cmp = 1
+			default:
+				cmp = 0
+			}
+		}
+	}
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=", "<>":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
@@ -0,0 +1,90 @@
+package sumologic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func messageQueryMessages() []*SearchJobResultMessage {
+	var messages []*SearchJobResultMessage
+	for _, m := range []map[string]interface{}{
+		{"host": "a", "status": "200", "latency": "10"},
+		{"host": "b", "status": "500", "latency": "90"},
+		{"host": "a", "status": "500", "latency": "30"},
+		{"host": "c", "status": "200", "latency": "5"},
+		{"host": "a", "status": "404", "latency": "n/a"},
+	} {
+		messages = append(messages, &SearchJobResultMessage{Map: m})
+	}
+	return messages
+}
+
+func TestQueryMessages(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected *MessageQueryResult
+	}{
+		{
+			query: "SELECT host, latency WHERE status >= 400 AND NOT host = 'b'",
+			expected: &MessageQueryResult{
+				Columns: []string{"host", "latency"},
+				Rows:    [][]interface{}{{"a", "30"}, {"a", "n/a"}},
+			},
+		},
+		{
+			query: "select host, count(*) as errors, max(latency) where status = 500 or (latency > 9 and status != 200) group by host",
+			expected: &MessageQueryResult{
+				Columns: []string{"host", "errors", "max(latency)"},
+				Rows:    [][]interface{}{{"b", 1.0, 90.0}, {"a", 2.0, 30.0}},
+			},
+		},
+		{
+			query: "SELECT count(latency), avg(latency), sum(latency), min(latency)",
+			expected: &MessageQueryResult{
+				Columns: []string{"count(latency)", "avg(latency)", "sum(latency)", "min(latency)"},
+				Rows:    [][]interface{}{{5.0, 33.75, 135.0, 5.0}},
+			},
+		},
+		{
+			query: "SELECT * WHERE latency < 6",
+			expected: &MessageQueryResult{
+				Columns: []string{"host", "latency", "status"},
+				Rows:    [][]interface{}{{"c", "5", "200"}},
+			},
+		},
+		{
+			query: "SELECT count(*), avg(latency) WHERE host = 'z'",
+			expected: &MessageQueryResult{
+				Columns: []string{"count(*)", "avg(latency)"},
+				Rows:    [][]interface{}{{0.0, nil}},
+			},
+		},
+	}
+	for _, test := range tests {
+		result, err := QueryMessages(messageQueryMessages(), test.query)
+		if err != nil {
+			t.Errorf("QueryMessages(%q) returned error: %v", test.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("QueryMessages(%q): expected %v, got %v", test.query, test.expected, result)
+		}
+	}
+}
+
+func TestQueryMessagesErrors(t *testing.T) {
+	for _, query := range []string{
+		"host",
+		"SELECT",
+		"SELECT host, count(*)",
+		"SELECT * GROUP BY host",
+		"SELECT median(latency)",
+		"SELECT host WHERE host = 'a",
+		"SELECT host WHERE (host = 'a'",
+		"SELECT host LIMIT 10",
+	} {
+		if _, err := QueryMessages(messageQueryMessages(), query); err == nil {
+			t.Errorf("Expected QueryMessages(%q) to fail", query)
+		}
+	}
+}
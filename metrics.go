@@ -0,0 +1,179 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricFormat is a format HTTP sources accept metrics in.
+type MetricFormat int
+
+// Metric formats.
+const (
+	MetricFormatCarbon2 MetricFormat = iota
+	MetricFormatPrometheus
+)
+
+// Content types that announce the metric format to an HTTP source.
+const (
+	contentTypeCarbon2    = "application/vnd.sumologic.carbon2"
+	contentTypePrometheus = "application/vnd.sumologic.prometheus"
+)
+
+// Datapoint is one value of a metric. Tags identify the time series; MetaTags only
+// describe it and are sent as Carbon 2.0 meta tags, or as labels in Prometheus format.
+type Datapoint struct {
+	Metric   string
+	Tags     map[string]string
+	MetaTags map[string]string
+	Value    float64
+	Time     time.Time
+}
+
+var prometheusNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// SendMetrics posts datapoints to the URL of an HTTP source in the format.
+func (c *Client) SendMetrics(sourceURL string, format MetricFormat, points []Datapoint, opts ...CallOption) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	var body strings.Builder
+	var contentType string
+	for _, p := range points {
+		var line string
+		var err error
+		switch format {
+		case MetricFormatCarbon2:
+			line, err = carbon2Line(p)
+			contentType = contentTypeCarbon2
+		case MetricFormatPrometheus:
+			line, err = prometheusLine(p)
+			contentType = contentTypePrometheus
+		default:
+			return fmt.Errorf("unknown metric format %d", format)
+		}
+		if err != nil {
+			return err
+		}
+		body.WriteString(line)
+		body.WriteByte('\n')
+	}
+
+	// The source URL carries its own token, so the request doesn't use the API credentials.
+	req, err := http.NewRequest("POST", sourceURL, strings.NewReader(body.String()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req = withCallOptions(req, opts)
+
+	resp, respBody, err := c.send(req)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusUnauthorized:
+		return ErrClientAuthenticationError
+	default:
+		return newAPIError(resp, respBody)
+	}
+}
+
+// carbon2Line formats the datapoint as a Carbon 2.0 line: intrinsic tags, two spaces,
+// meta tags, the value and the time in seconds.
+func carbon2Line(p Datapoint) (string, error) {
+	if p.Metric == "" {
+		return "", fmt.Errorf("datapoint has no metric name")
+	}
+	tags := map[string]string{"metric": p.Metric}
+	for k, v := range p.Tags {
+		tags[k] = v
+	}
+	intrinsic, err := carbon2Tags(tags)
+	if err != nil {
+		return "", err
+	}
+	meta, err := carbon2Tags(p.MetaTags)
+	if err != nil {
+		return "", err
+	}
+	line := intrinsic + "  "
+	if meta != "" {
+		line += meta + " "
+	}
+	return line + strconv.FormatFloat(p.Value, 'g', -1, 64) + " " + strconv.FormatInt(datapointTime(p).Unix(), 10), nil
+}
+
+func carbon2Tags(tags map[string]string) (string, error) {
+	pairs := make([]string, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		v := tags[k]
+		if k == "" || v == "" || strings.ContainsAny(k, " =") || strings.ContainsAny(v, " =") {
+			return "", fmt.Errorf("invalid Carbon 2.0 tag `%s=%s`: tags can't be empty or contain spaces or `=`", k, v)
+		}
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, " "), nil
+}
+
+// prometheusLine formats the datapoint in the Prometheus exposition format, with the time
+// in milliseconds.
+func prometheusLine(p Datapoint) (string, error) {
+	if !prometheusNameRegexp.MatchString(p.Metric) {
+		return "", fmt.Errorf("invalid Prometheus metric name `%s`", p.Metric)
+	}
+	labels := make(map[string]string, len(p.Tags)+len(p.MetaTags))
+	for k, v := range p.MetaTags {
+		labels[k] = v
+	}
+	for k, v := range p.Tags {
+		labels[k] = v
+	}
+
+	var b strings.Builder
+	b.WriteString(p.Metric)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, k := range sortedKeys(labels) {
+			if !prometheusNameRegexp.MatchString(k) || strings.Contains(k, ":") {
+				return "", fmt.Errorf("invalid Prometheus label name `%s`", k)
+			}
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(k + `="` + prometheusLabelEscaper.Replace(labels[k]) + `"`)
+		}
+		b.WriteByte('}')
+	}
+	b.WriteString(" " + strconv.FormatFloat(p.Value, 'g', -1, 64))
+	b.WriteString(" " + strconv.FormatInt(datapointTime(p).UnixNano()/int64(time.Millisecond), 10))
+	return b.String(), nil
+}
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// datapointTime returns the time of the datapoint, now when it isn't set.
+func datapointTime(p Datapoint) time.Time {
+	if p.Time.IsZero() {
+		return time.Now()
+	}
+	return p.Time
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,197 @@
+package sumologic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Metrics rollups, how the values of each quantization interval are combined.
+const (
+	MetricsRollupAvg   = "Avg"
+	MetricsRollupMin   = "Min"
+	MetricsRollupMax   = "Max"
+	MetricsRollupSum   = "Sum"
+	MetricsRollupCount = "Count"
+)
+
+// MetricsQuery is one row of a metrics query.
+type MetricsQuery struct {
+	// RowID names the row, so other rows can refer to it as #A. Rows are named A, B, C...
+	// in order when it's empty.
+	RowID string
+	Query string
+	// Quantization is the interval the values are aggregated over, chosen by the API when zero.
+	Quantization time.Duration
+	// Rollup is how the values of an interval are combined, MetricsRollupAvg when empty.
+	Rollup string
+	// Timeshift moves the query back in time, e.g. to compare with the week before.
+	Timeshift time.Duration
+}
+
+// MetricsQueryRequest is a metrics query over the time range [From, To).
+type MetricsQueryRequest struct {
+	Queries []MetricsQuery
+	From    time.Time
+	To      time.Time
+}
+
+// MetricsPoint is one value of a time series.
+type MetricsPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// MetricsTimeSeries is one time series of a metrics query result, identified by its
+// metric name and dimensions.
+type MetricsTimeSeries struct {
+	Metric     string            `json:"metric"`
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+	Points     []MetricsPoint    `json:"points"`
+}
+
+// Label returns the metric and its dimensions, e.g. CPU_Idle{_sourceHost=web-1}.
+func (ts MetricsTimeSeries) Label() string {
+	var dims []string
+	for _, k := range sortedKeys(ts.Dimensions) {
+		if k == "metric" {
+			continue
+		}
+		dims = append(dims, k+"="+ts.Dimensions[k])
+	}
+	if len(dims) == 0 {
+		return ts.Metric
+	}
+	return ts.Metric + "{" + strings.Join(dims, ",") + "}"
+}
+
+// MetricsQueryResult is the time series returned for one row of a query.
+type MetricsQueryResult struct {
+	RowID      string
+	TimeSeries []MetricsTimeSeries
+}
+
+// MetricsQueryError is returned when the API reports errors for a metrics query instead
+// of results, e.g. a query that doesn't parse.
+type MetricsQueryError struct {
+	Errors []string
+}
+
+func (e *MetricsQueryError) Error() string {
+	return fmt.Sprintf("metrics query failed: %s", strings.Join(e.Errors, "; "))
+}
+
+type metricsQueryBody struct {
+	Queries []metricsQueryRow `json:"queries"`
+	// The time range is bounded by epoch milliseconds on both ends.
+	TimeRange struct {
+		Type string                `json:"type"`
+		From metricsQueryBoundary  `json:"from"`
+		To   *metricsQueryBoundary `json:"to"`
+	} `json:"timeRange"`
+}
+
+type metricsQueryRow struct {
+	RowID        string `json:"rowId"`
+	Query        string `json:"query"`
+	Quantization int64  `json:"quantization,omitempty"`
+	Rollup       string `json:"rollup"`
+	Timeshift    int64  `json:"timeshift,omitempty"`
+}
+
+type metricsQueryBoundary struct {
+	Type        string `json:"type"`
+	EpochMillis int64  `json:"epochMillis"`
+}
+
+type metricsQueryResponse struct {
+	QueryResult []struct {
+		RowID          string `json:"rowId"`
+		TimeSeriesList struct {
+			TimeSeries []struct {
+				MetricDefinition struct {
+					Metric     string            `json:"metric"`
+					Dimensions map[string]string `json:"dimensions"`
+				} `json:"metricDefinition"`
+				Points struct {
+					Timestamps []int64   `json:"timestamps"`
+					Values     []float64 `json:"values"`
+				} `json:"points"`
+			} `json:"timeSeries"`
+		} `json:"timeSeriesList"`
+	} `json:"queryResult"`
+	Errors *struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"errors"`
+}
+
+// QueryMetrics runs a metrics query and returns the time series of each row.
+// POST metricsQueries
+func (c *Client) QueryMetrics(mqr MetricsQueryRequest, opts ...CallOption) ([]MetricsQueryResult, error) {
+	if len(mqr.Queries) == 0 {
+		return nil, fmt.Errorf("metrics query has no rows")
+	}
+	if !mqr.From.Before(mqr.To) {
+		return nil, ErrTimeRangeOrder
+	}
+
+	var in metricsQueryBody
+	for i, q := range mqr.Queries {
+		row := metricsQueryRow{
+			RowID:        q.RowID,
+			Query:        q.Query,
+			Quantization: q.Quantization.Milliseconds(),
+			Rollup:       q.Rollup,
+			Timeshift:    q.Timeshift.Milliseconds(),
+		}
+		if row.RowID == "" {
+			row.RowID = string(rune('A' + i))
+		}
+		if row.Rollup == "" {
+			row.Rollup = MetricsRollupAvg
+		}
+		in.Queries = append(in.Queries, row)
+	}
+	in.TimeRange.Type = "BeginBoundedTimeRange"
+	in.TimeRange.From = metricsQueryBoundary{Type: "EpochTimeRangeBoundary", EpochMillis: mqr.From.UnixMilli()}
+	in.TimeRange.To = &metricsQueryBoundary{Type: "EpochTimeRangeBoundary", EpochMillis: mqr.To.UnixMilli()}
+
+	var mr = new(metricsQueryResponse)
+	if err := c.do(call{method: "POST", path: "metricsQueries", in: in, out: mr}, opts); err != nil {
+		return nil, err
+	}
+	if len(mr.QueryResult) == 0 && mr.Errors != nil && len(mr.Errors.Errors) > 0 {
+		qe := new(MetricsQueryError)
+		for _, e := range mr.Errors.Errors {
+			qe.Errors = append(qe.Errors, e.Message)
+		}
+		return nil, qe
+	}
+	return mr.results()
+}
+
+func (mr *metricsQueryResponse) results() ([]MetricsQueryResult, error) {
+	results := make([]MetricsQueryResult, 0, len(mr.QueryResult))
+	for _, qr := range mr.QueryResult {
+		result := MetricsQueryResult{RowID: qr.RowID}
+		for _, ts := range qr.TimeSeriesList.TimeSeries {
+			if len(ts.Points.Timestamps) != len(ts.Points.Values) {
+				return nil, fmt.Errorf("time series of row %s has %d timestamps and %d values", qr.RowID, len(ts.Points.Timestamps), len(ts.Points.Values))
+			}
+			series := MetricsTimeSeries{
+				Metric:     ts.MetricDefinition.Metric,
+				Dimensions: ts.MetricDefinition.Dimensions,
+				Points:     make([]MetricsPoint, len(ts.Points.Values)),
+			}
+			for i, v := range ts.Points.Values {
+				series.Points[i] = MetricsPoint{Time: time.UnixMilli(ts.Points.Timestamps[i]), Value: v}
+			}
+			result.TimeSeries = append(result.TimeSeries, series)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
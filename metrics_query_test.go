@@ -0,0 +1,70 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestQueryMetrics(t *testing.T) {
+	from := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/metricsQueries" {
+			t.Errorf("Expected request to ‘/metricsQueries’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var in metricsQueryBody
+		json.NewDecoder(r.Body).Decode(&in)
+		if len(in.Queries) != 1 || in.Queries[0].RowID != "A" || in.Queries[0].Quantization != 60000 || in.Queries[0].Rollup != "Avg" {
+			t.Errorf("Unexpected queries %+v", in.Queries)
+		}
+		if in.TimeRange.From.EpochMillis != from.UnixMilli() || in.TimeRange.To.EpochMillis != from.Add(time.Hour).UnixMilli() {
+			t.Errorf("Unexpected time range %+v", in.TimeRange)
+		}
+		w.Write([]byte(`{"queryResult": [{"rowId": "A", "timeSeriesList": {"timeSeries": [{
+			"metricDefinition": {"metric": "CPU_Idle", "dimensions": {"_sourceHost": "web-1", "metric": "CPU_Idle"}},
+			"points": {"timestamps": [1677672000000, 1677672060000], "values": [95.5, 80]}
+		}]}}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	results, err := c.QueryMetrics(MetricsQueryRequest{
+		Queries: []MetricsQuery{{Query: "metric=CPU_Idle", Quantization: time.Minute}},
+		From:    from,
+		To:      from.Add(time.Hour),
+	})
+	if err != nil {
+		t.Errorf("QueryMetrics() returned an error: %s", err)
+		return
+	}
+	if len(results) != 1 || len(results[0].TimeSeries) != 1 {
+		t.Errorf("Expected one time series, got %+v", results)
+		return
+	}
+	series := results[0].TimeSeries[0]
+	if series.Label() != "CPU_Idle{_sourceHost=web-1}" {
+		t.Errorf("Unexpected label ‘%s’", series.Label())
+	}
+	if len(series.Points) != 2 || series.Points[1].Value != 80 || !series.Points[0].Time.Equal(from) {
+		t.Errorf("Unexpected points %+v", series.Points)
+	}
+}
+
+func TestQueryMetricsErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"queryResult": [], "errors": {"errors": [{"code": "parse.error", "message": "Unexpected token"}]}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	now := time.Now()
+	_, err := c.QueryMetrics(MetricsQueryRequest{Queries: []MetricsQuery{{Query: "metric=("}}, From: now.Add(-time.Hour), To: now})
+	if qe, ok := err.(*MetricsQueryError); !ok || qe.Errors[0] != "Unexpected token" {
+		t.Errorf("Expected a *MetricsQueryError, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package sumologic
+
+import (
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendMetrics(t *testing.T) {
+	at := time.Unix(1469126640, 0)
+	points := []Datapoint{
+		{Metric: "cpu_idle", Tags: map[string]string{"host": "a", "cluster": "c1"}, MetaTags: map[string]string{"team": "infra"}, Value: 97.3, Time: at},
+		{Metric: "cpu_idle", Tags: map[string]string{"host": "b"}, Value: 12, Time: at},
+	}
+	tests := []struct {
+		format      MetricFormat
+		contentType string
+		body        string
+	}{
+		{MetricFormatCarbon2, "application/vnd.sumologic.carbon2",
+			"cluster=c1 host=a metric=cpu_idle  team=infra 97.3 1469126640\n" +
+				"host=b metric=cpu_idle  12 1469126640\n"},
+		{MetricFormatPrometheus, "application/vnd.sumologic.prometheus",
+			`cpu_idle{cluster="c1",host="a",team="infra"} 97.3 1469126640000` + "\n" +
+				`cpu_idle{host="b"} 12 1469126640000` + "\n"},
+	}
+	for _, test := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" {
+				t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+			}
+			if r.URL.EscapedPath() != "/receiver/v1/http/token" {
+				t.Errorf("Expected request to ‘/receiver/v1/http/token’, got ‘%s’", r.URL.EscapedPath())
+			}
+			if auth := r.Header.Get("Authorization"); auth != "" {
+				t.Errorf("Expected no API credentials to be sent to the source, got ‘%s’", auth)
+			}
+			if ctype := r.Header.Get("Content-Type"); ctype != test.contentType {
+				t.Errorf("Expected content-type ‘%s’, got ‘%s’", test.contentType, ctype)
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			if string(body) != test.body {
+				t.Errorf("Expected body\n%s\ngot\n%s", test.body, body)
+			}
+		}))
+
+		c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/")
+		if err := c.SendMetrics(ts.URL+"/receiver/v1/http/token", test.format, points); err != nil {
+			t.Errorf("SendMetrics() returned an error: %s", err)
+		}
+		ts.Close()
+	}
+}
+
+func TestMetricLineValidation(t *testing.T) {
+	if _, err := carbon2Line(Datapoint{Metric: "cpu", Tags: map[string]string{"host": "a b"}}); err == nil {
+		t.Errorf("Expected an error for a Carbon 2.0 tag with a space")
+	}
+	if _, err := prometheusLine(Datapoint{Metric: "cpu.idle"}); err == nil {
+		t.Errorf("Expected an error for an invalid Prometheus metric name")
+	}
+	line, err := prometheusLine(Datapoint{Metric: "up", Tags: map[string]string{"path": `C:\a "b"`}, Value: math.Inf(1), Time: time.Unix(1, 0)})
+	if err != nil || line != `up{path="C:\\a \"b\""} +Inf 1000` {
+		t.Errorf("Unexpected Prometheus line %q (%v)", line, err)
+	}
+}
@@ -0,0 +1,302 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Monitor is a monitor or a folder of monitors in the monitors library, told apart by Type.
+// Folders list the items in them as Children.
+type Monitor struct {
+	ID              string                `json:"id,omitempty"`
+	Type            string                `json:"type"`
+	Name            string                `json:"name"`
+	Description     string                `json:"description,omitempty"`
+	ParentID        string                `json:"parentId,omitempty"`
+	Version         int                   `json:"version,omitempty"`
+	MonitorType     string                `json:"monitorType,omitempty"`
+	EvaluationDelay string                `json:"evaluationDelay,omitempty"`
+	IsDisabled      bool                  `json:"isDisabled,omitempty"`
+	Queries         []MonitorQuery        `json:"queries,omitempty"`
+	Triggers        []MonitorTrigger      `json:"triggers,omitempty"`
+	Notifications   []MonitorNotification `json:"notifications,omitempty"`
+	Playbook        string                `json:"playbook,omitempty"`
+	Children        []Monitor             `json:"children,omitempty"`
+	CreatedAt       string                `json:"createdAt,omitempty"`
+	CreatedBy       string                `json:"createdBy,omitempty"`
+	ModifiedAt      string                `json:"modifiedAt,omitempty"`
+	ModifiedBy      string                `json:"modifiedBy,omitempty"`
+}
+
+// Monitors library item types.
+const (
+	MonitorType       = "MonitorsLibraryMonitor"
+	MonitorFolderType = "MonitorsLibraryFolder"
+)
+
+// Monitor types, by the kind of query they evaluate.
+const (
+	MonitorTypeLogs    = "Logs"
+	MonitorTypeMetrics = "Metrics"
+)
+
+// MonitorQuery is one query of a monitor, identified by its RowID (A, B, ...).
+type MonitorQuery struct {
+	RowID string `json:"rowId"`
+	Query string `json:"query"`
+}
+
+// MonitorTrigger is a condition that sets or resolves an alert of a monitor.
+// Build them with LogsTrigger, MetricsTrigger or MissingDataTrigger.
+type MonitorTrigger struct {
+	DetectionMethod  string  `json:"detectionMethod"`
+	TriggerType      string  `json:"triggerType"`
+	TimeRange        string  `json:"timeRange"`
+	Threshold        float64 `json:"threshold"`
+	ThresholdType    string  `json:"thresholdType,omitempty"`
+	Field            string  `json:"field,omitempty"`
+	OccurrenceType   string  `json:"occurrenceType,omitempty"`
+	TriggerSource    string  `json:"triggerSource,omitempty"`
+	ResolutionWindow string  `json:"resolutionWindow,omitempty"`
+}
+
+// Detection methods of monitor triggers. Logs monitors take the Logs methods and
+// metrics monitors the Metrics ones.
+const (
+	MonitorDetectionLogsStatic         = "LogsStaticCondition"
+	MonitorDetectionLogsMissingData    = "LogsMissingDataCondition"
+	MonitorDetectionMetricsStatic      = "MetricsStaticCondition"
+	MonitorDetectionMetricsMissingData = "MetricsMissingDataCondition"
+)
+
+// Detection methods are named after the monitor type, e.g. LogsStaticCondition.
+const monitorDetectionMissingDataSuffix = "MissingDataCondition"
+
+// Trigger types of monitor triggers.
+const (
+	MonitorTriggerCritical            = "Critical"
+	MonitorTriggerWarning             = "Warning"
+	MonitorTriggerMissingData         = "MissingData"
+	MonitorTriggerResolvedCritical    = "ResolvedCritical"
+	MonitorTriggerResolvedWarning     = "ResolvedWarning"
+	MonitorTriggerResolvedMissingData = "ResolvedMissingData"
+)
+
+// Comparisons of a trigger's threshold with the query result.
+const (
+	ThresholdGreaterThan        = "GreaterThan"
+	ThresholdGreaterThanOrEqual = "GreaterThanOrEqual"
+	ThresholdLessThan           = "LessThan"
+	ThresholdLessThanOrEqual    = "LessThanOrEqual"
+)
+
+// LogsTrigger returns a logs monitor trigger that fires when the number of results,
+// or the value of field when it's not empty, compares with threshold over timeRange.
+func LogsTrigger(triggerType, timeRange string, thresholdType string, threshold float64, field string) MonitorTrigger {
+	return MonitorTrigger{
+		DetectionMethod: MonitorDetectionLogsStatic,
+		TriggerType:     triggerType,
+		TimeRange:       timeRange,
+		ThresholdType:   thresholdType,
+		Threshold:       threshold,
+		Field:           field,
+	}
+}
+
+// MetricsTrigger returns a metrics monitor trigger that fires when any time series
+// compares with threshold at least once over timeRange.
+func MetricsTrigger(triggerType, timeRange string, thresholdType string, threshold float64) MonitorTrigger {
+	return MonitorTrigger{
+		DetectionMethod: MonitorDetectionMetricsStatic,
+		TriggerType:     triggerType,
+		TimeRange:       timeRange,
+		ThresholdType:   thresholdType,
+		Threshold:       threshold,
+		OccurrenceType:  "AtLeastOnce",
+		TriggerSource:   "AnyTimeSeries",
+	}
+}
+
+// MissingDataTrigger returns a trigger of a monitor of the type that fires when its
+// query returns no data over timeRange.
+func MissingDataTrigger(monitorType, timeRange string) MonitorTrigger {
+	return MonitorTrigger{
+		DetectionMethod: monitorType + monitorDetectionMissingDataSuffix,
+		TriggerType:     MonitorTriggerMissingData,
+		TimeRange:       timeRange,
+	}
+}
+
+// MonitorNotification sends a notification when one of the trigger types fires.
+type MonitorNotification struct {
+	Notification       MonitorNotificationAction `json:"notification"`
+	RunForTriggerTypes []string                  `json:"runForTriggerTypes"`
+}
+
+// MonitorNotificationAction is an email to Recipients, or a message sent through the
+// connection with ConnectionID.
+type MonitorNotificationAction struct {
+	ConnectionType  string   `json:"connectionType"`
+	ConnectionID    string   `json:"connectionId,omitempty"`
+	PayloadOverride string   `json:"payloadOverride,omitempty"`
+	Recipients      []string `json:"recipients,omitempty"`
+	Subject         string   `json:"subject,omitempty"`
+	MessageBody     string   `json:"messageBody,omitempty"`
+	TimeZone        string   `json:"timeZone,omitempty"`
+}
+
+// MonitorSearchResult is a monitor found by SearchMonitors, with the path of its folder.
+type MonitorSearchResult struct {
+	Item Monitor `json:"item"`
+	Path string  `json:"path"`
+}
+
+// ErrMonitorNotFound is returned when a monitor or folder doesn't exist.
+var ErrMonitorNotFound = errors.New("Monitor not found")
+
+// ValidateMonitor checks a monitor's queries and triggers before it's created or updated:
+// it needs a query, and triggers whose detection methods match its monitor type.
+func ValidateMonitor(m Monitor) error {
+	if m.Type == MonitorFolderType {
+		return nil
+	}
+	switch m.MonitorType {
+	case MonitorTypeLogs, MonitorTypeMetrics:
+	default:
+		return fmt.Errorf("monitor `%s` has invalid monitor type `%s`", m.Name, m.MonitorType)
+	}
+	if len(m.Queries) == 0 {
+		return fmt.Errorf("monitor `%s` has no queries", m.Name)
+	}
+	if len(m.Triggers) == 0 {
+		return fmt.Errorf("monitor `%s` has no triggers", m.Name)
+	}
+	for _, t := range m.Triggers {
+		if !strings.HasPrefix(t.DetectionMethod, m.MonitorType) {
+			return fmt.Errorf("monitor `%s` of type %s has a %s trigger", m.Name, m.MonitorType, t.DetectionMethod)
+		}
+		if t.TimeRange == "" {
+			return fmt.Errorf("monitor `%s` has a %s trigger without a time range", m.Name, t.TriggerType)
+		}
+	}
+	return nil
+}
+
+// GetMonitorsRootFolder gets the root folder of the monitors library.
+func (c *Client) GetMonitorsRootFolder(opts ...CallOption) (*Monitor, error) {
+	return c.getMonitor("monitors/root", opts...)
+}
+
+// GetMonitor gets the monitor or folder with the specified ID.
+func (c *Client) GetMonitor(id string, opts ...CallOption) (*Monitor, error) {
+	return c.getMonitor(fmt.Sprintf("monitors/%s", id), opts...)
+}
+
+// CreateMonitor creates a monitor or folder in the folder with the specified ID.
+// Monitors without a Type are created as monitors.
+func (c *Client) CreateMonitor(parentID string, m Monitor, opts ...CallOption) (*Monitor, error) {
+	if m.Type == "" {
+		m.Type = MonitorType
+	}
+	q := url.Values{}
+	q.Set("parentId", parentID)
+	return c.sendMonitor(call{method: "POST", path: "monitors?" + q.Encode(), in: m, create: true}, m.Name, opts)
+}
+
+// UpdateMonitor updates the monitor or folder with the same ID. Version must be the
+// version last read, so concurrent changes aren't overwritten.
+func (c *Client) UpdateMonitor(m Monitor, opts ...CallOption) (*Monitor, error) {
+	if m.Type == "" {
+		m.Type = MonitorType
+	}
+	return c.sendMonitor(call{method: "PUT", path: fmt.Sprintf("monitors/%s", m.ID), in: m}, m.Name, opts)
+}
+
+// DeleteMonitor deletes the monitor or folder with the specified ID, along with
+// everything in the folder.
+func (c *Client) DeleteMonitor(id string, opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("monitors/%s", id), notFound: ErrMonitorNotFound}, opts)
+}
+
+// MoveMonitor moves the monitor or folder with the specified ID into another folder.
+func (c *Client) MoveMonitor(id, parentID string, opts ...CallOption) (*Monitor, error) {
+	q := url.Values{}
+	q.Set("parentId", parentID)
+	return c.sendMonitor(call{method: "POST", path: fmt.Sprintf("monitors/%s/move?%s", id, q.Encode())}, id, opts)
+}
+
+// CopyMonitor copies the monitor or folder with the specified ID into another folder.
+// The copy keeps the original's name unless name is set.
+func (c *Client) CopyMonitor(id, parentID, name string, opts ...CallOption) (*Monitor, error) {
+	in := struct {
+		ParentID string `json:"parentId"`
+		Name     string `json:"name,omitempty"`
+	}{parentID, name}
+	return c.sendMonitor(call{method: "POST", path: fmt.Sprintf("monitors/%s/copy", id), in: in}, id, opts)
+}
+
+// SearchMonitors returns a page of the monitors and folders matching the query, such as
+// `createdBy:000000000000968B` or a name. A limit of 0 uses the API default.
+func (c *Client) SearchMonitors(query string, limit, offset int, opts ...CallOption) ([]MonitorSearchResult, error) {
+	q := url.Values{}
+	q.Set("query", query)
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	}
+
+	var results []MonitorSearchResult
+	if err := c.do(call{method: "GET", path: "monitors/search?" + q.Encode(), out: &results}, opts); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Monitors returns a ResourceClient for monitors and monitor folders.
+// Create adds them to the root folder of the monitors library unless ParentID is set.
+func (c *Client) Monitors(opts ...CallOption) ResourceClient[Monitor] {
+	return &resourceClient[Monitor]{
+		get: func(id string) (*Monitor, error) {
+			return c.GetMonitor(id, opts...)
+		},
+		create: func(m Monitor) (*Monitor, error) {
+			parentID := m.ParentID
+			if parentID == "" {
+				root, err := c.GetMonitorsRootFolder(opts...)
+				if err != nil {
+					return nil, err
+				}
+				parentID = root.ID
+			}
+			return c.CreateMonitor(parentID, m, opts...)
+		},
+		update: func(m Monitor) (*Monitor, error) {
+			return c.UpdateMonitor(m, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteMonitor(id, opts...)
+		},
+	}
+}
+
+func (c *Client) getMonitor(path string, opts ...CallOption) (*Monitor, error) {
+	return c.sendMonitor(call{method: "GET", path: path}, "", opts)
+}
+
+// sendMonitor makes a call returning a monitor. name identifies the monitor in
+// validation errors.
+func (c *Client) sendMonitor(cl call, name string, opts []CallOption) (*Monitor, error) {
+	var m = new(Monitor)
+	cl.out = m
+	cl.notFound = ErrMonitorNotFound
+	cl.badRequest = fmt.Errorf("Bad Request. Please check the settings for monitor `%s`", name)
+	if err := c.do(cl, opts); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
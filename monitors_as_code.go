@@ -0,0 +1,250 @@
+package sumologic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Actions of the changes of a MonitorPlan.
+const (
+	MonitorChangeCreate = "create"
+	MonitorChangeUpdate = "update"
+	MonitorChangeDelete = "delete"
+)
+
+// monitorIgnoredFields are the fields left out when comparing a declared monitor with
+// the one in the library: those set by the API, and the children compared on their own.
+var monitorIgnoredFields = append([]string{"version", "children"}, VolatileContentFields...)
+
+// MonitorChange is one change a MonitorPlan makes to the monitors library. Path is the
+// slash separated names of the item's folders and the item itself, below the managed
+// folder. Existing is the item in the library for updates and deletes.
+type MonitorChange struct {
+	Action   string
+	Path     string
+	Monitor  Monitor
+	Existing *Monitor
+}
+
+// MonitorPlan is the changes that make a folder of the monitors library match declared
+// monitors, in the order they're applied: parent folders are created before what's in
+// them.
+type MonitorPlan struct {
+	FolderID string
+	Changes  []MonitorChange
+}
+
+// LoadMonitors reads declared monitors and folders from JSON: an array of monitors, with
+// the contents of folders as their children. Items without a Type are monitors, or
+// folders when they have children. Every monitor is checked with ValidateMonitor and
+// names must be unique within a folder, since they identify the items.
+func LoadMonitors(r io.Reader) ([]Monitor, error) {
+	var monitors []Monitor
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&monitors); err != nil {
+		return nil, err
+	}
+	if err := prepareDeclaredMonitors(monitors, ""); err != nil {
+		return nil, err
+	}
+	return monitors, nil
+}
+
+func prepareDeclaredMonitors(monitors []Monitor, parentPath string) error {
+	seen := make(map[string]bool)
+	for i := range monitors {
+		m := &monitors[i]
+		if m.Name == "" || strings.Contains(m.Name, "/") {
+			return fmt.Errorf("monitor in `%s` has an empty name or one containing `/`", parentPath)
+		}
+		path := joinMonitorPath(parentPath, m.Name)
+		// Items are placed by where they're declared.
+		m.ParentID = ""
+		if seen[m.Name] {
+			return fmt.Errorf("monitor `%s` is declared twice", path)
+		}
+		seen[m.Name] = true
+
+		if m.Type == "" {
+			m.Type = MonitorType
+			if len(m.Children) > 0 {
+				m.Type = MonitorFolderType
+			}
+		}
+		if m.Type != MonitorFolderType {
+			if len(m.Children) > 0 {
+				return fmt.Errorf("monitor `%s` has children but isn't a folder", path)
+			}
+			if err := ValidateMonitor(*m); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := prepareDeclaredMonitors(m.Children, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PlanMonitors compares declared monitors with the contents of the folder with the
+// specified ID, the root folder of the library when it's empty, and returns the changes
+// that make the folder match them. Items are matched by path. Items in the folder that
+// aren't declared are only deleted when prune is set.
+func (c *Client) PlanMonitors(folderID string, declared []Monitor, prune bool, opts ...CallOption) (*MonitorPlan, error) {
+	var folder *Monitor
+	var err error
+	if folderID == "" {
+		folder, err = c.GetMonitorsRootFolder(opts...)
+	} else {
+		folder, err = c.GetMonitor(folderID, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan := &MonitorPlan{FolderID: folder.ID}
+	if err := c.planMonitorFolder(plan, folder, declared, "", prune, opts); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (c *Client) planMonitorFolder(plan *MonitorPlan, folder *Monitor, declared []Monitor, path string, prune bool, opts []CallOption) error {
+	existing := make(map[string]Monitor, len(folder.Children))
+	for _, child := range folder.Children {
+		existing[child.Name] = child
+	}
+
+	for _, m := range declared {
+		childPath := joinMonitorPath(path, m.Name)
+		current, ok := existing[m.Name]
+		delete(existing, m.Name)
+		if !ok {
+			m.ParentID = folder.ID
+			planMonitorCreate(plan, m, childPath)
+			continue
+		}
+		if current.Type != m.Type {
+			return fmt.Errorf("monitor `%s` is a %s in the library but declared as a %s", childPath, current.Type, m.Type)
+		}
+
+		// Folder listings don't hold the full definition of their items.
+		full, err := c.GetMonitor(current.ID, opts...)
+		if err != nil {
+			return err
+		}
+		changed, err := monitorChanged(m, *full)
+		if err != nil {
+			return err
+		}
+		if changed {
+			plan.Changes = append(plan.Changes, MonitorChange{Action: MonitorChangeUpdate, Path: childPath, Monitor: m, Existing: full})
+		}
+		if m.Type == MonitorFolderType {
+			if err := c.planMonitorFolder(plan, full, m.Children, childPath, prune, opts); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !prune {
+		return nil
+	}
+	for _, child := range folder.Children {
+		if current, ok := existing[child.Name]; ok {
+			plan.Changes = append(plan.Changes, MonitorChange{Action: MonitorChangeDelete, Path: joinMonitorPath(path, child.Name), Existing: &current})
+		}
+	}
+	return nil
+}
+
+// planMonitorCreate adds the creation of a monitor or folder and everything in it. The
+// ParentID of the monitor is set when its folder already exists; what's in a folder
+// created by the plan goes into it once it's created.
+func planMonitorCreate(plan *MonitorPlan, m Monitor, path string) {
+	children := m.Children
+	m.Children = nil
+	plan.Changes = append(plan.Changes, MonitorChange{Action: MonitorChangeCreate, Path: path, Monitor: m})
+	for _, child := range children {
+		child.ParentID = ""
+		planMonitorCreate(plan, child, joinMonitorPath(path, child.Name))
+	}
+}
+
+// monitorChanged reports whether a declared monitor differs from the one in the library,
+// ignoring the fields set by the API.
+func monitorChanged(declared, current Monitor) (bool, error) {
+	a, err := canonicalMonitor(declared)
+	if err != nil {
+		return false, err
+	}
+	b, err := canonicalMonitor(current)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(a, b), nil
+}
+
+func canonicalMonitor(m Monitor) ([]byte, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return CanonicalContent(data, monitorIgnoredFields)
+}
+
+// ApplyMonitorPlan makes the plan's changes in order. It stops at the first change that
+// fails and returns the changes made before it.
+func (c *Client) ApplyMonitorPlan(plan *MonitorPlan, opts ...CallOption) ([]MonitorChange, error) {
+	// Folders created by the plan, by path, for the items created in them.
+	folders := make(map[string]string)
+	var applied []MonitorChange
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case MonitorChangeCreate:
+			parentID := change.Monitor.ParentID
+			if parentID == "" {
+				parentPath := ""
+				if i := strings.LastIndex(change.Path, "/"); i >= 0 {
+					parentPath = change.Path[:i]
+				}
+				var ok bool
+				if parentID, ok = folders[parentPath]; !ok {
+					return applied, fmt.Errorf("folder `%s` of monitor `%s` isn't created by the plan", parentPath, change.Path)
+				}
+			}
+			created, err := c.CreateMonitor(parentID, change.Monitor, opts...)
+			if err != nil {
+				return applied, err
+			}
+			if created.Type == MonitorFolderType {
+				folders[change.Path] = created.ID
+			}
+		case MonitorChangeUpdate:
+			m := change.Monitor
+			m.ID, m.Version, m.Children = change.Existing.ID, change.Existing.Version, nil
+			if _, err := c.UpdateMonitor(m, opts...); err != nil {
+				return applied, err
+			}
+		case MonitorChangeDelete:
+			if err := c.DeleteMonitor(change.Existing.ID, opts...); err != nil {
+				return applied, err
+			}
+		default:
+			return applied, fmt.Errorf("unknown change `%s` of monitor `%s`", change.Action, change.Path)
+		}
+		applied = append(applied, change)
+	}
+	return applied, nil
+}
+
+func joinMonitorPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
@@ -0,0 +1,99 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const declaredMonitors = `[
+	{"name": "Team", "children": [
+		{"name": "Errors", "monitorType": "Logs", "queries": [{"rowId": "A", "query": "error"}],
+		 "triggers": [{"detectionMethod": "LogsStaticCondition", "triggerType": "Critical", "timeRange": "-15m", "threshold": 20}]},
+		{"name": "Timeouts", "monitorType": "Logs", "queries": [{"rowId": "A", "query": "timeout"}],
+		 "triggers": [{"detectionMethod": "LogsStaticCondition", "triggerType": "Critical", "timeRange": "-15m", "threshold": 5}]}
+	]},
+	{"name": "Platform", "type": "MonitorsLibraryFolder", "children": [
+		{"name": "Latency", "monitorType": "Metrics", "queries": [{"rowId": "A", "query": "metric=latency"}],
+		 "triggers": [{"detectionMethod": "MetricsStaticCondition", "triggerType": "Critical", "timeRange": "-5m", "threshold": 1}]}
+	]}
+]`
+
+func TestLoadMonitorsRejectsDuplicates(t *testing.T) {
+	_, err := LoadMonitors(strings.NewReader(`[{"name": "Team", "type": "MonitorsLibraryFolder"}, {"name": "Team", "type": "MonitorsLibraryFolder"}]`))
+	if err == nil || !strings.Contains(err.Error(), "declared twice") {
+		t.Errorf("Expected an error for a duplicate name, got %v", err)
+	}
+}
+
+func TestPlanAndApplyMonitors(t *testing.T) {
+	declared, err := LoadMonitors(strings.NewReader(declaredMonitors))
+	if err != nil {
+		t.Errorf("LoadMonitors() returned an error: %s", err)
+		return
+	}
+
+	errors := Monitor{ID: "m1", Type: MonitorType, Name: "Errors", Version: 3, MonitorType: MonitorTypeLogs,
+		Queries:  []MonitorQuery{{RowID: "A", Query: "error"}},
+		Triggers: []MonitorTrigger{{DetectionMethod: MonitorDetectionLogsStatic, TriggerType: MonitorTriggerCritical, TimeRange: "-15m", Threshold: 10}}}
+	library := map[string]Monitor{
+		"/monitors/root": {ID: "root", Type: MonitorFolderType, Children: []Monitor{
+			{ID: "f1", Type: MonitorFolderType, Name: "Team"},
+			{ID: "m9", Type: MonitorType, Name: "Old"},
+		}},
+		"/monitors/f1": {ID: "f1", Type: MonitorFolderType, Name: "Team", Children: []Monitor{errors}},
+		"/monitors/m1": errors,
+	}
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			m, ok := library[r.URL.EscapedPath()]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(m)
+			return
+		}
+		var m Monitor
+		json.NewDecoder(r.Body).Decode(&m)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+m.Name)
+		if r.Method == "POST" {
+			m.ID = "new-" + m.Name
+		}
+		json.NewEncoder(w).Encode(m)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	plan, err := c.PlanMonitors("", declared, true)
+	if err != nil {
+		t.Errorf("PlanMonitors() returned an error: %s", err)
+		return
+	}
+	var changes []string
+	for _, change := range plan.Changes {
+		changes = append(changes, change.Action+" "+change.Path)
+	}
+	expected := []string{"update Team/Errors", "create Team/Timeouts", "create Platform", "create Platform/Latency", "delete Old"}
+	if strings.Join(changes, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected changes %v, got %v", expected, changes)
+	}
+
+	if _, err := c.ApplyMonitorPlan(plan); err != nil {
+		t.Errorf("ApplyMonitorPlan() returned an error: %s", err)
+		return
+	}
+	expected = []string{
+		"PUT /monitors/m1 Errors",
+		"POST /monitors?parentId=f1 Timeouts",
+		"POST /monitors?parentId=root Platform",
+		"POST /monitors?parentId=new-Platform Latency",
+		"DELETE /monitors/m9 ",
+	}
+	if strings.Join(requests, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,95 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateMonitor(t *testing.T) {
+	m := Monitor{
+		Name:        "errors",
+		MonitorType: MonitorTypeLogs,
+		Queries:     []MonitorQuery{{RowID: "A", Query: "error"}},
+		Triggers: []MonitorTrigger{
+			LogsTrigger(MonitorTriggerCritical, "-15m", ThresholdGreaterThan, 100, ""),
+			MissingDataTrigger(MonitorTypeLogs, "-1h"),
+		},
+	}
+	if err := ValidateMonitor(m); err != nil {
+		t.Errorf("ValidateMonitor() returned an error: %s", err)
+	}
+	if m.Triggers[1].DetectionMethod != MonitorDetectionLogsMissingData {
+		t.Errorf("Expected a logs missing data trigger, got %s", m.Triggers[1].DetectionMethod)
+	}
+
+	m.Triggers = append(m.Triggers, MetricsTrigger(MonitorTriggerWarning, "-5m", ThresholdGreaterThan, 0.9))
+	if err := ValidateMonitor(m); err == nil {
+		t.Errorf("Expected an error for a metrics trigger on a logs monitor")
+	}
+	if err := ValidateMonitor(Monitor{Name: "folder", Type: MonitorFolderType}); err != nil {
+		t.Errorf("Expected folders to be valid, got %s", err)
+	}
+}
+
+func TestMonitorsCreateInRootFolder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/monitors/root":
+			w.Write([]byte(`{"id": "root", "type": "MonitorsLibraryFolder", "name": "Root"}`))
+		case "/monitors":
+			if r.Method != "POST" {
+				t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+			}
+			if r.URL.Query().Get("parentId") != "root" {
+				t.Errorf("Expected the monitor to be created in the root folder, got ‘%s’", r.URL.RawQuery)
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			var m Monitor
+			json.Unmarshal(body, &m)
+			if m.Type != MonitorType || m.Triggers[0].DetectionMethod != MonitorDetectionMetricsStatic {
+				t.Errorf("Unexpected monitor `%s`", body)
+			}
+			m.ID = "m1"
+			json.NewEncoder(w).Encode(m)
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	m, err := c.Monitors().Create(Monitor{
+		Name:        "cpu",
+		MonitorType: MonitorTypeMetrics,
+		Queries:     []MonitorQuery{{RowID: "A", Query: "metric=cpu"}},
+		Triggers:    []MonitorTrigger{MetricsTrigger(MonitorTriggerCritical, "-5m", ThresholdGreaterThan, 90)},
+	})
+	if err != nil {
+		t.Errorf("Create() returned an error: %s", err)
+		return
+	}
+	if m.ID != "m1" {
+		t.Errorf("Expected the created monitor, got %+v", m)
+	}
+}
+
+func TestMoveMonitorDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/monitors/m1/move" || r.URL.Query().Get("parentId") != "f1" {
+			t.Errorf("Expected request to ‘/monitors/m1/move?parentId=f1’, got ‘%s’", r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if _, err := c.MoveMonitor("m1", "f1"); err != ErrMonitorNotFound {
+		t.Errorf("MoveMonitor() returned the wrong error: %v", err)
+	}
+}
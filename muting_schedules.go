@@ -0,0 +1,118 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// MutingSchedule silences the notifications of monitors during a scheduled window.
+type MutingSchedule struct {
+	ID          string                 `json:"id,omitempty"`
+	Type        string                 `json:"type"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	ParentID    string                 `json:"parentId,omitempty"`
+	Monitor     MutingScheduleScope    `json:"monitor"`
+	Schedule    MutingScheduleDuration `json:"schedule"`
+}
+
+// Muting schedule library item types.
+const (
+	MutingScheduleType       = "MutingSchedulesLibraryMutingSchedule"
+	MutingScheduleFolderType = "MutingSchedulesLibraryFolder"
+)
+
+// MutingScheduleScope selects the monitors and monitor folders a schedule mutes.
+type MutingScheduleScope struct {
+	IDs []string `json:"ids,omitempty"`
+	All bool     `json:"all"`
+}
+
+// MutingScheduleDuration is when a muting schedule starts and how long it lasts.
+// StartDate is formatted as 2006-01-02, StartTime as 15:04 and Duration is in minutes.
+// RRule optionally repeats the window.
+type MutingScheduleDuration struct {
+	TimeZone  string `json:"timezone"`
+	StartDate string `json:"startDate"`
+	StartTime string `json:"startTime"`
+	Duration  int    `json:"duration"`
+	RRule     string `json:"rrule,omitempty"`
+}
+
+// MutingScheduleSearchResult is a muting schedule found by SearchMutingSchedules.
+type MutingScheduleSearchResult struct {
+	Item MutingSchedule `json:"item"`
+	Path string         `json:"path"`
+}
+
+// ErrMutingScheduleNotFound is returned when a muting schedule doesn't exist on a Read or Delete.
+var ErrMutingScheduleNotFound = errors.New("Muting schedule not found")
+
+// GetMutingSchedulesRootFolder gets the root folder of the muting schedules library.
+func (c *Client) GetMutingSchedulesRootFolder(opts ...CallOption) (*MutingSchedule, error) {
+	var ms = new(MutingSchedule)
+	if err := c.do(call{method: "GET", path: "mutingSchedules/root", out: ms}, opts); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// CreateMutingSchedule creates a muting schedule in the folder with the specified ID.
+func (c *Client) CreateMutingSchedule(parentID string, ms MutingSchedule, opts ...CallOption) (*MutingSchedule, error) {
+	if ms.Type == "" {
+		ms.Type = MutingScheduleType
+	}
+	q := url.Values{}
+	q.Set("parentId", parentID)
+	var created = new(MutingSchedule)
+	err := c.do(call{
+		method:     "POST",
+		path:       "mutingSchedules?" + q.Encode(),
+		in:         ms,
+		out:        created,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for muting schedule `%s`", ms.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// SearchMutingSchedules returns the muting schedules matching the query.
+func (c *Client) SearchMutingSchedules(query string, opts ...CallOption) ([]MutingScheduleSearchResult, error) {
+	q := url.Values{}
+	q.Set("query", query)
+	var results []MutingScheduleSearchResult
+	if err := c.do(call{method: "GET", path: "mutingSchedules/search?" + q.Encode(), out: &results}, opts); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// DeleteMutingSchedule deletes the muting schedule with the specified ID.
+func (c *Client) DeleteMutingSchedule(id string, opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("mutingSchedules/%s", id), notFound: ErrMutingScheduleNotFound}, opts)
+}
+
+// MutingSchedules returns a ResourceClient for muting schedules.
+// Create adds schedules to the root folder of the muting schedules library.
+func (c *Client) MutingSchedules(opts ...CallOption) ResourceClient[MutingSchedule] {
+	return &resourceClient[MutingSchedule]{
+		create: func(ms MutingSchedule) (*MutingSchedule, error) {
+			parentID := ms.ParentID
+			if parentID == "" {
+				root, err := c.GetMutingSchedulesRootFolder(opts...)
+				if err != nil {
+					return nil, err
+				}
+				parentID = root.ID
+			}
+			return c.CreateMutingSchedule(parentID, ms, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteMutingSchedule(id, opts...)
+		},
+	}
+}
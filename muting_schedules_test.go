@@ -0,0 +1,31 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeleteMutingScheduleDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/mutingSchedules/abc" {
+			t.Errorf("Expected request to ‘/mutingSchedules/abc’, got ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	err = c.DeleteMutingSchedule("abc")
+	if err != ErrMutingScheduleNotFound {
+		t.Errorf("DeleteMutingSchedule() returned the wrong error: %s", err)
+	}
+}
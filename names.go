@@ -0,0 +1,115 @@
+package sumologic
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NameResolverTTL is how long the Lookup methods of Client reuse a listing of a resource type.
+// Listings are made with the call options of the first lookup of the type.
+const NameResolverTTL = 5 * time.Minute
+
+// AmbiguousNameError is returned when a name matches more than one resource.
+type AmbiguousNameError struct {
+	Kind string
+	Name string
+	IDs  []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%s name %q is ambiguous, it matches %s", e.Kind, e.Name, strings.Join(e.IDs, ", "))
+}
+
+// NameResolver resolves the names of one type of resource to IDs. It lists the resources
+// once and reuses the listing until the TTL passes, so resolving many names makes one call.
+type NameResolver[T any] struct {
+	client   ResourceClient[T]
+	kind     string
+	name     func(T) string
+	id       func(T) string
+	notFound error
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	ids     map[string][]string
+	expires time.Time
+}
+
+// NewNameResolver returns a resolver for the resources listed by client. kind names the
+// resource type in errors, and notFound is returned for names that match nothing.
+func NewNameResolver[T any](client ResourceClient[T], kind string, name, id func(T) string, notFound error, ttl time.Duration) *NameResolver[T] {
+	return &NameResolver[T]{client: client, kind: kind, name: name, id: id, notFound: notFound, ttl: ttl}
+}
+
+// Resolve returns the ID of the resource with the name. It fails with an
+// *AmbiguousNameError when several resources have the name.
+func (r *NameResolver[T]) Resolve(name string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ids == nil || !time.Now().Before(r.expires) {
+		resources, err := r.client.List()
+		if err != nil {
+			return "", err
+		}
+		r.ids = make(map[string][]string)
+		for _, resource := range resources {
+			n := r.name(resource)
+			r.ids[n] = append(r.ids[n], r.id(resource))
+		}
+		r.expires = time.Now().Add(r.ttl)
+	}
+
+	ids := r.ids[name]
+	switch len(ids) {
+	case 0:
+		return "", r.notFound
+	case 1:
+		return ids[0], nil
+	default:
+		return "", &AmbiguousNameError{Kind: r.kind, Name: name, IDs: append([]string(nil), ids...)}
+	}
+}
+
+// Invalidate drops the cached listing, e.g. after creating or renaming a resource.
+func (r *NameResolver[T]) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = nil
+}
+
+// nameResolver returns the client's resolver for a kind of resource, creating it on first use.
+func nameResolver[T any](c *Client, kind string, newResolver func() *NameResolver[T]) *NameResolver[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nameResolvers == nil {
+		c.nameResolvers = make(map[string]interface{})
+	}
+	r, ok := c.nameResolvers[kind].(*NameResolver[T])
+	if !ok {
+		r = newResolver()
+		c.nameResolvers[kind] = r
+	}
+	return r
+}
+
+// LookupPartitionByName returns the ID of the partition with the name.
+func (c *Client) LookupPartitionByName(name string, opts ...CallOption) (string, error) {
+	return nameResolver(c, "partition", func() *NameResolver[Partition] {
+		return NewNameResolver(c.Partitions(opts...), "partition",
+			func(p Partition) string { return p.Name },
+			func(p Partition) string { return p.ID },
+			ErrPartitionNotFound, NameResolverTTL)
+	}).Resolve(name)
+}
+
+// LookupEntityByName returns the ID of the entity with the name.
+func (c *Client) LookupEntityByName(name string, opts ...CallOption) (string, error) {
+	return nameResolver(c, "entity", func() *NameResolver[Entity] {
+		return NewNameResolver(c.Entities(opts...), "entity",
+			func(e Entity) string { return e.Name },
+			func(e Entity) string { return e.ID },
+			ErrEntityNotFound, NameResolverTTL)
+	}).Resolve(name)
+}
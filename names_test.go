@@ -0,0 +1,47 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLookupPartitionByName(t *testing.T) {
+	lists := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/partitions" {
+			t.Errorf("Expected request to ‘/partitions’, got ‘%s’", r.URL.EscapedPath())
+		}
+		lists++
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(PartitionList{Data: []Partition{
+			{ID: "1", Name: "prod"},
+			{ID: "2", Name: "audit"},
+			{ID: "3", Name: "audit"},
+		}})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	id, err := c.LookupPartitionByName("prod")
+	if err != nil || id != "1" {
+		t.Errorf("Expected partition 1, got %q and %v", id, err)
+	}
+	if _, err := c.LookupPartitionByName("missing"); err != ErrPartitionNotFound {
+		t.Errorf("Expected ErrPartitionNotFound, got %v", err)
+	}
+	_, err = c.LookupPartitionByName("audit")
+	if ambiguous, ok := err.(*AmbiguousNameError); !ok || len(ambiguous.IDs) != 2 {
+		t.Errorf("Expected an *AmbiguousNameError matching 2 partitions, got %v", err)
+	}
+	if lists != 1 {
+		t.Errorf("Expected the partitions to be listed once, got %d", lists)
+	}
+}
@@ -0,0 +1,250 @@
+package sumologic
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption overrides the client's defaults for a single API call.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	header    http.Header
+	timeout   time.Duration
+	retry     *RetryPolicy
+	adminMode bool
+
+	maxResponseBytes int64
+
+	ctx     context.Context
+	limiter *rateLimiter
+
+	actor       string
+	lowPriority bool
+
+	resultFields []string
+}
+
+// RetryPolicy retries calls that fail with a transient error. A call is made at most
+// MaxAttempts times. Each class of failure backs off separately: after the first 5xx
+// response the call waits Backoff, and twice as long after each one after that, up to
+// MaxBackoff. 429 responses back off the same way from RateLimitBackoff, unless they say
+// when to retry with a Retry-After header, which is always followed. Connection errors
+// are first retried straight away FastRetries times, then back off like 5xx responses.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// RateLimitBackoff is the first wait after a 429 response without a Retry-After
+	// header, Backoff when zero.
+	RateLimitBackoff time.Duration
+	// FastRetries is how many connection errors are retried without waiting.
+	FastRetries int
+	// Retryable decides which failures are retried, RetryTransient when nil.
+	Retryable func(req *http.Request, resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy is the retry policy of clients created by NewClient. It only retries
+// 5xx responses and network errors of requests that are safe to repeat, so a create
+// isn't made twice when its response is lost.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:      3,
+	Backoff:          time.Second,
+	MaxBackoff:       30 * time.Second,
+	RateLimitBackoff: 5 * time.Second,
+	FastRetries:      1,
+	Retryable:        RetryIdempotent,
+}
+
+// RetryTransient retries network errors, 429 responses and 5xx responses.
+func RetryTransient(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// A response over the size limit will be just as large next time.
+		_, tooLarge := err.(*ResponseTooLargeError)
+		return !tooLarge
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// RetryIdempotent retries like RetryTransient, except that POST and PATCH requests are
+// only retried on 429 responses, which the API sends before doing anything.
+func RetryIdempotent(req *http.Request, resp *http.Response, err error) bool {
+	if req.Method == "POST" || req.Method == "PATCH" {
+		return err == nil && resp.StatusCode == http.StatusTooManyRequests
+	}
+	return RetryTransient(req, resp, err)
+}
+
+// failureClass is a kind of failure that backs off separately from the others.
+type failureClass int
+
+const (
+	serverFailure failureClass = iota
+	rateLimitFailure
+	connectionFailure
+)
+
+func classifyFailure(resp *http.Response, err error) failureClass {
+	switch {
+	case err != nil:
+		return connectionFailure
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return rateLimitFailure
+	default:
+		return serverFailure
+	}
+}
+
+// wait returns the wait before retrying the n-th failure of the class.
+func (p *RetryPolicy) wait(class failureClass, n int) time.Duration {
+	switch class {
+	case rateLimitFailure:
+		if p.RateLimitBackoff > 0 {
+			return p.backoffFrom(p.RateLimitBackoff, n)
+		}
+	case connectionFailure:
+		if n <= p.FastRetries {
+			return 0
+		}
+		n -= p.FastRetries
+	}
+	return p.backoff(n)
+}
+
+// backoff returns the wait before the retry following the attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	return p.backoffFrom(p.Backoff, attempt)
+}
+
+// backoffFrom doubles d for each attempt after the first, up to MaxBackoff.
+func (p *RetryPolicy) backoffFrom(d time.Duration, attempt int) time.Duration {
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
+
+// WithHeader adds a header to the request.
+func WithHeader(name, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(name, value)
+	}
+}
+
+// WithTimeout limits how long the call may take, including reading the response.
+func WithTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetryPolicy retries the call according to the policy instead of the client's.
+// A MaxAttempts of 1 turns off retries for the call.
+func WithRetryPolicy(policy RetryPolicy) CallOption {
+	return func(o *callOptions) {
+		o.retry = &policy
+	}
+}
+
+// WithAdminMode makes the call in admin mode, giving access to content shared with
+// administrators rather than only the caller's own content.
+func WithAdminMode() CallOption {
+	return func(o *callOptions) {
+		o.adminMode = true
+	}
+}
+
+// WithMaxResponseBytes overrides Client.MaxResponseBytes for the call. A negative limit
+// removes the client's limit.
+func WithMaxResponseBytes(limit int64) CallOption {
+	return func(o *callOptions) {
+		o.maxResponseBytes = limit
+	}
+}
+
+// WithContext makes the call with ctx. When ctx is canceled or its deadline passes, the
+// in-flight request is abandoned and the call returns the context's error; calls that
+// poll, such as searches run to completion, stop waiting too.
+func WithContext(ctx context.Context) CallOption {
+	return func(o *callOptions) {
+		o.ctx = ctx
+	}
+}
+
+// withLimiter paces every request of the call, including retries, with the limiter.
+func withLimiter(l *rateLimiter) CallOption {
+	return func(o *callOptions) {
+		o.limiter = l
+	}
+}
+
+type callOptionsKey struct{}
+
+// withCallOptions applies the options to the request: headers are set right away and the
+// rest is stored in the request's context for send.
+func withCallOptions(req *http.Request, opts []CallOption) *http.Request {
+	if len(opts) == 0 {
+		return req
+	}
+	o := collectCallOptions(opts)
+	for name, values := range o.header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	if o.adminMode {
+		req.Header.Set("isAdminMode", "true")
+	}
+	ctx := req.Context()
+	if o.ctx != nil {
+		ctx = o.ctx
+	}
+	return req.WithContext(context.WithValue(ctx, callOptionsKey{}, o))
+}
+
+// sleepCallOptions waits for d, returning early with the context's error if the call's
+// context is done first.
+func sleepCallOptions(d time.Duration, opts []CallOption) error {
+	ctx := collectCallOptions(opts).ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return sleepContext(ctx, d)
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func collectCallOptions(opts []CallOption) *callOptions {
+	o := new(callOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// requestCallOptions returns the options stored by withCallOptions, or nil.
+func requestCallOptions(req *http.Request) *callOptions {
+	o, _ := req.Context().Value(callOptionsKey{}).(*callOptions)
+	return o
+}
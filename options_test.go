@@ -0,0 +1,243 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCallOptionsHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Header.Get("X-Request-Source") != "reconciler" {
+			t.Errorf("Expected X-Request-Source ‘reconciler’, got ‘%s’", r.Header.Get("X-Request-Source"))
+		}
+		if r.Header.Get("isAdminMode") != "true" {
+			t.Errorf("Expected isAdminMode ‘true’, got ‘%s’", r.Header.Get("isAdminMode"))
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	err = c.DeleteHostedCollector(defaultCollector.ID, WithHeader("X-Request-Source", "reconciler"), WithAdminMode())
+	if err != nil {
+		t.Errorf("DeleteHostedCollector() returned an error: %s", err)
+	}
+}
+
+func TestCallOptionsTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	if err := c.DeleteHostedCollector(defaultCollector.ID, WithTimeout(20*time.Millisecond)); err == nil {
+		t.Errorf("DeleteHostedCollector() did not time out")
+	}
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err != nil {
+		t.Errorf("DeleteHostedCollector() returned an error without a timeout: %s", err)
+	}
+}
+
+func TestCallOptionsRetryPolicy(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"collector": {"id": 1, "name": "test"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	collector, err := c.CreateHostedCollector(Collector{Name: "test"}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+	if err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+		return
+	}
+	if attempts != 3 || collector.ID != 1 {
+		t.Errorf("Expected 3 attempts and collector 1, got %d attempts and %v", attempts, collector)
+	}
+
+	attempts = 0
+	if _, err := c.CreateHostedCollector(Collector{Name: "test"}); err == nil || attempts != 1 {
+		t.Errorf("Expected a single failed attempt without a retry policy, got %d", attempts)
+	}
+}
+
+func TestCallOptionsContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+		case r.URL.EscapedPath() == "/search/jobs/blocked":
+			// Hold the request until the client gives up on it.
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"state": "GATHERING RESULTS"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.DeleteHostedCollector(defaultCollector.ID, WithContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected DeleteHostedCollector() to fail with a canceled context, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.searchMessages(StartSearchRequest{Query: "error"}, 10*time.Millisecond, WithContext(ctx))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected polling to stop at the context's deadline, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetSearchJobStatus("blocked", nil, WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Errorf("Expected a request in flight at the deadline to return the context's error, got %v", err)
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	attempts := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts[r.Method]++
+		if attempts[r.Method] < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.RetryPolicy.Backoff = time.Millisecond
+
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err != nil || attempts["DELETE"] != 3 {
+		t.Errorf("Expected DeleteHostedCollector() to succeed on the third attempt, got %d attempts and %v", attempts["DELETE"], err)
+	}
+	if _, err := c.CreateHostedCollector(Collector{Name: "test"}); err == nil || attempts["POST"] != 1 {
+		t.Errorf("Expected CreateHostedCollector() not to be retried, got %d attempts", attempts["POST"])
+	}
+
+	attempts = map[string]int{}
+	c, _ = NewClient("accessToken", ts.URL, WithoutRetries())
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err == nil || attempts["DELETE"] != 1 {
+		t.Errorf("Expected a single attempt without retries, got %d", attempts["DELETE"])
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if d := p.backoff(attempt); d != expected {
+			t.Errorf("Expected backoff %v after attempt %d, got %v", expected, attempt, d)
+		}
+	}
+}
+
+func TestRetryPolicyWaitByFailureClass(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second, MaxBackoff: 30 * time.Second, RateLimitBackoff: 5 * time.Second, FastRetries: 2}
+	tests := []struct {
+		class    failureClass
+		n        int
+		expected time.Duration
+	}{
+		{serverFailure, 1, time.Second},
+		{serverFailure, 3, 4 * time.Second},
+		{rateLimitFailure, 1, 5 * time.Second},
+		{rateLimitFailure, 2, 10 * time.Second},
+		{rateLimitFailure, 4, 30 * time.Second},
+		{connectionFailure, 1, 0},
+		{connectionFailure, 2, 0},
+		{connectionFailure, 3, time.Second},
+		{connectionFailure, 4, 2 * time.Second},
+	}
+	for _, test := range tests {
+		if d := p.wait(test.class, test.n); d != test.expected {
+			t.Errorf("Expected wait %v for failure %d of class %d, got %v", test.expected, test.n, test.class, d)
+		}
+	}
+
+	p.RateLimitBackoff = 0
+	if d := p.wait(rateLimitFailure, 1); d != time.Second {
+		t.Errorf("Expected 429s to back off from Backoff without RateLimitBackoff, got %v", d)
+	}
+}
+
+func TestEndpointGroup(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/api/v1/search/jobs":  "search",
+		"/api/v1/collectors/1": "collectors",
+		"/api/v2/connections":  "connections",
+		"/collectors":          "collectors",
+		"/":                    "",
+	} {
+		if group := endpointGroup(path); group != expected {
+			t.Errorf("Expected endpoint group %q for %s, got %q", expected, path, group)
+		}
+	}
+}
+
+func TestEndpointRetryPolicies(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	c.RetryPolicy.Backoff = time.Millisecond
+	c.EndpointRetryPolicies = map[string]*RetryPolicy{"collectors": nil}
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err == nil || attempts != 1 {
+		t.Errorf("Expected the collectors group not to be retried, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	c.EndpointRetryPolicies["collectors"] = &RetryPolicy{MaxAttempts: 5, Backoff: time.Millisecond}
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err == nil || attempts != 5 {
+		t.Errorf("Expected the collectors group policy to make 5 attempts, got %d", attempts)
+	}
+
+	attempts = 0
+	if err := c.DeleteHostedCollector(defaultCollector.ID, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond})); err == nil || attempts != 2 {
+		t.Errorf("Expected the call's retry policy to win over the group policy, got %d attempts", attempts)
+	}
+}
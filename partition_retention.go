@@ -0,0 +1,107 @@
+package sumologic
+
+import "sort"
+
+// RetentionChange is a planned change to the retention period of one partition.
+type RetentionChange struct {
+	Partition        Partition
+	CurrentRetention int
+	DesiredRetention int
+
+	// DailyBytes is the partition's estimated ingest per day.
+	DailyBytes int64
+
+	// StorageDeltaBytes estimates how much stored data the change adds (or removes,
+	// when negative) once the new retention period is fully in effect.
+	StorageDeltaBytes int64
+}
+
+// RetentionPlan is the difference between the desired and live partition retention.
+type RetentionPlan struct {
+	Changes []RetentionChange
+
+	// Missing lists desired partition names that don't exist.
+	Missing []string
+}
+
+// StorageDeltaBytes is the combined storage impact of every change in the plan.
+func (p *RetentionPlan) StorageDeltaBytes() int64 {
+	var total int64
+	for _, change := range p.Changes {
+		total += change.StorageDeltaBytes
+	}
+	return total
+}
+
+// PlanPartitionRetention compares the desired retention in days, keyed by partition name,
+// with the live partitions and returns the changes needed.
+// dailyBytes optionally provides the ingest volume per day for each partition name;
+// partitions without an entry are estimated from their stored bytes and current retention.
+func (c *Client) PlanPartitionRetention(desired map[string]int, dailyBytes map[string]int64, opts ...CallOption) (*RetentionPlan, error) {
+	partitions, err := c.ListAllPartitions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return planPartitionRetention(partitions, desired, dailyBytes), nil
+}
+
+func planPartitionRetention(partitions []Partition, desired map[string]int, dailyBytes map[string]int64) *RetentionPlan {
+	plan := new(RetentionPlan)
+	found := make(map[string]bool)
+
+	for _, p := range partitions {
+		retention, ok := desired[p.Name]
+		if !ok {
+			continue
+		}
+		found[p.Name] = true
+		if retention == p.RetentionPeriod {
+			continue
+		}
+
+		daily, ok := dailyBytes[p.Name]
+		if !ok && p.RetentionPeriod > 0 {
+			daily = p.TotalBytes / int64(p.RetentionPeriod)
+		}
+		plan.Changes = append(plan.Changes, RetentionChange{
+			Partition:         p,
+			CurrentRetention:  p.RetentionPeriod,
+			DesiredRetention:  retention,
+			DailyBytes:        daily,
+			StorageDeltaBytes: daily * int64(retention-p.RetentionPeriod),
+		})
+	}
+
+	for name := range desired {
+		if !found[name] {
+			plan.Missing = append(plan.Missing, name)
+		}
+	}
+	sort.Strings(plan.Missing)
+	return plan
+}
+
+// ApplyPartitionRetention applies the changes in a plan. confirm is called before each
+// change and the change is skipped when it returns false; a nil confirm applies everything.
+// reduceImmediately deletes data outside a shortened retention period right away instead of
+// after the grace period. The changes that were applied are returned, including when an
+// update fails part way through.
+func (c *Client) ApplyPartitionRetention(plan *RetentionPlan, confirm func(RetentionChange) bool, reduceImmediately bool, opts ...CallOption) ([]RetentionChange, error) {
+	var applied []RetentionChange
+	for _, change := range plan.Changes {
+		if confirm != nil && !confirm(change) {
+			continue
+		}
+		_, err := c.UpdatePartition(change.Partition.ID, UpdatePartitionRequest{
+			RetentionPeriod:                  change.DesiredRetention,
+			ReduceRetentionPeriodImmediately: reduceImmediately,
+			IsCompliant:                      change.Partition.IsCompliant,
+			RoutingExpression:                change.Partition.RoutingExpression,
+		}, opts...)
+		if err != nil {
+			return applied, err
+		}
+		applied = append(applied, change)
+	}
+	return applied, nil
+}
@@ -0,0 +1,76 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPlanPartitionRetention(t *testing.T) {
+	partitions := []Partition{
+		{ID: "1", Name: "app", RetentionPeriod: 30, TotalBytes: 3000},
+		{ID: "2", Name: "audit", RetentionPeriod: 365},
+		{ID: "3", Name: "debug", RetentionPeriod: 30, TotalBytes: 3000},
+	}
+	desired := map[string]int{"app": 90, "audit": 365, "debug": 7, "missing": 30}
+
+	plan := planPartitionRetention(partitions, desired, map[string]int64{"debug": 1000})
+	if len(plan.Changes) != 2 {
+		t.Errorf("Expected 2 changes, got %v", plan.Changes)
+		return
+	}
+	if plan.Changes[0].Partition.Name != "app" || plan.Changes[0].StorageDeltaBytes != 6000 {
+		t.Errorf("Expected app to grow by 6000 bytes, got %v", plan.Changes[0])
+	}
+	if plan.Changes[1].Partition.Name != "debug" || plan.Changes[1].StorageDeltaBytes != -23000 {
+		t.Errorf("Expected debug to shrink by 23000 bytes, got %v", plan.Changes[1])
+	}
+	if plan.StorageDeltaBytes() != -17000 {
+		t.Errorf("Expected a total delta of -17000 bytes, got %d", plan.StorageDeltaBytes())
+	}
+	if len(plan.Missing) != 1 || plan.Missing[0] != "missing" {
+		t.Errorf("Expected ‘missing’ to be reported, got %v", plan.Missing)
+	}
+}
+
+func TestApplyPartitionRetentionConfirm(t *testing.T) {
+	var updated []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method != "PUT" {
+			t.Errorf("Expected ‘PUT’ request, got ‘%s’", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		upr := new(UpdatePartitionRequest)
+		json.Unmarshal(body, upr)
+		if upr.RetentionPeriod != 90 {
+			t.Errorf("Expected retention of 90 days, got %d", upr.RetentionPeriod)
+		}
+		updated = append(updated, r.URL.EscapedPath())
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	plan := &RetentionPlan{Changes: []RetentionChange{
+		{Partition: Partition{ID: "1", Name: "app"}, DesiredRetention: 90},
+		{Partition: Partition{ID: "2", Name: "audit"}, DesiredRetention: 90},
+	}}
+	applied, err := c.ApplyPartitionRetention(plan, func(change RetentionChange) bool {
+		return change.Partition.Name != "audit"
+	}, false)
+	if err != nil {
+		t.Errorf("ApplyPartitionRetention() returned an error: %s", err)
+		return
+	}
+	if len(applied) != 1 || len(updated) != 1 || updated[0] != "/partitions/1" {
+		t.Errorf("Expected only partition 1 to be updated, got %v", updated)
+	}
+}
@@ -0,0 +1,180 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Partition is an index that stores the messages matching its routing expression.
+type Partition struct {
+	ID                string `json:"id,omitempty"`
+	Name              string `json:"name"`
+	RoutingExpression string `json:"routingExpression"`
+	AnalyticsTier     string `json:"analyticsTier,omitempty"`
+	RetentionPeriod   int    `json:"retentionPeriod,omitempty"`
+	IsCompliant       bool   `json:"isCompliant,omitempty"`
+	DataForwardingID  string `json:"dataForwardingId,omitempty"`
+	IsActive          bool   `json:"isActive,omitempty"`
+	TotalBytes        int64  `json:"totalBytes,omitempty"`
+	IndexType         string `json:"indexType,omitempty"`
+	CreatedAt         string `json:"createdAt,omitempty"`
+	CreatedBy         string `json:"createdBy,omitempty"`
+	ModifiedAt        string `json:"modifiedAt,omitempty"`
+	ModifiedBy        string `json:"modifiedBy,omitempty"`
+}
+
+// PartitionList is one page of partitions. Next is the token for the following page
+// and is empty on the last page.
+type PartitionList struct {
+	Data []Partition `json:"data"`
+	Next string      `json:"next,omitempty"`
+}
+
+// CreatePartitionRequest creates a partition storing the messages matching
+// RoutingExpression for RetentionPeriod days, the organization's default when zero.
+type CreatePartitionRequest struct {
+	Name              string `json:"name"`
+	RoutingExpression string `json:"routingExpression"`
+	AnalyticsTier     string `json:"analyticsTier,omitempty"`
+	RetentionPeriod   int    `json:"retentionPeriod,omitempty"`
+	IsCompliant       bool   `json:"isCompliant"`
+}
+
+// UpdatePartitionRequest holds the mutable settings of a partition.
+type UpdatePartitionRequest struct {
+	RetentionPeriod                  int    `json:"retentionPeriod,omitempty"`
+	ReduceRetentionPeriodImmediately bool   `json:"reduceRetentionPeriodImmediately"`
+	IsCompliant                      bool   `json:"isCompliant"`
+	RoutingExpression                string `json:"routingExpression,omitempty"`
+}
+
+// ErrPartitionNotFound is returned when a partition doesn't exist.
+var ErrPartitionNotFound = errors.New("Partition not found")
+
+// ListPartitions returns one page of partitions. A limit of 0 uses the API default.
+func (c *Client) ListPartitions(limit int, token string, opts ...CallOption) (*PartitionList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "partitions"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var pl = new(PartitionList)
+	if err := c.do(call{method: "GET", path: path, out: pl}, opts); err != nil {
+		return nil, err
+	}
+	return pl, nil
+}
+
+// ListAllPartitions follows the pagination tokens and returns every partition.
+func (c *Client) ListAllPartitions(opts ...CallOption) ([]Partition, error) {
+	var partitions []Partition
+	token := ""
+	for {
+		pl, err := c.ListPartitions(0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, pl.Data...)
+		if pl.Next == "" {
+			return partitions, nil
+		}
+		token = pl.Next
+	}
+}
+
+// GetPartition gets the partition with the specified ID.
+func (c *Client) GetPartition(id string, opts ...CallOption) (*Partition, error) {
+	var p = new(Partition)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("partitions/%s", id),
+		out:      p,
+		notFound: ErrPartitionNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreatePartition creates a partition.
+func (c *Client) CreatePartition(cpr CreatePartitionRequest, opts ...CallOption) (*Partition, error) {
+	var p = new(Partition)
+	err := c.do(call{
+		method:     "POST",
+		path:       "partitions",
+		in:         cpr,
+		out:        p,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if a partition with this name `%s` already exists and its routing expression is valid", cpr.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DecommissionPartition stops routing messages to the partition with the specified ID.
+// Its messages stay searchable until their retention period ends. Partitions can't be
+// deleted.
+func (c *Client) DecommissionPartition(id string, opts ...CallOption) error {
+	return c.do(call{method: "POST", path: fmt.Sprintf("partitions/%s/decommission", id), notFound: ErrPartitionNotFound}, opts)
+}
+
+// UpdatePartition updates the partition with the specified ID.
+func (c *Client) UpdatePartition(id string, upr UpdatePartitionRequest, opts ...CallOption) (*Partition, error) {
+	var p = new(Partition)
+	err := c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("partitions/%s", id),
+		in:         upr,
+		out:        p,
+		notFound:   ErrPartitionNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for partition `%s`", id),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Partitions returns a ResourceClient for partitions. Deleting a partition through it
+// decommissions it.
+func (c *Client) Partitions(opts ...CallOption) ResourceClient[Partition] {
+	return &resourceClient[Partition]{
+		list: func() ([]Partition, error) {
+			return c.ListAllPartitions(opts...)
+		},
+		get: func(id string) (*Partition, error) {
+			return c.GetPartition(id, opts...)
+		},
+		create: func(p Partition) (*Partition, error) {
+			return c.CreatePartition(CreatePartitionRequest{
+				Name:              p.Name,
+				RoutingExpression: p.RoutingExpression,
+				AnalyticsTier:     p.AnalyticsTier,
+				RetentionPeriod:   p.RetentionPeriod,
+				IsCompliant:       p.IsCompliant,
+			}, opts...)
+		},
+		update: func(p Partition) (*Partition, error) {
+			return c.UpdatePartition(p.ID, UpdatePartitionRequest{
+				RetentionPeriod:   p.RetentionPeriod,
+				IsCompliant:       p.IsCompliant,
+				RoutingExpression: p.RoutingExpression,
+			}, opts...)
+		},
+		delete: func(id string) error {
+			return c.DecommissionPartition(id, opts...)
+		},
+	}
+}
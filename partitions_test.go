@@ -0,0 +1,118 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListAllPartitionsFollowsToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.EscapedPath() != "/partitions" {
+			t.Errorf("Expected request to ‘/partitions’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var pl PartitionList
+		switch r.URL.Query().Get("token") {
+		case "":
+			pl = PartitionList{Data: []Partition{{ID: "1", Name: "one"}}, Next: "page2"}
+		case "page2":
+			pl = PartitionList{Data: []Partition{{ID: "2", Name: "two"}}}
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		body, _ := json.Marshal(pl)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	partitions, err := c.ListAllPartitions()
+	if err != nil {
+		t.Errorf("ListAllPartitions() returned an error: %s", err)
+		return
+	}
+	if len(partitions) != 2 {
+		t.Errorf("ListAllPartitions() expected 2 partitions, got %d", len(partitions))
+	}
+}
+
+func TestUpdatePartitionDoesntExist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method != "PUT" {
+			t.Errorf("Expected ‘PUT’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/partitions/abc" {
+			t.Errorf("Expected request to ‘/partitions/abc’, got ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.UpdatePartition("abc", UpdatePartitionRequest{RetentionPeriod: 30})
+	if err != ErrPartitionNotFound {
+		t.Errorf("UpdatePartition() returned the wrong error: %s", err)
+	}
+}
+
+func TestCreatePartition(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var cpr CreatePartitionRequest
+		if err := json.Unmarshal(body, &cpr); err != nil {
+			t.Errorf("Unable to unmarshal CreatePartitionRequest, got `%s`", body)
+		}
+		json.NewEncoder(w).Encode(Partition{
+			ID:                "p1",
+			Name:              cpr.Name,
+			RoutingExpression: cpr.RoutingExpression,
+			RetentionPeriod:   cpr.RetentionPeriod,
+			IsActive:          true,
+		})
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	p, err := c.CreatePartition(CreatePartitionRequest{Name: "prod_app", RoutingExpression: "_sourceCategory=prod/app", RetentionPeriod: 30})
+	if err != nil {
+		t.Errorf("CreatePartition() returned an error: %s", err)
+		return
+	}
+	if p.ID != "p1" || p.RetentionPeriod != 30 {
+		t.Errorf("Expected the created partition, got %+v", p)
+	}
+}
+
+func TestDecommissionPartition(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/partitions/p1/decommission" {
+			t.Errorf("Expected request to ‘/partitions/p1/decommission’, got ‘%s’", r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.Partitions().Delete("p1"); err != nil {
+		t.Errorf("Delete() returned an error: %s", err)
+	}
+}
@@ -0,0 +1,38 @@
+package sumologic
+
+// PasswordPolicy is the organization's policy for the passwords of its users.
+type PasswordPolicy struct {
+	MinLength                      int  `json:"minLength,omitempty"`
+	MaxLength                      int  `json:"maxLength,omitempty"`
+	MustContainLowercase           bool `json:"mustContainLowercase"`
+	MustContainUppercase           bool `json:"mustContainUppercase"`
+	MustContainDigits              bool `json:"mustContainDigits"`
+	MustContainSpecialChars        bool `json:"mustContainSpecialChars"`
+	MaxPasswordAgeInDays           int  `json:"maxPasswordAgeInDays,omitempty"`
+	MinUniquePasswords             int  `json:"minUniquePasswords,omitempty"`
+	AccountLockoutThreshold        int  `json:"accountLockoutThreshold,omitempty"`
+	FailedLoginResetDurationInMins int  `json:"failedLoginResetDurationInMins,omitempty"`
+	AccountLockoutDurationInMins   int  `json:"accountLockoutDurationInMins,omitempty"`
+	RequireMfa                     bool `json:"requireMfa"`
+	RememberMfa                    bool `json:"rememberMfa"`
+}
+
+// GetPasswordPolicy gets the organization's password policy.
+func (c *Client) GetPasswordPolicy(opts ...CallOption) (*PasswordPolicy, error) {
+	var pp = new(PasswordPolicy)
+	if err := c.do(call{method: "GET", path: "passwordPolicy", out: pp}, opts); err != nil {
+		return nil, err
+	}
+	return pp, nil
+}
+
+// UpdatePasswordPolicy replaces the organization's password policy. Fields left at zero
+// are reset to the API defaults, so get the policy and change it rather than building
+// a new one.
+func (c *Client) UpdatePasswordPolicy(pp PasswordPolicy, opts ...CallOption) (*PasswordPolicy, error) {
+	var updated = new(PasswordPolicy)
+	if err := c.do(call{method: "PUT", path: "passwordPolicy", in: pp, out: updated}, opts); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,46 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdatePasswordPolicy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/passwordPolicy" {
+			t.Errorf("Expected request to ‘/passwordPolicy’, got ‘%s’", r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"minLength": 8, "maxLength": 128, "mustContainDigits": true, "requireMfa": false}`))
+		case "PUT":
+			var pp PasswordPolicy
+			json.NewDecoder(r.Body).Decode(&pp)
+			if !pp.RequireMfa || pp.MinLength != 8 || !pp.MustContainDigits {
+				t.Errorf("Expected the fetched policy with MFA required, got %+v", pp)
+			}
+			json.NewEncoder(w).Encode(pp)
+		default:
+			t.Errorf("Unexpected ‘%s’ request", r.Method)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	pp, err := c.GetPasswordPolicy()
+	if err != nil {
+		t.Errorf("GetPasswordPolicy() returned an error: %s", err)
+		return
+	}
+	pp.RequireMfa = true
+	updated, err := c.UpdatePasswordPolicy(*pp)
+	if err != nil {
+		t.Errorf("UpdatePasswordPolicy() returned an error: %s", err)
+		return
+	}
+	if !updated.RequireMfa {
+		t.Errorf("Expected the updated policy to require MFA")
+	}
+}
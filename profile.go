@@ -0,0 +1,37 @@
+package sumologic
+
+import (
+	"context"
+	"net/http"
+	"runtime/pprof"
+)
+
+// Profiler labels set on the goroutine while a client with ProfileLabels fetches or
+// decodes a response, so CPU profiles of an application can attribute the SDK's share.
+const (
+	// ProfileLabelPhase is "fetch" while a request is sent and its response read, and
+	// "decode" while the response is decoded.
+	ProfileLabelPhase = "sumologic.phase"
+	// ProfileLabelEndpoint is the endpoint group of the request, e.g. "search".
+	ProfileLabelEndpoint = "sumologic.endpoint"
+)
+
+// WithProfileLabels makes the client set the ProfileLabelPhase and ProfileLabelEndpoint
+// pprof labels around fetching and decoding responses, in addition to the labels of the
+// call's context.
+func WithProfileLabels() ClientOption {
+	return func(c *Client) {
+		c.ProfileLabels = true
+	}
+}
+
+// profile runs f with the profiler labels for the phase of the request when the client
+// sets them. f is passed the request's context, carrying the labels.
+func (c *Client) profile(req *http.Request, phase string, f func(ctx context.Context)) {
+	if !c.ProfileLabels {
+		f(req.Context())
+		return
+	}
+	labels := pprof.Labels(ProfileLabelPhase, phase, ProfileLabelEndpoint, endpointGroup(req.URL.Path))
+	pprof.Do(req.Context(), labels, f)
+}
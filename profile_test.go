@@ -0,0 +1,139 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime/pprof"
+	"testing"
+)
+
+type labelRecordingTransport struct {
+	phases    []string
+	endpoints []string
+}
+
+func (t *labelRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	phase, _ := pprof.Label(req.Context(), ProfileLabelPhase)
+	endpoint, _ := pprof.Label(req.Context(), ProfileLabelEndpoint)
+	t.phases = append(t.phases, phase)
+	t.endpoints = append(t.endpoints, endpoint)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestProfileLabels(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"state": "DONE GATHERING RESULTS", "messageCount": 1}`))
+	}))
+	defer ts.Close()
+
+	transport := &labelRecordingTransport{}
+	c, _ := NewClient("accessToken", ts.URL, WithProfileLabels(), WithHTTPClient(&http.Client{Transport: transport}))
+	if _, err := c.GetSearchJobStatus("123", nil); err != nil {
+		t.Errorf("GetSearchJobStatus() returned an error: %s", err)
+		return
+	}
+	if len(transport.phases) != 1 || transport.phases[0] != "fetch" || transport.endpoints[0] != "search" {
+		t.Errorf("Expected the fetch of the search endpoint to be labelled, got phases %v and endpoints %v", transport.phases, transport.endpoints)
+	}
+
+	transport.phases, transport.endpoints = nil, nil
+	c, _ = NewClient("accessToken", ts.URL, WithHTTPClient(&http.Client{Transport: transport}))
+	if _, err := c.GetSearchJobStatus("123", nil); err != nil {
+		t.Errorf("GetSearchJobStatus() returned an error: %s", err)
+		return
+	}
+	if len(transport.phases) != 1 || transport.phases[0] != "" {
+		t.Errorf("Expected no labels without WithProfileLabels, got %v", transport.phases)
+	}
+}
+
+// typedMessage is the shape an application decodes messages into when it knows their fields.
+type typedMessage struct {
+	MessageTime    string `json:"_messagetime"`
+	SourceCategory string `json:"_sourcecategory"`
+	Raw            string `json:"_raw"`
+	Status         string `json:"status"`
+	Latency        string `json:"latency"`
+}
+
+type typedResult[T any] struct {
+	Fields   []*SearchJobResultField `json:"fields"`
+	Messages []struct {
+		Map T `json:"map"`
+	} `json:"messages"`
+}
+
+type rawResult struct {
+	Fields   []*SearchJobResultField `json:"fields"`
+	Messages []struct {
+		Map json.RawMessage `json:"map"`
+	} `json:"messages"`
+}
+
+func benchmarkMessagesPayload(n int) []byte {
+	result := SearchJobResult{Fields: []*SearchJobResultField{
+		{Name: "_messagetime", FieldType: "long"},
+		{Name: "_sourcecategory", FieldType: "string"},
+		{Name: "_raw", FieldType: "string"},
+		{Name: "status", FieldType: "int"},
+		{Name: "latency", FieldType: "double"},
+	}}
+	for i := 0; i < n; i++ {
+		result.Messages = append(result.Messages, &SearchJobResultMessage{Map: map[string]interface{}{
+			"_messagetime":    fmt.Sprint(1488326400000 + i),
+			"_sourcecategory": "prod/checkout/app",
+			"_raw":            fmt.Sprintf(`2017-03-01 00:00:00,000 INFO [request=%d] GET /api/cart status=200 latency=0.%03d`, i, i%1000),
+			"status":          "200",
+			"latency":         fmt.Sprintf("0.%03d", i%1000),
+		}})
+	}
+	body, _ := json.Marshal(result)
+	return body
+}
+
+func benchmarkDecode(b *testing.B, decode func([]byte) error) {
+	for _, n := range []int{100, 1000, 10000} {
+		body := benchmarkMessagesPayload(n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := decode(body); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeMessagesMap(b *testing.B) {
+	benchmarkDecode(b, func(body []byte) error {
+		var result SearchJobResult
+		return json.Unmarshal(body, &result)
+	})
+}
+
+func BenchmarkDecodeMessagesRawMessage(b *testing.B) {
+	benchmarkDecode(b, func(body []byte) error {
+		var result rawResult
+		return json.Unmarshal(body, &result)
+	})
+}
+
+func BenchmarkDecodeMessagesTyped(b *testing.B) {
+	benchmarkDecode(b, func(body []byte) error {
+		var result typedResult[typedMessage]
+		return json.Unmarshal(body, &result)
+	})
+}
+
+func BenchmarkDecodeMessagesProfileLabels(b *testing.B) {
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/", WithProfileLabels())
+	req, _ := http.NewRequest("GET", "https://api.sumologic.com/api/v1/search/jobs/1/messages", nil)
+	benchmarkDecode(b, func(body []byte) error {
+		var result SearchJobResult
+		return c.decodeJSON(req, body, &result)
+	})
+}
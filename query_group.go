@@ -0,0 +1,123 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// QueryGroup runs several queries at once and collects their results by name.
+// The queries share a concurrency limit, an optional request rate limit and a context:
+// the first query to fail cancels the others.
+type QueryGroup struct {
+	client *Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   []CallOption
+
+	pollInterval time.Duration
+
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	mu      sync.Mutex
+	results map[string]interface{}
+	err     error
+}
+
+// QueryGroupOptions configures a QueryGroup.
+type QueryGroupOptions struct {
+	// Concurrency is the number of queries run at once, 4 by default.
+	Concurrency int
+	// RequestsPerMinute, when set, limits the API requests made by all the queries together.
+	RequestsPerMinute int
+	// PollInterval is the delay between search status checks, 5 seconds by default.
+	PollInterval time.Duration
+}
+
+// NewQueryGroup returns a QueryGroup whose queries run with ctx and the call options.
+func (c *Client) NewQueryGroup(ctx context.Context, qo QueryGroupOptions, opts ...CallOption) *QueryGroup {
+	concurrency := qo.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	g := &QueryGroup{
+		client:  c,
+		ctx:     ctx,
+		cancel:  cancel,
+		slots:   make(chan struct{}, concurrency),
+		results: make(map[string]interface{}),
+	}
+	g.opts = append(g.opts, opts...)
+	g.opts = append(g.opts, WithContext(ctx))
+	if qo.RequestsPerMinute > 0 {
+		g.opts = append(g.opts, withLimiter(newRateLimiter(qo.RequestsPerMinute, 1)))
+	}
+	g.pollInterval = qo.PollInterval
+	if g.pollInterval <= 0 {
+		g.pollInterval = 5 * time.Second
+	}
+	return g
+}
+
+// Search adds a log search to the group. Its result is a []*SearchJobResultMessage.
+func (g *QueryGroup) Search(name string, ssr StartSearchRequest) {
+	g.Go(name, func(ctx context.Context, opts ...CallOption) (interface{}, error) {
+		return g.client.searchMessages(ssr, g.pollInterval, opts...)
+	})
+}
+
+// Go adds a query run by fn to the group, e.g. a metrics query. fn should pass the
+// call options it's given to every API call so they share the group's limits and context.
+func (g *QueryGroup) Go(name string, fn func(ctx context.Context, opts ...CallOption) (interface{}, error)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		select {
+		case g.slots <- struct{}{}:
+		case <-g.ctx.Done():
+			g.fail(name, g.ctx.Err())
+			return
+		}
+		defer func() { <-g.slots }()
+
+		result, err := fn(g.ctx, g.opts...)
+		if err != nil {
+			g.fail(name, err)
+			return
+		}
+		g.mu.Lock()
+		g.results[name] = result
+		g.mu.Unlock()
+	}()
+}
+
+func (g *QueryGroup) fail(name string, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.err == nil {
+		g.err = fmt.Errorf("query %s: %v", name, err)
+		g.cancel()
+	}
+}
+
+// Wait waits for every query and returns their results by name, or the first error.
+func (g *QueryGroup) Wait() (QueryResults, error) {
+	g.wg.Wait()
+	g.cancel()
+	if g.err != nil {
+		return nil, g.err
+	}
+	return g.results, nil
+}
+
+// QueryResults holds the results of a QueryGroup by query name.
+type QueryResults map[string]interface{}
+
+// Messages returns the messages found by the named search.
+func (r QueryResults) Messages(name string) []*SearchJobResultMessage {
+	messages, _ := r[name].([]*SearchJobResultMessage)
+	return messages
+}
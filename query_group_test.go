@@ -0,0 +1,88 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryGroup(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.EscapedPath()
+		switch {
+		case r.Method == "POST" && path == "/search/jobs":
+			body, _ := ioutil.ReadAll(r.Body)
+			var ssr StartSearchRequest
+			json.Unmarshal(body, &ssr)
+			w.WriteHeader(http.StatusAccepted)
+			body, _ = json.Marshal(SearchJob{ID: ssr.Query})
+			w.Write(body)
+		case strings.HasSuffix(path, "/messages"):
+			w.WriteHeader(http.StatusOK)
+			messages := []*SearchJobResultMessage{{Map: map[string]interface{}{"query": strings.Split(path, "/")[3]}}}
+			body, _ := json.Marshal(SearchJobResult{Messages: messages})
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusOK)
+			body, _ := json.Marshal(SearchJobStatusResponse{State: "DONE GATHERING RESULTS", MessageCount: 1})
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned error: %v", err)
+		return
+	}
+	g := c.NewQueryGroup(context.Background(), QueryGroupOptions{Concurrency: 2, PollInterval: time.Millisecond})
+	g.Search("errors", StartSearchRequest{Query: "errors"})
+	g.Search("warnings", StartSearchRequest{Query: "warnings"})
+	g.Go("metrics", func(ctx context.Context, opts ...CallOption) (interface{}, error) {
+		return 42, nil
+	})
+	results, err := g.Wait()
+	if err != nil {
+		t.Errorf("Wait() returned error: %v", err)
+		return
+	}
+	for _, name := range []string{"errors", "warnings"} {
+		messages := results.Messages(name)
+		if len(messages) != 1 || messages[0].Map["query"] != name {
+			t.Errorf("Messages(%q) returned %v", name, messages)
+		}
+	}
+	if results["metrics"] != 42 {
+		t.Errorf("Expected metrics result 42, got %v", results["metrics"])
+	}
+}
+
+func TestQueryGroupCancelsOnError(t *testing.T) {
+	c, err := NewClient("accessToken", "http://localhost")
+	if err != nil {
+		t.Errorf("NewClient() returned error: %v", err)
+		return
+	}
+	g := c.NewQueryGroup(context.Background(), QueryGroupOptions{})
+	g.Go("failing", func(ctx context.Context, opts ...CallOption) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	g.Go("slow", func(ctx context.Context, opts ...CallOption) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("not canceled")
+		}
+	})
+	_, err = g.Wait()
+	if err == nil || err.Error() != "query failing: boom" {
+		t.Errorf("Expected the failing query's error, got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// SavedQuery is a named query of a QueryLibrary. Query is a text/template rendered with the
+// parameters of each run, with the same functions as Template, e.g.
+//
+//	_sourceCategory={{.category}} | where status >= {{default 500 .minStatus}}
+type SavedQuery struct {
+	Name        string
+	Version     int
+	Description string
+	Query       string
+	// Parameters are the names of the parameters every run must set, unless Defaults has them.
+	Parameters []string
+	Defaults   TemplateParams
+	// DefaultRange is the window of time up to now a run covers when it isn't given a
+	// time range, 15 minutes when zero. Location is the time zone of the search, UTC when nil.
+	DefaultRange time.Duration
+	Location     *time.Location
+	// Registered is when the version was added to the library.
+	Registered time.Time
+
+	tmpl *template.Template
+}
+
+// ErrSavedQueryNotFound is returned when a library has no query, or no version of it, with
+// the requested name.
+var ErrSavedQueryNotFound = errors.New("Saved query not found")
+
+// QueryLibrary is a registry of named, versioned queries, so an application keeps its
+// queries in one place and runs them by name. Runs use the latest version of a query
+// unless another is requested. It's safe for concurrent use.
+type QueryLibrary struct {
+	mu      sync.RWMutex
+	queries map[string][]*SavedQuery
+}
+
+// NewQueryLibrary returns an empty QueryLibrary.
+func NewQueryLibrary() *QueryLibrary {
+	return &QueryLibrary{queries: make(map[string][]*SavedQuery)}
+}
+
+// Register adds a version of a query. Version 0 registers the version after the latest;
+// any other version must be higher than the latest.
+func (l *QueryLibrary) Register(q SavedQuery) error {
+	if q.Name == "" {
+		return errors.New("Saved query name is required")
+	}
+	tmpl, err := template.New(q.Name).Funcs(templateFuncs).Parse(q.Query)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	versions := l.queries[q.Name]
+	latest := 0
+	if len(versions) > 0 {
+		latest = versions[len(versions)-1].Version
+	}
+	if q.Version == 0 {
+		q.Version = latest + 1
+	}
+	if q.Version <= latest {
+		return fmt.Errorf("saved query `%s` already has version %d", q.Name, latest)
+	}
+	if q.DefaultRange <= 0 {
+		q.DefaultRange = 15 * time.Minute
+	}
+	q.Registered = time.Now()
+	q.tmpl = tmpl
+	l.queries[q.Name] = append(versions, &q)
+	return nil
+}
+
+// Get returns the latest version of the named query.
+func (l *QueryLibrary) Get(name string) (*SavedQuery, error) {
+	return l.GetVersion(name, 0)
+}
+
+// GetVersion returns a version of the named query, the latest when version is 0.
+func (l *QueryLibrary) GetVersion(name string, version int) (*SavedQuery, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	versions := l.queries[name]
+	if len(versions) == 0 {
+		return nil, ErrSavedQueryNotFound
+	}
+	if version == 0 {
+		q := *versions[len(versions)-1]
+		return &q, nil
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			q := *v
+			return &q, nil
+		}
+	}
+	return nil, ErrSavedQueryNotFound
+}
+
+// List returns the latest version of every query, sorted by name.
+func (l *QueryLibrary) List() []SavedQuery {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	queries := make([]SavedQuery, 0, len(l.queries))
+	for _, versions := range l.queries {
+		queries = append(queries, *versions[len(versions)-1])
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Name < queries[j].Name })
+	return queries
+}
+
+// Versions returns every version of the named query, oldest first.
+func (l *QueryLibrary) Versions(name string) []SavedQuery {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var queries []SavedQuery
+	for _, v := range l.queries[name] {
+		queries = append(queries, *v)
+	}
+	return queries
+}
+
+// Render returns the query text of the saved query with the parameters, after filling
+// in its defaults and checking that every required parameter is set.
+func (q *SavedQuery) Render(params TemplateParams) (string, error) {
+	merged := make(map[string]interface{}, len(q.Defaults)+len(params))
+	for k, v := range q.Defaults {
+		merged[k] = v
+	}
+	for k, v := range params {
+		merged[k] = v
+	}
+	var missing []string
+	for _, p := range q.Parameters {
+		if isEmptyParam(merged[p]) {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("saved query `%s` is missing parameters: %s", q.Name, strings.Join(missing, ", "))
+	}
+
+	var sb strings.Builder
+	if err := q.tmpl.Execute(&sb, merged); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// StartSearchRequest returns the request of a search running the saved query with the
+// parameters over its default range.
+func (q *SavedQuery) StartSearchRequest(params TemplateParams) (StartSearchRequest, error) {
+	return q.StartSearchRequestInRange(params, LastTimeRange(q.DefaultRange, q.Location))
+}
+
+// StartSearchRequestInRange returns the request of a search running the saved query with
+// the parameters over the time range.
+func (q *SavedQuery) StartSearchRequestInRange(params TemplateParams, tr TimeRange) (StartSearchRequest, error) {
+	query, err := q.Render(params)
+	if err != nil {
+		return StartSearchRequest{}, err
+	}
+	ssr := StartSearchRequest{Query: query}
+	if err := ssr.SetTimeRange(tr); err != nil {
+		return StartSearchRequest{}, err
+	}
+	return ssr, nil
+}
+
+// Start starts a search running the latest version of the named query with the
+// parameters over its default range.
+func (l *QueryLibrary) Start(c *Client, name string, params TemplateParams, opts ...CallOption) (*SearchJob, []*http.Cookie, error) {
+	q, err := l.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	ssr, err := q.StartSearchRequest(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	sj, err := c.StartSearchJob(ssr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sj, sj.cookies, nil
+}
+
+// StartInRange starts a search running the latest version of the named query with the
+// parameters over the time range.
+func (l *QueryLibrary) StartInRange(c *Client, name string, params TemplateParams, tr TimeRange, opts ...CallOption) (*SearchJob, []*http.Cookie, error) {
+	q, err := l.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	ssr, err := q.StartSearchRequestInRange(params, tr)
+	if err != nil {
+		return nil, nil, err
+	}
+	sj, err := c.StartSearchJob(ssr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sj, sj.cookies, nil
+}
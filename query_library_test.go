@@ -0,0 +1,91 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryLibrary(t *testing.T) {
+	l := NewQueryLibrary()
+	if err := l.Register(SavedQuery{Name: "errors", Query: "_sourceCategory={{.category}} error"}); err != nil {
+		t.Errorf("Register() returned an error: %s", err)
+		return
+	}
+	err := l.Register(SavedQuery{
+		Name:         "errors",
+		Description:  "Server errors",
+		Query:        "_sourceCategory={{.category}} | where status >= {{.minStatus}}",
+		Parameters:   []string{"category"},
+		Defaults:     TemplateParams{"minStatus": 500},
+		DefaultRange: time.Hour,
+	})
+	if err != nil {
+		t.Errorf("Register() returned an error: %s", err)
+		return
+	}
+	if err := l.Register(SavedQuery{Name: "errors", Version: 1, Query: "error"}); err == nil {
+		t.Errorf("Expected an error registering an older version")
+	}
+	l.Register(SavedQuery{Name: "audit", Query: "_index=sumologic_audit"})
+
+	list := l.List()
+	if len(list) != 2 || list[0].Name != "audit" || list[1].Name != "errors" || list[1].Version != 2 {
+		t.Errorf("Expected the latest version of both queries by name, got %+v", list)
+	}
+	if versions := l.Versions("errors"); len(versions) != 2 || versions[0].Version != 1 {
+		t.Errorf("Expected two versions of errors, got %d", len(versions))
+	}
+	if _, err := l.GetVersion("errors", 3); err != ErrSavedQueryNotFound {
+		t.Errorf("Expected ErrSavedQueryNotFound, got %v", err)
+	}
+
+	q, _ := l.Get("errors")
+	if _, err := q.Render(nil); err == nil || !strings.Contains(err.Error(), "category") {
+		t.Errorf("Expected an error for the missing category parameter, got %v", err)
+	}
+	ssr, err := q.StartSearchRequest(TemplateParams{"category": "web"})
+	if err != nil {
+		t.Errorf("StartSearchRequest() returned an error: %s", err)
+		return
+	}
+	if ssr.Query != "_sourceCategory=web | where status >= 500" {
+		t.Errorf("Expected the query rendered with its defaults, got %s", ssr.Query)
+	}
+	from, _ := time.Parse(time.RFC3339, ssr.From)
+	to, _ := time.Parse(time.RFC3339, ssr.To)
+	if to.Sub(from) != time.Hour || ssr.TimeZone != "UTC" {
+		t.Errorf("Expected the default range of an hour in UTC, got %s to %s in %s", ssr.From, ssr.To, ssr.TimeZone)
+	}
+}
+
+func TestQueryLibraryStart(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ssr StartSearchRequest
+		json.NewDecoder(r.Body).Decode(&ssr)
+		if ssr.Query != "_sourceCategory=web error" {
+			t.Errorf("Expected the rendered query, got %s", ssr.Query)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id": "123"}`))
+	}))
+	defer ts.Close()
+
+	l := NewQueryLibrary()
+	l.Register(SavedQuery{Name: "errors", Query: "_sourceCategory={{.category}} error"})
+	c, _ := NewClient("accessToken", ts.URL)
+	sj, _, err := l.Start(c, "errors", TemplateParams{"category": "web"})
+	if err != nil {
+		t.Errorf("Start() returned an error: %s", err)
+		return
+	}
+	if sj.ID != "123" {
+		t.Errorf("Expected search job 123, got %s", sj.ID)
+	}
+	if _, _, err := l.Start(c, "missing", nil); err != ErrSavedQueryNotFound {
+		t.Errorf("Expected ErrSavedQueryNotFound, got %v", err)
+	}
+}
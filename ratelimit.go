@@ -0,0 +1,98 @@
+package sumologic
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SearchJobRateLimit is the number of requests per minute the API allows for each user.
+const SearchJobRateLimit = 240
+
+// DefaultRateLimitBurst is the number of requests NewClient lets through at once before
+// pacing them to SearchJobRateLimit.
+const DefaultRateLimitBurst = 20
+
+// maxThrottledRetries is how many times a call is retried after a 429 response with a
+// Retry-After header, on top of its retry policy.
+const maxThrottledRetries = 3
+
+// WithRateLimit limits the client to requestsPerMinute, letting up to burst requests
+// through at once. Requests over the limit wait their turn. A requestsPerMinute of zero
+// or less removes the limit.
+func WithRateLimit(requestsPerMinute, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = nil
+		if requestsPerMinute > 0 {
+			c.limiter = newRateLimiter(requestsPerMinute, burst)
+		}
+	}
+}
+
+// rateLimiter is a token bucket refilled at a set number of requests per minute.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    int
+	// next is when the bucket would be full again if no more requests were made.
+	next time.Time
+}
+
+func newRateLimiter(requestsPerMinute, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{interval: time.Minute / time.Duration(requestsPerMinute), burst: burst}
+}
+
+// Wait blocks until the next request may be made or the context is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now) - time.Duration(l.burst-1)*l.interval
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	return sleepContext(ctx, wait)
+}
+
+// Pause holds back every request for d, e.g. after the API asks to retry later.
+func (l *rateLimiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Emptying the bucket until then makes the next request wait for d.
+	until := time.Now().Add(d + time.Duration(l.burst-1)*l.interval)
+	if l.next.Before(until) {
+		l.next = until
+	}
+}
+
+// retryAfter returns how long a 429 response asks to wait before retrying.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -0,0 +1,74 @@
+package sumologic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	// 1200 rpm is a request every 50ms.
+	l := newRateLimiter(1200, 3)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Errorf("Wait() returned error: %v", err)
+			return
+		}
+		if i == 2 && time.Since(start) > 25*time.Millisecond {
+			t.Errorf("Expected the burst of 3 to go through at once, took %v", time.Since(start))
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected the 2 requests after the burst to be paced, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); err != context.Canceled {
+		t.Errorf("Expected Wait() to fail with a canceled context, got %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	attempts := 0
+	var retried time.Time
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		retried = time.Now()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	start := time.Now()
+	if err := c.DeleteHostedCollector(defaultCollector.ID); err != nil {
+		t.Errorf("DeleteHostedCollector() returned an error: %s", err)
+		return
+	}
+	if attempts != 2 || retried.Sub(start) < time.Second {
+		t.Errorf("Expected a retry after a second, got %d attempts after %v", attempts, retried.Sub(start))
+	}
+}
+
+func TestWithRateLimitDisabled(t *testing.T) {
+	c, err := NewClient("accessToken", "http://localhost", WithRateLimit(0, 0))
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	if c.limiter != nil {
+		t.Errorf("Expected WithRateLimit(0, 0) to remove the rate limit")
+	}
+}
@@ -0,0 +1,52 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ReadOnlyError is returned for a call a read-only client refused to make because it
+// could modify the account.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("%s %s refused: the client is read-only", e.Method, e.Path)
+}
+
+// WithReadOnly makes the client refuse every POST, PUT, PATCH and DELETE request with a
+// *ReadOnlyError before it's sent, except for search jobs, which only create and delete
+// the caller's own searches, content export jobs, and queries sent as a POST, such as
+// metrics and span queries.
+func WithReadOnly() ClientOption {
+	return func(c *Client) {
+		c.ReadOnly = true
+	}
+}
+
+// checkReadOnly returns a *ReadOnlyError when a read-only client must not send the request.
+func (c *Client) checkReadOnly(req *http.Request) error {
+	if !c.ReadOnly || !isMutating(req.Method) || isQuery(req) || endpointGroup(req.URL.Path) == "search" {
+		return nil
+	}
+	if req.Method == "POST" && strings.HasSuffix(req.URL.Path, "/export") {
+		return nil
+	}
+	return &ReadOnlyError{Method: req.Method, Path: req.URL.Path}
+}
+
+// isQuery reports whether the request only reads the account's data, though its method
+// is one that changes it. Span queries are started and deleted like search jobs, but
+// unlike them they have no effect on the account at all.
+func isQuery(req *http.Request) bool {
+	if endpointGroup(req.URL.Path) == "spansQuery" {
+		return true
+	}
+	if req.Method != "POST" {
+		return false
+	}
+	return endpointGroup(req.URL.Path) == "metricsQueries" || strings.HasSuffix(req.URL.Path, "/logSearches/estimatedUsage")
+}
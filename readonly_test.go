@@ -0,0 +1,88 @@
+package sumologic
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadOnlyClient(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "job"}`))
+		default:
+			w.Write([]byte(`{"collector": {"id": 1, "name": "test"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL, WithReadOnly())
+	if _, _, err := c.GetHostedCollector(1); err != nil {
+		t.Errorf("Expected reads to be allowed, got %v", err)
+	}
+	if _, _, err := c.StartSearch(StartSearchRequest{Query: "*"}); err != nil {
+		t.Errorf("Expected search jobs to be allowed, got %v", err)
+	}
+	if _, err := c.StartContentExport("abc"); err != nil {
+		t.Errorf("Expected content exports to be allowed, got %v", err)
+	}
+	requests = 0
+
+	var roErr *ReadOnlyError
+	if _, err := c.CreateHostedCollector(Collector{Name: "test"}); !errors.As(err, &roErr) || roErr.Method != "POST" {
+		t.Errorf("Expected a *ReadOnlyError for a create, got %v", err)
+	}
+	if err := c.DeleteHostedCollector(1); !errors.As(err, &roErr) || roErr.Path != "/collectors/1" {
+		t.Errorf("Expected a *ReadOnlyError for a delete, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected refused calls not to reach the API, got %d requests", requests)
+	}
+}
+
+func TestReadOnlyClientAllowsQueries(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/metricsQueries":
+			w.Write([]byte(`{"queryResult": []}`))
+		case "/logSearches/estimatedUsage":
+			w.Write([]byte(`{"estimatedUsageDetails": {"dataScannedInBytes": 1024}}`))
+		case "/spansQuery":
+			w.Write([]byte(`{"id": "sq1"}`))
+		case "/spansQuery/sq1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected ‘%s’ request to ‘%s’", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	var journal bytes.Buffer
+	c, _ := NewClient("accessToken", ts.URL, WithReadOnly(), WithJournal(NewJSONLinesJournal(&journal), "analyst"))
+	from := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	tr := TimeRange{From: from, To: from.Add(time.Hour)}
+	if _, err := c.QueryMetrics(MetricsQueryRequest{Queries: []MetricsQuery{{Query: "metric=CPU_Idle"}}, From: tr.From, To: tr.To}); err != nil {
+		t.Errorf("Expected metrics queries to be allowed, got %v", err)
+	}
+	if _, err := c.EstimateSearchUsage(SearchUsageRequest{Query: "error", TimeRange: tr}); err != nil {
+		t.Errorf("Expected search usage estimates to be allowed, got %v", err)
+	}
+	sq, err := c.StartSpanQuery(SpanQueryRequest{Rows: []SpanQueryRow{{Query: "service=checkout"}}, TimeRange: tr})
+	if err != nil {
+		t.Errorf("Expected span queries to be allowed, got %v", err)
+		return
+	}
+	if err := sq.Delete(); err != nil {
+		t.Errorf("Expected span queries to be deleted, got %v", err)
+	}
+	if journal.Len() != 0 {
+		t.Errorf("Expected queries not to be journaled, got %s", journal.String())
+	}
+}
@@ -0,0 +1,86 @@
+package sumologic
+
+import "regexp"
+
+// RedactionRule replaces sensitive data in search result messages.
+// When Pattern is set its matches are replaced in the listed Fields, or in every string
+// field when Fields is empty. Without a Pattern the whole value of each listed field is replaced.
+type RedactionRule struct {
+	Name        string
+	Pattern     *regexp.Regexp
+	Fields      []string
+	Replacement string
+}
+
+// Built-in redaction rules for common kinds of sensitive data.
+var (
+	RedactEmails = RedactionRule{
+		Name:        "email",
+		Pattern:     regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`),
+		Replacement: "[EMAIL]",
+	}
+	RedactIPv4 = RedactionRule{
+		Name:        "ipv4",
+		Pattern:     regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`),
+		Replacement: "[IP]",
+	}
+	RedactTokens = RedactionRule{
+		Name:        "token",
+		Pattern:     regexp.MustCompile(`(?i)\b(bearer|basic|token|api[_-]?key|secret|password)([\s:="']+)[^\s"',;]+`),
+		Replacement: "${1}${2}[REDACTED]",
+	}
+)
+
+// Redactor applies redaction rules to search results so they can be shared outside the
+// security boundary. Set it on Client.Redactor to redact messages as they're retrieved.
+type Redactor struct {
+	Rules []RedactionRule
+}
+
+// NewRedactor returns a Redactor applying the rules in order.
+func NewRedactor(rules ...RedactionRule) *Redactor {
+	return &Redactor{Rules: rules}
+}
+
+// RedactMessage redacts one message in place.
+func (r *Redactor) RedactMessage(m *SearchJobResultMessage) {
+	for _, rule := range r.Rules {
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = "[REDACTED]"
+		}
+
+		if len(rule.Fields) == 0 {
+			if rule.Pattern == nil {
+				continue
+			}
+			for k, v := range m.Map {
+				if s, ok := v.(string); ok {
+					m.Map[k] = rule.Pattern.ReplaceAllString(s, replacement)
+				}
+			}
+			continue
+		}
+
+		for _, field := range rule.Fields {
+			v, ok := m.Map[field]
+			if !ok {
+				continue
+			}
+			if rule.Pattern == nil {
+				m.Map[field] = replacement
+				continue
+			}
+			if s, ok := v.(string); ok {
+				m.Map[field] = rule.Pattern.ReplaceAllString(s, replacement)
+			}
+		}
+	}
+}
+
+// RedactResult redacts every message of a search result in place.
+func (r *Redactor) RedactResult(result *SearchJobResult) {
+	for _, m := range result.Messages {
+		r.RedactMessage(m)
+	}
+}
@@ -0,0 +1,73 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestRedactMessage(t *testing.T) {
+	m := &SearchJobResultMessage{Map: map[string]interface{}{
+		"_raw":       `user=jane@example.com ip=10.1.2.3 Authorization: Bearer abc.def.ghi`,
+		"user_id":    "12345",
+		"_messageid": "-9223372036854773763",
+		"count":      3,
+	}}
+
+	r := NewRedactor(RedactEmails, RedactIPv4, RedactTokens, RedactionRule{Fields: []string{"user_id"}})
+	r.RedactMessage(m)
+
+	if raw := m.Map["_raw"]; raw != `user=[EMAIL] ip=[IP] Authorization: Bearer [REDACTED]` {
+		t.Errorf("Unexpected redacted _raw: %s", raw)
+	}
+	if m.Map["user_id"] != "[REDACTED]" {
+		t.Errorf("Expected user_id to be redacted, got %v", m.Map["user_id"])
+	}
+	if m.Map["_messageid"] != "-9223372036854773763" || m.Map["count"] != 3 {
+		t.Errorf("Expected other fields to be untouched, got %v", m.Map)
+	}
+}
+
+func TestRedactMessageFieldPattern(t *testing.T) {
+	m := &SearchJobResultMessage{Map: map[string]interface{}{
+		"card":  "4111-1111-1111-1111",
+		"other": "4111-1111-1111-1111",
+	}}
+	NewRedactor(RedactionRule{
+		Pattern:     regexp.MustCompile(`\d{4}-\d{4}-\d{4}-(\d{4})`),
+		Fields:      []string{"card"},
+		Replacement: "****-$1",
+	}).RedactMessage(m)
+	if m.Map["card"] != "****-1111" || m.Map["other"] != "4111-1111-1111-1111" {
+		t.Errorf("Unexpected redaction: %v", m.Map)
+	}
+}
+
+func TestGetSearchResultsRedacted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(SearchJobResult{Messages: []*SearchJobResultMessage{
+			{Map: map[string]interface{}{"_raw": "login from 192.168.0.1"}},
+		}})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.Redactor = NewRedactor(RedactIPv4)
+
+	result, err := c.GetSearchResults(SearchJobResultsRequest{ID: "job", Limit: 1}, nil)
+	if err != nil {
+		t.Errorf("GetSearchResults() returned an error: %s", err)
+		return
+	}
+	if raw := result.Messages[0].Map["_raw"]; raw != "login from [IP]" {
+		t.Errorf("Expected the IP to be redacted, got %s", raw)
+	}
+}
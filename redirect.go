@@ -0,0 +1,107 @@
+package sumologic
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxRedirects is how many redirects a request follows before the last one is returned.
+const maxRedirects = 5
+
+// endpoint returns the client's endpoint URL, which a deployment redirect may change.
+func (c *Client) endpoint() *url.URL {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.EndpointURL
+}
+
+// sendFollowingRedirects performs the request like sendOnce, following redirects itself
+// rather than leaving them to the HTTP client, which drops the Authorization header when
+// the host changes and turns a redirected POST into a GET without its body. The request
+// is replayed to the new location with its headers and body, but only on the same host
+// or to an https Sumo Logic deployment, and never from https to http; other redirects are
+// returned as they are. A permanent redirect to another host means the account lives on
+// a different deployment, so the client's endpoint is moved there for the following calls.
+func (c *Client) sendFollowingRedirects(req *http.Request) (*http.Response, []byte, error) {
+	for redirects := 0; ; redirects++ {
+		resp, body, err := c.sendOnce(req)
+		if err != nil || !isRedirect(resp.StatusCode) || redirects == maxRedirects {
+			return resp, body, err
+		}
+		location, lerr := resp.Location()
+		if lerr != nil || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return resp, body, err
+		}
+		if !trustedRedirect(req.URL, location) {
+			return resp, body, err
+		}
+
+		next := req.Clone(req.Context())
+		next.URL, next.Host = location, ""
+		if req.GetBody != nil {
+			b, err := req.GetBody()
+			if err != nil {
+				return nil, nil, err
+			}
+			next.Body = b
+		}
+		if location.Host != req.URL.Host && isPermanentRedirect(resp.StatusCode) {
+			c.moveEndpoint(req.URL, location)
+		}
+		req = next
+	}
+}
+
+// isDeploymentHost reports whether the host is a Sumo Logic deployment's API host.
+var isDeploymentHost = func(host string) bool {
+	return strings.HasSuffix(host, ".sumologic.com")
+}
+
+// trustedRedirect reports whether a request, with its credentials, may be replayed from
+// one URL to the other.
+func trustedRedirect(from, to *url.URL) bool {
+	if from.Scheme == "https" && to.Scheme != "https" {
+		return false
+	}
+	if to.Host == from.Host {
+		return true
+	}
+	return to.Scheme == "https" && isDeploymentHost(to.Hostname())
+}
+
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+func isPermanentRedirect(status int) bool {
+	return status == http.StatusMovedPermanently || status == http.StatusPermanentRedirect
+}
+
+// moveEndpoint points the client's endpoint at the scheme and host a request was
+// redirected to, and reports the move to OnEndpointRedirect, or the standard logger
+// when it's nil.
+func (c *Client) moveEndpoint(from, to *url.URL) {
+	c.mu.Lock()
+	old := c.EndpointURL
+	if old.Host != from.Host {
+		// Another call has already moved it, or the request wasn't to the endpoint.
+		c.mu.Unlock()
+		return
+	}
+	moved := *old
+	moved.Scheme, moved.Host = to.Scheme, to.Host
+	c.EndpointURL = &moved
+	c.mu.Unlock()
+
+	if c.OnEndpointRedirect != nil {
+		c.OnEndpointRedirect(old, &moved)
+		return
+	}
+	log.Printf("sumologic: API endpoint %s redirected to %s; update the client's endpoint to the account's deployment", old, &moved)
+}
@@ -0,0 +1,153 @@
+package sumologic
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+// trustTestDeployments makes the httptest servers' host a deployment host until the
+// returned func is called.
+func trustTestDeployments() func() {
+	saved := isDeploymentHost
+	isDeploymentHost = func(host string) bool { return host == "127.0.0.1" }
+	return func() { isDeploymentHost = saved }
+}
+
+func TestDeploymentRedirectIsReplayed(t *testing.T) {
+	defer trustTestDeployments()()
+
+	var requests int32
+	deployment := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.Header.Get("Authorization") != "Basic accessToken" {
+			t.Errorf("Expected the Authorization header to be kept, got ‘%s’", r.Header.Get("Authorization"))
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"name":"deploy","type":"CollectorRegistration"}` {
+			t.Errorf("Expected the body to be kept, got `%s`", body)
+		}
+		w.Write([]byte(`{"id": "tok1"}`))
+	}))
+	defer deployment.Close()
+	origin := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, deployment.URL+r.URL.Path, http.StatusMovedPermanently)
+	}))
+	defer origin.Close()
+
+	var moved *url.URL
+	c, _ := NewClient("accessToken", origin.URL+"/api/v1/", WithHTTPClient(origin.Client()))
+	c.OnEndpointRedirect = func(from, to *url.URL) { moved = to }
+
+	for i := 0; i < 2; i++ {
+		tok, err := c.CreateToken(Token{Name: "deploy"})
+		if err != nil {
+			t.Errorf("CreateToken() returned an error: %s", err)
+			return
+		}
+		if tok.ID != "tok1" {
+			t.Errorf("Expected the token from the redirected request, got ‘%s’", tok.ID)
+		}
+	}
+	if atomic.LoadInt32(&requests) != 2 {
+		t.Errorf("Expected 2 requests to reach the deployment, got %d", requests)
+	}
+	expected := deployment.URL + "/api/v1/"
+	if moved == nil || moved.String() != expected || c.EndpointURL.String() != expected {
+		t.Errorf("Expected the endpoint to move to %s, got %v", expected, c.EndpointURL)
+	}
+}
+
+func TestTemporaryRedirectKeepsEndpoint(t *testing.T) {
+	defer trustTestDeployments()()
+
+	var requests int32
+	deployment := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id": "tok1"}`))
+	}))
+	defer deployment.Close()
+	origin := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, deployment.URL+r.URL.Path, http.StatusFound)
+	}))
+	defer origin.Close()
+
+	c, _ := NewClient("accessToken", origin.URL+"/api/v1/", WithHTTPClient(origin.Client()))
+	c.OnEndpointRedirect = func(from, to *url.URL) {
+		t.Errorf("Expected a temporary redirect not to move the endpoint, moved to %s", to)
+	}
+	if _, err := c.GetToken("tok1"); err != nil {
+		t.Errorf("GetToken() returned an error: %s", err)
+		return
+	}
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("Expected the request to be replayed to the deployment, got %d requests", requests)
+	}
+	if c.EndpointURL.String() != origin.URL+"/api/v1/" {
+		t.Errorf("Expected the endpoint to stay at %s, got %s", origin.URL, c.EndpointURL)
+	}
+}
+
+func TestRedirectToForeignHostIsNotReplayed(t *testing.T) {
+	var requests int32
+	foreign := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id": "tok1"}`))
+	}))
+	defer foreign.Close()
+	origin := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, foreign.URL+r.URL.Path, http.StatusMovedPermanently)
+	}))
+	defer origin.Close()
+
+	c, _ := NewClient("accessToken", origin.URL, WithHTTPClient(origin.Client()))
+	if _, err := c.GetToken("tok1"); err == nil {
+		t.Errorf("Expected an error for a redirect to a foreign host")
+	}
+	if atomic.LoadInt32(&requests) != 0 {
+		t.Errorf("Expected no request to reach the foreign host, got %d", requests)
+	}
+	if c.EndpointURL.String() != origin.URL {
+		t.Errorf("Expected the endpoint to stay at %s, got %s", origin.URL, c.EndpointURL)
+	}
+}
+
+func TestRedirectToHTTPIsNotReplayed(t *testing.T) {
+	defer trustTestDeployments()()
+
+	var requests int32
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer plain.Close()
+	origin := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+r.URL.Path, http.StatusMovedPermanently)
+	}))
+	defer origin.Close()
+
+	c, _ := NewClient("accessToken", origin.URL, WithHTTPClient(origin.Client()))
+	if _, err := c.GetToken("tok1"); err == nil {
+		t.Errorf("Expected an error for a redirect from https to http")
+	}
+	if atomic.LoadInt32(&requests) != 0 {
+		t.Errorf("Expected no request to be downgraded to http, got %d", requests)
+	}
+}
+
+func TestRedirectLoopReturnsLastResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Path, http.StatusTemporaryRedirect)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if _, err := c.GetToken("tok1"); err == nil {
+		t.Errorf("Expected an error for a redirect loop")
+	}
+}
@@ -0,0 +1,137 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RehydrationRequest describes archived data to ingest and the search to run over it.
+type RehydrationRequest struct {
+	// SourceID is the ID of the archive source to ingest from.
+	SourceID int
+	// Name prefixes the names of the archive jobs created.
+	Name      string
+	StartTime time.Time
+	EndTime   time.Time
+
+	// ChunkSize is the time range covered by each archive job, 24 hours by default.
+	ChunkSize time.Duration
+	// PollInterval is the delay between job status checks, 30 seconds by default.
+	PollInterval time.Duration
+
+	// Query is the follow-up search started once every job has succeeded.
+	// No search is started when it's empty.
+	Query string
+}
+
+// Rehydration is the outcome of a rehydration: the archive jobs that ingested the data
+// and, when a query was given, the follow-up search job and its session cookies.
+type Rehydration struct {
+	Jobs      []ArchiveJob
+	SearchJob *SearchJob
+	Cookies   []*http.Cookie
+}
+
+// Rehydrate ingests archived data for the requested time range in chunks, waits for every
+// archive job to finish and then starts the follow-up search over the same time range.
+// An error is returned if any job fails; the jobs created so far are still returned.
+func (c *Client) Rehydrate(rr RehydrationRequest, opts ...CallOption) (*Rehydration, error) {
+	chunkSize := rr.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = 24 * time.Hour
+	}
+	pollInterval := rr.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 30 * time.Second
+	}
+	if !rr.StartTime.Before(rr.EndTime) {
+		return nil, fmt.Errorf("rehydration start time %s is not before end time %s", rr.StartTime, rr.EndTime)
+	}
+
+	r := new(Rehydration)
+	pending := make(map[string]bool)
+	for i, chunk := range chunkTimeRange(rr.StartTime, rr.EndTime, chunkSize) {
+		job, err := c.CreateArchiveJob(rr.SourceID, ArchiveJob{
+			Name:      fmt.Sprintf("%s-%d", rr.Name, i+1),
+			StartTime: chunk[0].UTC().Format(time.RFC3339),
+			EndTime:   chunk[1].UTC().Format(time.RFC3339),
+		}, opts...)
+		if err != nil {
+			return r, err
+		}
+		r.Jobs = append(r.Jobs, *job)
+		pending[job.ID] = true
+	}
+
+	for len(pending) > 0 {
+		if err := sleepCallOptions(pollInterval, opts); err != nil {
+			return r, err
+		}
+		err := c.pollArchiveJobs(rr.SourceID, r, pending, opts...)
+		if err != nil {
+			return r, err
+		}
+	}
+
+	if rr.Query == "" {
+		return r, nil
+	}
+	sj, err := c.StartSearchJob(StartSearchRequest{
+		Query:    rr.Query,
+		From:     rr.StartTime.UTC().Format("2006-01-02T15:04:05"),
+		To:       rr.EndTime.UTC().Format("2006-01-02T15:04:05"),
+		TimeZone: "UTC",
+	}, opts...)
+	if err != nil {
+		return r, err
+	}
+	r.SearchJob = sj
+	r.Cookies = sj.cookies
+	return r, nil
+}
+
+// pollArchiveJobs refreshes the status of the rehydration's jobs and removes the
+// finished ones from pending.
+func (c *Client) pollArchiveJobs(sourceID int, r *Rehydration, pending map[string]bool, opts ...CallOption) error {
+	token := ""
+	for {
+		ajl, err := c.ListArchiveJobs(sourceID, 0, token, opts...)
+		if err != nil {
+			return err
+		}
+		for _, job := range ajl.Data {
+			if !pending[job.ID] {
+				continue
+			}
+			for i := range r.Jobs {
+				if r.Jobs[i].ID == job.ID {
+					r.Jobs[i] = job
+				}
+			}
+			switch job.Status {
+			case ArchiveJobStatusSucceeded:
+				delete(pending, job.ID)
+			case ArchiveJobStatusFailed:
+				return fmt.Errorf("archive job %s (%s) failed", job.Name, job.ID)
+			}
+		}
+		if ajl.Next == "" {
+			return nil
+		}
+		token = ajl.Next
+	}
+}
+
+// chunkTimeRange splits [start, end) into consecutive ranges no longer than size.
+func chunkTimeRange(start, end time.Time, size time.Duration) [][2]time.Time {
+	var chunks [][2]time.Time
+	for from := start; from.Before(end); from = from.Add(size) {
+		to := from.Add(size)
+		if to.After(end) {
+			to = end
+		}
+		chunks = append(chunks, [2]time.Time{from, to})
+	}
+	return chunks
+}
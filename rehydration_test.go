@@ -0,0 +1,94 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChunkTimeRange(t *testing.T) {
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	chunks := chunkTimeRange(start, start.Add(60*time.Hour), 24*time.Hour)
+	if len(chunks) != 3 {
+		t.Errorf("Expected 3 chunks, got %d", len(chunks))
+		return
+	}
+	if !chunks[2][0].Equal(start.Add(48*time.Hour)) || !chunks[2][1].Equal(start.Add(60*time.Hour)) {
+		t.Errorf("Unexpected last chunk %v", chunks[2])
+	}
+}
+
+func TestRehydrate(t *testing.T) {
+	var created []ArchiveJob
+	polls := 0
+	searched := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.EscapedPath() == "/archive/42/jobs":
+			w.WriteHeader(http.StatusCreated)
+			body, _ := ioutil.ReadAll(r.Body)
+			job := ArchiveJob{}
+			json.Unmarshal(body, &job)
+			job.ID = job.Name
+			job.Status = ArchiveJobStatusPending
+			created = append(created, job)
+			body, _ = json.Marshal(job)
+			w.Write(body)
+		case r.Method == "GET" && r.URL.EscapedPath() == "/archive/42/jobs":
+			w.WriteHeader(http.StatusOK)
+			polls++
+			jobs := make([]ArchiveJob, len(created))
+			copy(jobs, created)
+			for i := range jobs {
+				jobs[i].Status = ArchiveJobStatusIngesting
+				if polls > 1 {
+					jobs[i].Status = ArchiveJobStatusSucceeded
+				}
+			}
+			body, _ := json.Marshal(ArchiveJobList{Data: jobs})
+			w.Write(body)
+		case r.Method == "POST" && r.URL.EscapedPath() == "/search/jobs":
+			w.WriteHeader(http.StatusAccepted)
+			searched = true
+			body, _ := json.Marshal(SearchJob{ID: "followup"})
+			w.Write(body)
+		default:
+			t.Errorf("Unexpected ‘%s’ request to ‘%s’", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	r, err := c.Rehydrate(RehydrationRequest{
+		SourceID:     42,
+		Name:         "incident",
+		StartTime:    start,
+		EndTime:      start.Add(48 * time.Hour),
+		PollInterval: time.Millisecond,
+		Query:        "_sourceCategory=archive",
+	})
+	if err != nil {
+		t.Errorf("Rehydrate() returned an error: %s", err)
+		return
+	}
+	if len(r.Jobs) != 2 || created[1].StartTime != "2017-01-02T00:00:00Z" {
+		t.Errorf("Expected two daily archive jobs, got %v", created)
+	}
+	for _, job := range r.Jobs {
+		if job.Status != ArchiveJobStatusSucceeded {
+			t.Errorf("Expected job %s to have succeeded, got %s", job.ID, job.Status)
+		}
+	}
+	if !searched || r.SearchJob == nil || r.SearchJob.ID != "followup" {
+		t.Errorf("Expected the follow-up search to be started")
+	}
+}
@@ -0,0 +1,92 @@
+package sumologic
+
+import (
+	"errors"
+	"sync"
+)
+
+// ResourceClient is the common set of operations on a type of management resource, so tools
+// such as reconcilers can work with any resource type. IDs are strings for every resource;
+// numeric IDs are formatted in base 10.
+type ResourceClient[T any] interface {
+	List() ([]T, error)
+	Get(id string) (*T, error)
+	Create(resource T) (*T, error)
+	Update(resource T) (*T, error)
+	Delete(id string) error
+}
+
+// ErrOperationNotSupported is returned by a ResourceClient for operations the resource's API
+// doesn't offer.
+var ErrOperationNotSupported = errors.New("Operation not supported for this resource")
+
+// resourceClient implements ResourceClient with one function per operation.
+// Operations without a function aren't supported.
+type resourceClient[T any] struct {
+	list   func() ([]T, error)
+	get    func(id string) (*T, error)
+	create func(resource T) (*T, error)
+	update func(resource T) (*T, error)
+	delete func(id string) error
+}
+
+func (r *resourceClient[T]) List() ([]T, error) {
+	if r.list == nil {
+		return nil, ErrOperationNotSupported
+	}
+	return r.list()
+}
+
+func (r *resourceClient[T]) Get(id string) (*T, error) {
+	if r.get == nil {
+		return nil, ErrOperationNotSupported
+	}
+	return r.get(id)
+}
+
+func (r *resourceClient[T]) Create(resource T) (*T, error) {
+	if r.create == nil {
+		return nil, ErrOperationNotSupported
+	}
+	return r.create(resource)
+}
+
+func (r *resourceClient[T]) Update(resource T) (*T, error) {
+	if r.update == nil {
+		return nil, ErrOperationNotSupported
+	}
+	return r.update(resource)
+}
+
+func (r *resourceClient[T]) Delete(id string) error {
+	if r.delete == nil {
+		return ErrOperationNotSupported
+	}
+	return r.delete(id)
+}
+
+// etagCache remembers the ETags of the resources a ResourceClient has read, so an update
+// only succeeds if the resource hasn't changed since.
+type etagCache struct {
+	mu    sync.Mutex
+	etags map[string]string
+}
+
+func (e *etagCache) remember(id, etag string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.etags == nil {
+		e.etags = make(map[string]string)
+	}
+	e.etags[id] = etag
+}
+
+// take returns the ETag read for the resource and forgets it, since the update it's
+// used for changes the resource.
+func (e *etagCache) take(id string) (string, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	etag, ok := e.etags[id]
+	delete(e.etags, id)
+	return etag, ok
+}
@@ -0,0 +1,128 @@
+package sumologic
+
+import (
+	"sync"
+	"time"
+)
+
+// ResourceCache is a ResourceClient that caches resources by ID, so tools that read the
+// same resources many times, e.g. to resolve references, make fewer API calls. Entries
+// expire after the TTL and are replaced by the results of writes made through the cache.
+// Writes made elsewhere aren't seen until the entry expires or is invalidated.
+type ResourceCache[T any] struct {
+	// OnInvalidate, when set, is called with the ID of each entry removed by a write or Invalidate.
+	OnInvalidate func(id string)
+
+	client ResourceClient[T]
+	id     func(T) string
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]resourceCacheEntry[T]
+}
+
+type resourceCacheEntry[T any] struct {
+	resource T
+	expires  time.Time
+}
+
+// NewResourceCache returns a cache in front of client. id returns a resource's ID as
+// used by Get and Delete.
+func NewResourceCache[T any](client ResourceClient[T], id func(T) string, ttl time.Duration) *ResourceCache[T] {
+	return &ResourceCache[T]{
+		client:  client,
+		id:      id,
+		ttl:     ttl,
+		entries: make(map[string]resourceCacheEntry[T]),
+	}
+}
+
+// List lists the resources from the API and caches each of them.
+func (c *ResourceCache[T]) List() ([]T, error) {
+	resources, err := c.client.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range resources {
+		c.store(r)
+	}
+	return resources, nil
+}
+
+// Get returns the cached resource, reading it from the API when it's not cached or has expired.
+func (c *ResourceCache[T]) Get(id string) (*T, error) {
+	c.mu.Lock()
+	e, ok := c.entries[id]
+	c.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		r := e.resource
+		return &r, nil
+	}
+
+	r, err := c.client.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	c.store(*r)
+	return r, nil
+}
+
+// Create creates the resource and caches the result.
+func (c *ResourceCache[T]) Create(resource T) (*T, error) {
+	r, err := c.client.Create(resource)
+	if err != nil {
+		return nil, err
+	}
+	c.store(*r)
+	return r, nil
+}
+
+// Update invalidates the resource, updates it and caches the result.
+func (c *ResourceCache[T]) Update(resource T) (*T, error) {
+	c.Invalidate(c.id(resource))
+	r, err := c.client.Update(resource)
+	if err != nil {
+		return nil, err
+	}
+	c.store(*r)
+	return r, nil
+}
+
+// Delete invalidates the resource and deletes it.
+func (c *ResourceCache[T]) Delete(id string) error {
+	c.Invalidate(id)
+	return c.client.Delete(id)
+}
+
+// Invalidate removes a resource from the cache.
+func (c *ResourceCache[T]) Invalidate(id string) {
+	c.mu.Lock()
+	_, ok := c.entries[id]
+	delete(c.entries, id)
+	c.mu.Unlock()
+	if ok && c.OnInvalidate != nil {
+		c.OnInvalidate(id)
+	}
+}
+
+// InvalidateAll empties the cache.
+func (c *ResourceCache[T]) InvalidateAll() {
+	c.mu.Lock()
+	var ids []string
+	for id := range c.entries {
+		ids = append(ids, id)
+	}
+	c.entries = make(map[string]resourceCacheEntry[T])
+	c.mu.Unlock()
+	if c.OnInvalidate != nil {
+		for _, id := range ids {
+			c.OnInvalidate(id)
+		}
+	}
+}
+
+func (c *ResourceCache[T]) store(r T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[c.id(r)] = resourceCacheEntry[T]{resource: r, expires: time.Now().Add(c.ttl)}
+}
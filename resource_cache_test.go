@@ -0,0 +1,80 @@
+package sumologic
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestResourceCache(t *testing.T) {
+	gets := 0
+	collectors := map[string]Collector{"1": {ID: 1, Name: "one"}, "2": {ID: 2, Name: "two"}}
+	rc := &resourceClient[Collector]{
+		get: func(id string) (*Collector, error) {
+			gets++
+			c, ok := collectors[id]
+			if !ok {
+				return nil, ErrCollectorNotFound
+			}
+			return &c, nil
+		},
+		update: func(c Collector) (*Collector, error) {
+			c.Description = "updated"
+			return &c, nil
+		},
+		delete: func(id string) error {
+			return nil
+		},
+	}
+	cache := NewResourceCache[Collector](rc, func(c Collector) string { return strconv.Itoa(c.ID) }, time.Hour)
+	var invalidated []string
+	cache.OnInvalidate = func(id string) { invalidated = append(invalidated, id) }
+
+	for i := 0; i < 3; i++ {
+		c, err := cache.Get("1")
+		if err != nil || c.Name != "one" {
+			t.Errorf("Get() returned %v, %v", c, err)
+			return
+		}
+	}
+	if gets != 1 {
+		t.Errorf("Expected 1 API read for repeated gets, got %d", gets)
+	}
+
+	if _, err := cache.Update(Collector{ID: 1, Name: "one"}); err != nil {
+		t.Errorf("Update() returned error: %v", err)
+	}
+	c, _ := cache.Get("1")
+	if c.Description != "updated" || gets != 1 {
+		t.Errorf("Expected the updated collector from the cache, got %+v after %d reads", c, gets)
+	}
+
+	cache.Get("2")
+	if err := cache.Delete("2"); err != nil {
+		t.Errorf("Delete() returned error: %v", err)
+	}
+	cache.Get("2")
+	if gets != 3 {
+		t.Errorf("Expected a deleted collector to be read again, got %d reads", gets)
+	}
+	if len(invalidated) != 2 || invalidated[0] != "1" || invalidated[1] != "2" {
+		t.Errorf("Expected invalidations of 1 and 2, got %v", invalidated)
+	}
+}
+
+func TestResourceCacheExpires(t *testing.T) {
+	gets := 0
+	rc := &resourceClient[Collector]{
+		get: func(id string) (*Collector, error) {
+			gets++
+			return &Collector{ID: 1}, nil
+		},
+	}
+	cache := NewResourceCache[Collector](rc, func(c Collector) string { return strconv.Itoa(c.ID) }, time.Millisecond)
+	cache.Get("1")
+	time.Sleep(5 * time.Millisecond)
+	cache.Get("1")
+	if gets != 2 {
+		t.Errorf("Expected an expired entry to be read again, got %d reads", gets)
+	}
+}
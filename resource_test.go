@@ -0,0 +1,100 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHostedCollectorsResourceClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedURL := "/collectors/" + strconv.Itoa(defaultCollector.ID)
+		if r.URL.EscapedPath() != expectedURL {
+			t.Errorf("Expected request to ‘%s’, got ‘%s’", expectedURL, r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case "GET":
+			w.Header().Set("ETag", "etag")
+			w.WriteHeader(http.StatusOK)
+		case "PUT":
+			if r.Header.Get("If-Match") != "etag" {
+				t.Errorf("Expected Etag of `etag`, got `%s`", r.Header.Get("If-Match"))
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+		body, _ := json.Marshal(CollectorRequest{Collector: defaultCollector})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	var rc ResourceClient[Collector] = c.HostedCollectors()
+	collector, err := rc.Get(strconv.Itoa(defaultCollector.ID))
+	if err != nil {
+		t.Errorf("Get() returned an error: %s", err)
+		return
+	}
+	if _, err := rc.Update(*collector); err != nil {
+		t.Errorf("Update() returned an error: %s", err)
+	}
+	if _, err := rc.Get("not-a-number"); err == nil {
+		t.Errorf("Get() did not return an error for a non-numeric ID")
+	}
+}
+
+func TestHostedCollectorsResourceClientConflict(t *testing.T) {
+	var gets int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			// The collector changes after every read.
+			n := atomic.AddInt32(&gets, 1)
+			w.Header().Set("ETag", fmt.Sprintf("etag%d", n))
+			body, _ := json.Marshal(CollectorRequest{Collector: defaultCollector})
+			w.Write(body)
+		case "PUT":
+			if r.Header.Get("If-Match") != fmt.Sprintf("etag%d", atomic.LoadInt32(&gets)) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			body, _ := json.Marshal(CollectorRequest{Collector: defaultCollector})
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	rc := c.HostedCollectors()
+	collector, err := rc.Get(strconv.Itoa(defaultCollector.ID))
+	if err != nil {
+		t.Errorf("Get() returned an error: %s", err)
+		return
+	}
+	if _, _, err := c.GetHostedCollector(defaultCollector.ID); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	if _, err := rc.Update(*collector); err != ErrCollectorModified {
+		t.Errorf("Expected ErrCollectorModified for a collector changed since Get, got %v", err)
+	}
+}
+
+func TestResourceClientNotSupported(t *testing.T) {
+	c, _ := NewClient("accessToken", "http://localhost")
+
+	if _, err := c.Fields().Update(Field{}); err != ErrOperationNotSupported {
+		t.Errorf("Update() returned the wrong error: %v", err)
+	}
+	if err := c.Entities().Delete("id"); err != ErrOperationNotSupported {
+		t.Errorf("Delete() returned the wrong error: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ResponseTooLargeError is returned by GET calls whose response is larger than the
+// configured maximum. The response is discarded without being read into memory.
+type ResponseTooLargeError struct {
+	URL        string
+	Limit      int64
+	Suggestion string
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("Response from `%s` is larger than %d bytes: %s", e.URL, e.Limit, e.Suggestion)
+}
+
+func newResponseTooLargeError(req *http.Request, limit int64) *ResponseTooLargeError {
+	path := req.URL.Path
+	suggestion := "lower the limit of the request and page through the results"
+	switch {
+	case strings.HasSuffix(path, "/messages"), strings.HasSuffix(path, "/records"):
+		suggestion = "request fewer results per page with a lower limit and fetch the job's results page by page"
+	case req.URL.Query().Get("limit") == "":
+		suggestion = "pass a limit and follow the next page token"
+	}
+	return &ResponseTooLargeError{
+		URL:        req.URL.String(),
+		Limit:      limit,
+		Suggestion: suggestion,
+	}
+}
@@ -0,0 +1,56 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxResponseBytes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"messages": [{"map": {"_raw": "` + strings.Repeat("x", 1000) + `"}}]}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.MaxResponseBytes = 512
+
+	_, err = c.GetSearchResults(SearchJobResultsRequest{ID: "job", Limit: 10000}, nil)
+	tooLarge, ok := err.(*ResponseTooLargeError)
+	if !ok {
+		t.Errorf("GetSearchResults() returned the wrong error: %v", err)
+		return
+	}
+	if tooLarge.Limit != 512 || !strings.Contains(tooLarge.Suggestion, "fewer results per page") {
+		t.Errorf("Unexpected error %v", tooLarge)
+	}
+
+	if _, err := c.GetSearchResults(SearchJobResultsRequest{ID: "job", Limit: 1}, nil, WithMaxResponseBytes(-1)); err != nil {
+		t.Errorf("GetSearchResults() returned an error without a limit: %s", err)
+	}
+}
+
+func TestMaxResponseBytesOnlyGuardsGET(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"collector": {"id": 1, "name": "` + strings.Repeat("x", 1000) + `"}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	c.MaxResponseBytes = 512
+
+	if _, err := c.CreateHostedCollector(Collector{Name: "test"}); err != nil {
+		t.Errorf("CreateHostedCollector() returned an error: %s", err)
+	}
+}
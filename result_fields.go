@@ -0,0 +1,89 @@
+package sumologic
+
+import "encoding/json"
+
+// WithResultFields keeps only the named fields, such as _raw and _messagetime, in the
+// messages returned by the call. With the Search Job API the other fields are dropped
+// while the response is decoded, so large results don't keep them in memory.
+func WithResultFields(fields ...string) CallOption {
+	return func(o *callOptions) {
+		o.resultFields = fields
+	}
+}
+
+// prunedSearchJobResult decodes a search result keeping only some fields of its messages.
+type prunedSearchJobResult struct {
+	keep   map[string]bool
+	result SearchJobResult
+}
+
+func newPrunedSearchJobResult(fields []string) *prunedSearchJobResult {
+	keep := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		keep[f] = true
+	}
+	return &prunedSearchJobResult{keep: keep}
+}
+
+func (r *prunedSearchJobResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Fields   []*SearchJobResultField `json:"fields"`
+		Messages []*struct {
+			Map map[string]json.RawMessage `json:"map"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for _, f := range raw.Fields {
+		if f == nil || r.keep[f.Name] {
+			r.result.Fields = append(r.result.Fields, f)
+		}
+	}
+	r.result.Messages = make([]*SearchJobResultMessage, len(raw.Messages))
+	for i, m := range raw.Messages {
+		if m == nil {
+			continue
+		}
+		pruned := make(map[string]interface{}, len(r.keep))
+		for name, value := range m.Map {
+			if !r.keep[name] {
+				continue
+			}
+			var v interface{}
+			if err := json.Unmarshal(value, &v); err != nil {
+				return err
+			}
+			pruned[name] = v
+		}
+		r.result.Messages[i] = &SearchJobResultMessage{Map: pruned}
+	}
+	return nil
+}
+
+func (r *prunedSearchJobResult) validate() error {
+	return r.result.validate()
+}
+
+// pruneSearchJobResult removes the fields not named from the result's messages in place.
+func pruneSearchJobResult(result *SearchJobResult, fields []string) {
+	keep := newPrunedSearchJobResult(fields).keep
+	var kept []*SearchJobResultField
+	for _, f := range result.Fields {
+		if f == nil || keep[f.Name] {
+			kept = append(kept, f)
+		}
+	}
+	result.Fields = kept
+	for _, m := range result.Messages {
+		if m == nil {
+			continue
+		}
+		for name := range m.Map {
+			if !keep[name] {
+				delete(m.Map, name)
+			}
+		}
+	}
+}
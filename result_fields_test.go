@@ -0,0 +1,49 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGetSearchResultsWithResultFields(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"fields": [{"name": "_raw", "fieldType": "string"}, {"name": "_sourcehost", "fieldType": "string"}, {"name": "_messagetime", "fieldType": "long"}],
+			"messages": [{"map": {"_raw": "error", "_sourcehost": "web-1", "_messagetime": "1359407350899", "_size": "5"}}]
+		}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	result, err := c.GetSearchResults(SearchJobResultsRequest{ID: "123", Limit: 10}, nil, WithResultFields("_raw", "_messagetime"))
+	if err != nil {
+		t.Errorf("GetSearchResults() returned an error: %s", err)
+		return
+	}
+	expected := map[string]interface{}{"_raw": "error", "_messagetime": "1359407350899"}
+	if len(result.Messages) != 1 || !reflect.DeepEqual(result.Messages[0].Map, expected) {
+		t.Errorf("Expected the message pruned to %v, got %v", expected, result.Messages)
+	}
+	if len(result.Fields) != 2 || result.Fields[0].Name != "_raw" || result.Fields[1].Name != "_messagetime" {
+		t.Errorf("Expected only the requested fields, got %d", len(result.Fields))
+	}
+}
+
+func TestPruneSearchJobResult(t *testing.T) {
+	result := &SearchJobResult{
+		Fields:   []*SearchJobResultField{{Name: "_raw"}, {Name: "_sourcehost"}},
+		Messages: []*SearchJobResultMessage{{Map: map[string]interface{}{"_raw": "error", "_sourcehost": "web-1"}}},
+	}
+	pruneSearchJobResult(result, []string{"_raw"})
+	if len(result.Fields) != 1 || !reflect.DeepEqual(result.Messages[0].Map, map[string]interface{}{"_raw": "error"}) {
+		t.Errorf("Expected only _raw to be kept, got %v and %v", result.Fields, result.Messages[0].Map)
+	}
+}
+
+func BenchmarkDecodeMessagesResultFields(b *testing.B) {
+	benchmarkDecode(b, func(body []byte) error {
+		return newPrunedSearchJobResult([]string{"_raw", "_messagetime"}).UnmarshalJSON(body)
+	})
+}
@@ -0,0 +1,180 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ScheduledView is an index that stores the results of its aggregate query, run over the
+// incoming messages from StartTime on.
+type ScheduledView struct {
+	ID                string `json:"id,omitempty"`
+	Query             string `json:"query"`
+	IndexName         string `json:"indexName"`
+	StartTime         string `json:"startTime"`
+	RetentionPeriod   int    `json:"retentionPeriod,omitempty"`
+	DataForwardingID  string `json:"dataForwardingId,omitempty"`
+	ParsingMode       string `json:"parsingMode,omitempty"`
+	IndexID           string `json:"indexId,omitempty"`
+	TotalBytes        int64  `json:"totalBytes,omitempty"`
+	TotalMessageCount int64  `json:"totalMessageCount,omitempty"`
+	CreatedAt         string `json:"createdAt,omitempty"`
+	CreatedBy         string `json:"createdBy,omitempty"`
+	ModifiedAt        string `json:"modifiedAt,omitempty"`
+	ModifiedBy        string `json:"modifiedBy,omitempty"`
+}
+
+// ScheduledViewList is one page of scheduled views. Next is the token for the following
+// page and is empty on the last page.
+type ScheduledViewList struct {
+	Data []ScheduledView `json:"data"`
+	Next string          `json:"next,omitempty"`
+}
+
+// UpdateScheduledViewRequest holds the mutable settings of a scheduled view.
+type UpdateScheduledViewRequest struct {
+	DataForwardingID                 string `json:"dataForwardingId,omitempty"`
+	RetentionPeriod                  int    `json:"retentionPeriod,omitempty"`
+	ReduceRetentionPeriodImmediately bool   `json:"reduceRetentionPeriodImmediately"`
+}
+
+// ErrScheduledViewNotFound is returned when a scheduled view doesn't exist.
+var ErrScheduledViewNotFound = errors.New("Scheduled view not found")
+
+// ListScheduledViews returns one page of scheduled views. A limit of 0 uses the API default.
+func (c *Client) ListScheduledViews(limit int, token string, opts ...CallOption) (*ScheduledViewList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "scheduledViews"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var svl = new(ScheduledViewList)
+	if err := c.do(call{method: "GET", path: path, out: svl}, opts); err != nil {
+		return nil, err
+	}
+	return svl, nil
+}
+
+// ListAllScheduledViews follows the pagination tokens and returns every scheduled view.
+func (c *Client) ListAllScheduledViews(opts ...CallOption) ([]ScheduledView, error) {
+	var views []ScheduledView
+	token := ""
+	for {
+		svl, err := c.ListScheduledViews(0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, svl.Data...)
+		if svl.Next == "" {
+			return views, nil
+		}
+		token = svl.Next
+	}
+}
+
+// GetScheduledView gets the scheduled view with the specified ID.
+func (c *Client) GetScheduledView(id string, opts ...CallOption) (*ScheduledView, error) {
+	var sv = new(ScheduledView)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("scheduledViews/%s", id),
+		out:      sv,
+		notFound: ErrScheduledViewNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return sv, nil
+}
+
+// CreateScheduledView creates a scheduled view. Its query must be an aggregate query and
+// StartTime, in RFC 3339 format, when it starts indexing.
+func (c *Client) CreateScheduledView(view ScheduledView, opts ...CallOption) (*ScheduledView, error) {
+	var sv = new(ScheduledView)
+	err := c.do(call{
+		method:     "POST",
+		path:       "scheduledViews",
+		in:         view,
+		out:        sv,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if a scheduled view with this index name `%s` already exists and its query is valid", view.IndexName),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return sv, nil
+}
+
+// UpdateScheduledView updates the scheduled view with the specified ID.
+func (c *Client) UpdateScheduledView(id string, usvr UpdateScheduledViewRequest, opts ...CallOption) (*ScheduledView, error) {
+	var sv = new(ScheduledView)
+	err := c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("scheduledViews/%s", id),
+		in:         usvr,
+		out:        sv,
+		notFound:   ErrScheduledViewNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for scheduled view `%s`", id),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return sv, nil
+}
+
+// PauseScheduledView stops indexing new messages into the scheduled view with the
+// specified ID until it's started again.
+func (c *Client) PauseScheduledView(id string, opts ...CallOption) error {
+	return c.scheduledViewAction("POST", fmt.Sprintf("scheduledViews/%s/pause", id), opts)
+}
+
+// StartScheduledView resumes indexing into a paused scheduled view.
+func (c *Client) StartScheduledView(id string, opts ...CallOption) error {
+	return c.scheduledViewAction("POST", fmt.Sprintf("scheduledViews/%s/start", id), opts)
+}
+
+// DisableScheduledView disables the scheduled view with the specified ID for good. Its
+// indexed data stays searchable until its retention period ends. Scheduled views can't
+// be deleted.
+func (c *Client) DisableScheduledView(id string, opts ...CallOption) error {
+	return c.scheduledViewAction("DELETE", fmt.Sprintf("scheduledViews/%s/disable", id), opts)
+}
+
+// ScheduledViews returns a ResourceClient for scheduled views. Deleting a scheduled view
+// through it disables it.
+func (c *Client) ScheduledViews(opts ...CallOption) ResourceClient[ScheduledView] {
+	return &resourceClient[ScheduledView]{
+		list: func() ([]ScheduledView, error) {
+			return c.ListAllScheduledViews(opts...)
+		},
+		get: func(id string) (*ScheduledView, error) {
+			return c.GetScheduledView(id, opts...)
+		},
+		create: func(sv ScheduledView) (*ScheduledView, error) {
+			return c.CreateScheduledView(sv, opts...)
+		},
+		update: func(sv ScheduledView) (*ScheduledView, error) {
+			return c.UpdateScheduledView(sv.ID, UpdateScheduledViewRequest{
+				DataForwardingID: sv.DataForwardingID,
+				RetentionPeriod:  sv.RetentionPeriod,
+			}, opts...)
+		},
+		delete: func(id string) error {
+			return c.DisableScheduledView(id, opts...)
+		},
+	}
+}
+
+// scheduledViewAction sends a request that has no response body.
+func (c *Client) scheduledViewAction(method, path string, opts []CallOption) error {
+	return c.do(call{method: method, path: path, notFound: ErrScheduledViewNotFound}, opts)
+}
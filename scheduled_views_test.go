@@ -0,0 +1,94 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListAllScheduledViewsFollowsToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/scheduledViews" {
+			t.Errorf("Expected request to ‘/scheduledViews’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var svl ScheduledViewList
+		switch r.URL.Query().Get("token") {
+		case "":
+			svl = ScheduledViewList{Data: []ScheduledView{{ID: "1", IndexName: "one"}}, Next: "page2"}
+		case "page2":
+			svl = ScheduledViewList{Data: []ScheduledView{{ID: "2", IndexName: "two"}}}
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		json.NewEncoder(w).Encode(svl)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	views, err := c.ListAllScheduledViews()
+	if err != nil {
+		t.Errorf("ListAllScheduledViews() returned an error: %s", err)
+		return
+	}
+	if len(views) != 2 {
+		t.Errorf("ListAllScheduledViews() expected 2 scheduled views, got %d", len(views))
+	}
+}
+
+func TestCreateScheduledView(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var sv ScheduledView
+		if err := json.Unmarshal(body, &sv); err != nil {
+			t.Errorf("Unable to unmarshal ScheduledView, got `%s`", body)
+		}
+		if sv.StartTime != "2023-01-01T00:00:00Z" {
+			t.Errorf("Expected the start time to be sent, got `%s`", body)
+		}
+		sv.ID = "sv1"
+		json.NewEncoder(w).Encode(sv)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	sv, err := c.CreateScheduledView(ScheduledView{
+		Query:     "_sourceCategory=prod/app | count by _sourceHost",
+		IndexName: "app_hosts",
+		StartTime: "2023-01-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Errorf("CreateScheduledView() returned an error: %s", err)
+		return
+	}
+	if sv.ID != "sv1" {
+		t.Errorf("Expected the created scheduled view's ID, got ‘%s’", sv.ID)
+	}
+}
+
+func TestPauseAndStartScheduledView(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		paths = append(paths, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.PauseScheduledView("sv1"); err != nil {
+		t.Errorf("PauseScheduledView() returned an error: %s", err)
+	}
+	if err := c.StartScheduledView("sv1"); err != nil {
+		t.Errorf("StartScheduledView() returned an error: %s", err)
+	}
+	if len(paths) != 2 || paths[0] != "/scheduledViews/sv1/pause" || paths[1] != "/scheduledViews/sv1/start" {
+		t.Errorf("Expected the pause and start requests, got %v", paths)
+	}
+}
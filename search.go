@@ -1,13 +1,15 @@
 package sumologic
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // https://help.sumologic.com/APIs/Search-Job-API/About-the-Search-Job-API#Creating_a_search_job
@@ -15,7 +17,7 @@ import (
 // Rate Limit 240 rpm
 // use ISO 8601 for time ranges
 // Process Flow
-// 1. Request a Search Job - Client.StartSearch(*Search) - query and time range.
+// 1. Request a Search Job - Client.StartSearchJob(StartSearchRequest) - query and time range.
 // 2. Response - a search job ID or error SearchJob
 // 3. Request search status - Client.GetSearchStatus(id int) must be done every 5 in at least
 // 4. Response
@@ -38,6 +40,10 @@ type SearchJob struct {
 	ID      string `json:"id,omitempty"`
 	Code    string `json:"code"`
 	Message string `json:"message"`
+
+	client  *Client
+	cookies []*http.Cookie
+	opts    []CallOption
 }
 
 // SearchJobStates are the different states a search job can be in.
@@ -49,48 +55,80 @@ var SearchJobStates = map[string]string{
 	"CANCELED":               "The search job has been canceled.",
 }
 
-// StartSearch calls the Sumologic API Search Endpoint.
+// StartSearchJob calls the Sumologic API Search Endpoint, or the client's SearchBackend.
+// With a SearchQuota it first waits for a free slot, and with a SearchCoalescer it
+// returns the open job of an identical search instead of starting one. The client keeps
+// the job's session cookies and sends them with every later call about the job.
 // POST search/jobs
-func (c *Client) StartSearch(ssr StartSearchRequest) (*SearchJob, []*http.Cookie, error) {
-	body, _ := json.Marshal(ssr)
-
-	relativeURL, _ := url.Parse("search/jobs")
-	url := c.EndpointURL.ResolveReference(relativeURL)
+func (c *Client) StartSearchJob(ssr StartSearchRequest, opts ...CallOption) (*SearchJob, error) {
+	var sj *SearchJob
+	var err error
+	if c.SearchCoalescer != nil {
+		sj, _, err = c.SearchCoalescer.start(c, ssr, opts)
+	} else {
+		sj, _, err = c.startSearch(ssr, opts...)
+	}
+	return sj, err
+}
 
-	req, err := http.NewRequest("POST", url.String(), bytes.NewBuffer(body))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+c.AuthToken)
+// StartSearch starts a search job and returns it with its session cookies.
+//
+// Deprecated: Use StartSearchJob. The client keeps the session cookies itself.
+func (c *Client) StartSearch(ssr StartSearchRequest, opts ...CallOption) (*SearchJob, []*http.Cookie, error) {
+	sj, err := c.StartSearchJob(ssr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sj, sj.cookies, nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+func (c *Client) startSearch(ssr StartSearchRequest, opts ...CallOption) (*SearchJob, []*http.Cookie, error) {
+	c.expireSearchSessions()
+	var slot string
+	if c.SearchQuota != nil {
+		var err error
+		if slot, err = c.SearchQuota.acquire(opts); err != nil {
+			return nil, nil, err
+		}
+	}
+	sj, cookies, err := c.searchBackend().StartSearch(ssr, opts...)
 	if err != nil {
+		if slot != "" {
+			c.SearchQuota.store().Release(slot)
+		}
 		return nil, nil, err
 	}
-	defer resp.Body.Close()
+	if slot != "" {
+		c.SearchQuota.started(sj.ID, slot)
+	}
+	sj.client, sj.cookies, sj.opts = c, cookies, opts
+	c.rememberSearchSession(sj.ID, cookies)
+	return sj, cookies, nil
+}
 
-	responseBody, _ := ioutil.ReadAll(resp.Body)
+func (c *Client) postSearchJob(ssr StartSearchRequest, opts ...CallOption) (*SearchJob, []*http.Cookie, error) {
+	req, err := c.newRequest("POST", "search/jobs", ssr, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, responseBody, err := c.send(req)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	switch resp.StatusCode {
 	case http.StatusAccepted:
 		var sj = new(SearchJob)
 
-		err = json.Unmarshal(responseBody, &sj)
+		err = c.decodeJSON(req, responseBody, sj)
 		if err != nil {
 			return nil, nil, err
 		}
-		cookies := resp.Cookies()
-		return sj, cookies, nil
+		return sj, resp.Cookies(), nil
 	case http.StatusUnauthorized:
 		return nil, nil, ErrClientAuthenticationError
-	case http.StatusBadRequest:
-		var sj = new(SearchJob)
-		err = json.Unmarshal(responseBody, &sj)
-		if err != nil {
-			return nil, nil, err
-		}
-		return nil, nil, fmt.Errorf("Start SearchJob BadRequest, %v, %v", sj.Code, sj.Message)
 	default:
-		return nil, nil, fmt.Errorf("unexepected http status code %v", resp.StatusCode)
+		return nil, nil, searchAPIError(resp, responseBody)
 	}
 }
 
@@ -111,37 +149,209 @@ type SearchJobStatusResponse struct {
 	PendingErrors   []string           `json:"pendingErrors"`
 }
 
-// GetSearchJobStatus retrieves the status of a running job.
-func (c *Client) GetSearchJobStatus(searchJobID string, cookies []*http.Cookie) (*SearchJobStatusResponse, error) {
+// GetSearchJob retrieves the status of a running job. SearchJob.GetStatus is preferred.
+func (c *Client) GetSearchJob(searchJobID string, opts ...CallOption) (*SearchJobStatusResponse, error) {
+	return c.getSearchJob(searchJobID, nil, opts...)
+}
+
+// getSearchJob retrieves the status of a job with cookies, or the job's session cookies
+// when cookies is nil.
+func (c *Client) getSearchJob(searchJobID string, cookies []*http.Cookie, opts ...CallOption) (*SearchJobStatusResponse, error) {
+	status, err := c.searchBackend().GetSearchJobStatus(searchJobID, c.searchSession(searchJobID, cookies), opts...)
+	if err == nil && status.State == "CANCELED" {
+		c.searchJobGone(searchJobID)
+	} else {
+		c.searchJobUsed(searchJobID, err)
+	}
+	return status, err
+}
+
+// GetSearchJobStatus retrieves the status of a running job, sending cookies as its
+// session cookies when they aren't nil.
+//
+// Deprecated: Use GetSearchJob. The client keeps the session cookies itself.
+func (c *Client) GetSearchJobStatus(searchJobID string, cookies []*http.Cookie, opts ...CallOption) (*SearchJobStatusResponse, error) {
+	return c.getSearchJob(searchJobID, cookies, opts...)
+}
 
-	relativeURL, _ := url.Parse(fmt.Sprintf("search/jobs/%s", searchJobID))
-	url := c.EndpointURL.ResolveReference(relativeURL)
-	req, err := http.NewRequest("GET", url.String(), nil)
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+c.AuthToken)
+func (c *Client) getSearchJobStatus(searchJobID string, cookies []*http.Cookie, opts ...CallOption) (*SearchJobStatusResponse, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("search/jobs/%s", searchJobID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
 	for _, v := range cookies {
 		req.AddCookie(v)
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, responseBody, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	responseBody, _ := ioutil.ReadAll(resp.Body)
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		var jobStatus = new(SearchJobStatusResponse)
-		err = json.Unmarshal(responseBody, &jobStatus)
+		err = c.decodeJSON(req, responseBody, jobStatus)
 		if err != nil {
 			return nil, err
 		}
 		return jobStatus, nil
+	case http.StatusUnauthorized:
+		return nil, ErrClientAuthenticationError
+	default:
+		return nil, searchAPIError(resp, responseBody)
+	}
+}
+
+// CloseSearchJob deletes a search job, canceling it if it's still running. Deleting jobs
+// once their results have been read frees their resources and keeps the number of
+// concurrent jobs under the account's limit. SearchJob.Delete is preferred.
+func (c *Client) CloseSearchJob(searchJobID string, opts ...CallOption) error {
+	return c.closeSearchJob(searchJobID, nil, opts...)
+}
+
+// closeSearchJob deletes a job with cookies, or the job's session cookies when cookies
+// is nil.
+func (c *Client) closeSearchJob(searchJobID string, cookies []*http.Cookie, opts ...CallOption) error {
+	if c.SearchCoalescer != nil && !c.SearchCoalescer.release(searchJobID) {
+		// Other callers still use the shared job.
+		return nil
+	}
+	err := c.searchBackend().DeleteSearchJob(searchJobID, c.searchSession(searchJobID, cookies), opts...)
+	if err == nil || errors.Is(err, ErrJobNotFound) {
+		c.forgetSearchSession(searchJobID)
+	}
+	return c.releaseSearchSlot(searchJobID, err)
+}
+
+// DeleteSearchJob deletes a search job, sending cookies as its session cookies when they
+// aren't nil.
+//
+// Deprecated: Use CloseSearchJob. The client keeps the session cookies itself.
+func (c *Client) DeleteSearchJob(searchJobID string, cookies []*http.Cookie, opts ...CallOption) error {
+	return c.closeSearchJob(searchJobID, cookies, opts...)
+}
+
+func (c *Client) deleteSearchJob(searchJobID string, cookies []*http.Cookie, opts ...CallOption) error {
+	req, err := c.newRequest("DELETE", fmt.Sprintf("search/jobs/%s", searchJobID), nil, opts...)
+	if err != nil {
+		return err
+	}
+	for _, v := range cookies {
+		req.AddCookie(v)
+	}
+
+	resp, body, err := c.send(req)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusUnauthorized:
+		return ErrClientAuthenticationError
 	default:
-		return nil, fmt.Errorf("Status not OK : %v", resp.StatusCode)
+		return searchAPIError(resp, body)
+	}
+}
+
+// Delete deletes the search job. The search job must have been returned by StartSearchJob.
+func (sj *SearchJob) Delete(opts ...CallOption) error {
+	if sj.client == nil {
+		return fmt.Errorf("search job %s wasn't started by this client", sj.ID)
+	}
+	return sj.client.closeSearchJob(sj.ID, sj.cookies, append(append([]CallOption(nil), sj.opts...), opts...)...)
+}
+
+// searchJobPollInterval is the delay between status checks while waiting on a search job.
+var searchJobPollInterval = time.Second
+
+// searchJobDeleteTimeout bounds the deletion of a search job whose caller's context may
+// already be done.
+const searchJobDeleteTimeout = 10 * time.Second
+
+// WaitForMessages waits until at least n messages are available, without waiting for
+// the job to finish, so the first results can be shown while the rest are gathered.
+// It returns early with the final status when the job finishes with fewer messages.
+// The search job must have been returned by StartSearchJob.
+func (sj *SearchJob) WaitForMessages(ctx context.Context, n int) (*SearchJobStatusResponse, error) {
+	return sj.wait(ctx, searchJobPollInterval, false, func(status *SearchJobStatusResponse) bool {
+		return status.MessageCount >= n
+	})
+}
+
+// searchJobKeepalive is how often a search job must be polled; the API cancels jobs
+// that go unpolled for 5 minutes, so backoff never waits longer than this.
+const searchJobKeepalive = 4 * time.Minute
+
+// SearchJobError is returned when the API reports errors for a search job, e.g. a
+// query that doesn't parse.
+type SearchJobError struct {
+	ID     string
+	Errors []string
+}
+
+func (e *SearchJobError) Error() string {
+	return fmt.Sprintf("search job %s failed: %s", e.ID, strings.Join(e.Errors, "; "))
+}
+
+// WaitForCompletion polls the search job until it's done gathering results or has been
+// force paused, and returns its final status. The delay between polls starts at
+// pollInterval and backs off with jitter, but never beyond the job's keepalive deadline.
+// It fails if the job is canceled, and with a *SearchJobError if the job reports errors.
+// The search job must have been returned by StartSearchJob.
+func (sj *SearchJob) WaitForCompletion(ctx context.Context, pollInterval time.Duration) (*SearchJobStatusResponse, error) {
+	return sj.wait(ctx, pollInterval, true, func(*SearchJobStatusResponse) bool {
+		return false
+	})
+}
+
+// wait polls the job's status until done returns true or the job finishes. With backoff,
+// the delay between polls grows by half each time, jittered by up to a fifth either way.
+func (sj *SearchJob) wait(ctx context.Context, pollInterval time.Duration, backoff bool, done func(*SearchJobStatusResponse) bool) (*SearchJobStatusResponse, error) {
+	if sj.client == nil {
+		return nil, fmt.Errorf("search job %s wasn't started by this client", sj.ID)
+	}
+	opts := append(append([]CallOption(nil), sj.opts...), WithContext(ctx))
+	pollOpts := append(opts[:len(opts):len(opts)], WithLowPriority())
+	delay := pollInterval
+	for {
+		status, err := sj.client.getSearchJob(sj.ID, sj.cookies, pollOpts...)
+		if err == ErrCallShed {
+			// Skip the poll while the search API is slow.
+			if err := sleepCallOptions(searchJobPollInterval, opts); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if backoff && len(status.PendingErrors) > 0 {
+			return status, &SearchJobError{ID: sj.ID, Errors: status.PendingErrors}
+		}
+		if done(status) || status.State == "DONE GATHERING RESULTS" || status.State == "FORCE PAUSED" {
+			return status, nil
+		}
+		if status.State == "CANCELED" {
+			return nil, fmt.Errorf("search job %s was canceled", sj.ID)
+		}
+
+		wait := delay
+		if backoff {
+			wait = time.Duration(float64(delay) * (0.8 + 0.4*rand.Float64()))
+			delay = delay * 3 / 2
+		}
+		if wait > searchJobKeepalive {
+			wait = searchJobKeepalive
+		}
+		if delay > searchJobKeepalive {
+			delay = searchJobKeepalive
+		}
+		if err := sleepCallOptions(wait, opts); err != nil {
+			return nil, err
+		}
 	}
 }
 
@@ -174,40 +384,264 @@ type SearchJobResult struct {
 	Messages []*SearchJobResultMessage `json:"messages"`
 }
 
-// GetSearchResults will retrieve the messages from a finished search job.
-func (c *Client) GetSearchResults(sjrr SearchJobResultsRequest, cookies []*http.Cookie) (*SearchJobResult, error) {
-	relativeURL, _ := url.Parse(fmt.Sprintf("search/jobs/%s/messages", sjrr.ID))
-	url := c.EndpointURL.ResolveReference(relativeURL)
-	req, err := http.NewRequest("GET", url.String(), nil)
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Basic "+c.AuthToken)
-	for _, v := range cookies {
-		req.AddCookie(v)
+// GetSearchJobMessages will retrieve the messages from a finished search job.
+// SearchJob.GetMessages is preferred.
+func (c *Client) GetSearchJobMessages(sjrr SearchJobResultsRequest, opts ...CallOption) (*SearchJobResult, error) {
+	return c.getSearchJobMessages(sjrr, nil, opts...)
+}
+
+// getSearchJobMessages retrieves messages with cookies, or the job's session cookies
+// when cookies is nil.
+func (c *Client) getSearchJobMessages(sjrr SearchJobResultsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobResult, error) {
+	searchResult, err := c.searchBackend().GetSearchResults(sjrr, c.searchSession(sjrr.ID, cookies), opts...)
+	c.searchJobUsed(sjrr.ID, err)
+	if err != nil {
+		return nil, err
 	}
-	q := req.URL.Query()
+	if fields := collectCallOptions(opts).resultFields; len(fields) > 0 && c.SearchBackend != nil {
+		pruneSearchJobResult(searchResult, fields)
+	}
+	if c.Redactor != nil {
+		c.Redactor.RedactResult(searchResult)
+	}
+	return searchResult, nil
+}
+
+// GetSearchResults will retrieve the messages from a finished search job, sending cookies
+// as its session cookies when they aren't nil.
+//
+// Deprecated: Use GetSearchJobMessages. The client keeps the session cookies itself.
+func (c *Client) GetSearchResults(sjrr SearchJobResultsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobResult, error) {
+	return c.getSearchJobMessages(sjrr, cookies, opts...)
+}
+
+func (c *Client) getSearchResults(sjrr SearchJobResultsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobResult, error) {
+	q := url.Values{}
 	q.Add("offset", strconv.Itoa(sjrr.Offset))
 	q.Add("limit", strconv.Itoa(sjrr.Limit))
-	req.URL.RawQuery = q.Encode()
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	req, err := c.newRequest("GET", fmt.Sprintf("search/jobs/%s/messages?%s", sjrr.ID, q.Encode()), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	for _, v := range cookies {
+		req.AddCookie(v)
+	}
 
-	responseBody, _ := ioutil.ReadAll(resp.Body)
+	resp, responseBody, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
 
 	switch resp.StatusCode {
 	case http.StatusOK:
+		if fields := collectCallOptions(opts).resultFields; len(fields) > 0 {
+			var pruned = newPrunedSearchJobResult(fields)
+			err = c.decodeJSON(req, responseBody, pruned)
+			if err != nil {
+				return nil, err
+			}
+			return &pruned.result, nil
+		}
 		var searchResult = new(SearchJobResult)
-		err = json.Unmarshal(responseBody, &searchResult)
+		err = c.decodeJSON(req, responseBody, searchResult)
 		if err != nil {
 			return nil, err
 		}
 		return searchResult, nil
+	case http.StatusUnauthorized:
+		return nil, ErrClientAuthenticationError
+	default:
+		return nil, searchAPIError(resp, responseBody)
+	}
+
+}
+
+// SearchJobRecordsRequest is a wrapper for the search job records params.
+type SearchJobRecordsRequest struct {
+	ID     string `json:"searchJobId"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+// SearchJobRecord is one row of an aggregate query's result, e.g. one timeslice of a count.
+// The API returns every value as a string; see SearchJobRecordsResult.Typed.
+type SearchJobRecord struct {
+	Map map[string]string `json:"map"`
+}
+
+// SearchJobRecordsResult holds the records of an aggregate query.
+type SearchJobRecordsResult struct {
+	Fields  []*SearchJobResultField `json:"fields"`
+	Records []*SearchJobRecord      `json:"records"`
+}
+
+// Typed returns the value of a record's field converted according to its field type:
+// int and long fields as int64, double as float64, boolean as bool and anything else,
+// or a value that doesn't convert, as a string. It returns nil for a missing field.
+func (r *SearchJobRecordsResult) Typed(record *SearchJobRecord, name string) interface{} {
+	value, ok := record.Map[name]
+	if !ok {
+		return nil
+	}
+	for _, f := range r.Fields {
+		if f.Name != name {
+			continue
+		}
+		switch f.FieldType {
+		case "int", "long":
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return v
+			}
+		case "double":
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				return v
+			}
+		case "boolean":
+			if v, err := strconv.ParseBool(value); err == nil {
+				return v
+			}
+		}
+		break
+	}
+	return value
+}
+
+// GetSearchJobRecords retrieves the records of an aggregate search job, such as one using
+// count, sum or timeslice. SearchJob.GetRecords is preferred.
+func (c *Client) GetSearchJobRecords(sjrr SearchJobRecordsRequest, opts ...CallOption) (*SearchJobRecordsResult, error) {
+	return c.getSearchJobRecords(sjrr, nil, opts...)
+}
+
+// getSearchJobRecords retrieves records with cookies, or the job's session cookies when
+// cookies is nil.
+func (c *Client) getSearchJobRecords(sjrr SearchJobRecordsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobRecordsResult, error) {
+	records, err := c.searchBackend().GetSearchRecords(sjrr, c.searchSession(sjrr.ID, cookies), opts...)
+	c.searchJobUsed(sjrr.ID, err)
+	return records, err
+}
+
+// GetSearchRecords retrieves the records of an aggregate search job, sending cookies as
+// its session cookies when they aren't nil.
+//
+// Deprecated: Use GetSearchJobRecords. The client keeps the session cookies itself.
+func (c *Client) GetSearchRecords(sjrr SearchJobRecordsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobRecordsResult, error) {
+	return c.getSearchJobRecords(sjrr, cookies, opts...)
+}
+
+func (c *Client) getSearchRecords(sjrr SearchJobRecordsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobRecordsResult, error) {
+	q := url.Values{}
+	q.Add("offset", strconv.Itoa(sjrr.Offset))
+	q.Add("limit", strconv.Itoa(sjrr.Limit))
+
+	req, err := c.newRequest("GET", fmt.Sprintf("search/jobs/%s/records?%s", sjrr.ID, q.Encode()), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range cookies {
+		req.AddCookie(v)
+	}
+
+	resp, responseBody, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var recordsResult = new(SearchJobRecordsResult)
+		err = c.decodeJSON(req, responseBody, recordsResult)
+		if err != nil {
+			return nil, err
+		}
+		return recordsResult, nil
+	case http.StatusUnauthorized:
+		return nil, ErrClientAuthenticationError
 	default:
-		return nil, fmt.Errorf("Status not OK : %v", resp.StatusCode)
+		return nil, searchAPIError(resp, responseBody)
+	}
+}
+
+// GetStatus retrieves the status of the search job. The search job must have been
+// returned by StartSearchJob.
+func (sj *SearchJob) GetStatus(opts ...CallOption) (*SearchJobStatusResponse, error) {
+	if sj.client == nil {
+		return nil, fmt.Errorf("search job %s wasn't started by this client", sj.ID)
+	}
+	return sj.client.getSearchJob(sj.ID, sj.cookies, append(append([]CallOption(nil), sj.opts...), opts...)...)
+}
+
+// GetMessages retrieves a page of the search job's messages. The search job must have
+// been returned by StartSearchJob.
+func (sj *SearchJob) GetMessages(offset, limit int, opts ...CallOption) (*SearchJobResult, error) {
+	if sj.client == nil {
+		return nil, fmt.Errorf("search job %s wasn't started by this client", sj.ID)
 	}
+	return sj.client.getSearchJobMessages(SearchJobResultsRequest{
+		ID:     sj.ID,
+		Offset: offset,
+		Limit:  limit,
+	}, sj.cookies, append(append([]CallOption(nil), sj.opts...), opts...)...)
+}
 
+// GetRecords retrieves a page of the search job's records. The search job must have
+// been returned by StartSearchJob.
+func (sj *SearchJob) GetRecords(offset, limit int, opts ...CallOption) (*SearchJobRecordsResult, error) {
+	if sj.client == nil {
+		return nil, fmt.Errorf("search job %s wasn't started by this client", sj.ID)
+	}
+	return sj.client.getSearchJobRecords(SearchJobRecordsRequest{
+		ID:     sj.ID,
+		Offset: offset,
+		Limit:  limit,
+	}, sj.cookies, append(append([]CallOption(nil), sj.opts...), opts...)...)
 }
+
+// searchMessages runs a search to completion, polling its status every pollInterval,
+// and returns all of the messages it found. The search job is deleted before it returns.
+func (c *Client) searchMessages(ssr StartSearchRequest, pollInterval time.Duration, opts ...CallOption) ([]*SearchJobResultMessage, error) {
+	sj, err := c.StartSearchJob(ssr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// The job counts against the concurrent search limit until it's deleted, so delete
+	// it even when the call's context is done.
+	defer c.CloseSearchJob(sj.ID, append(append([]CallOption(nil), opts...), WithContext(context.Background()))...)
+
+	var status *SearchJobStatusResponse
+	for {
+		status, err = c.GetSearchJob(sj.ID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if status.State == "DONE GATHERING RESULTS" || status.State == "FORCE PAUSED" {
+			break
+		}
+		if status.State == "CANCELED" {
+			return nil, fmt.Errorf("search job %s was canceled", sj.ID)
+		}
+		if err := sleepCallOptions(pollInterval, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	var messages []*SearchJobResultMessage
+	for offset := 0; offset < status.MessageCount; offset += searchResultsPageLimit {
+		result, err := c.GetSearchJobMessages(SearchJobResultsRequest{
+			ID:     sj.ID,
+			Offset: offset,
+			Limit:  searchResultsPageLimit,
+		}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Messages) == 0 {
+			break
+		}
+		messages = append(messages, result.Messages...)
+	}
+	return messages, nil
+}
+
+// searchResultsPageLimit is the largest page the messages endpoint returns.
+const searchResultsPageLimit = 10000
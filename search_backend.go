@@ -0,0 +1,61 @@
+package sumologic
+
+import "net/http"
+
+// SearchBackend runs search jobs for a Client. Every search the client makes, including
+// through SearchJob methods, iterators and query groups, goes through its backend, so a
+// newer search API can be supported by setting one without changing callers. Backends
+// return search jobs with their session cookies, which the client keeps; the client ties the job to itself.
+type SearchBackend interface {
+	StartSearch(ssr StartSearchRequest, opts ...CallOption) (*SearchJob, []*http.Cookie, error)
+	GetSearchJobStatus(searchJobID string, cookies []*http.Cookie, opts ...CallOption) (*SearchJobStatusResponse, error)
+	GetSearchResults(sjrr SearchJobResultsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobResult, error)
+	GetSearchRecords(sjrr SearchJobRecordsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobRecordsResult, error)
+	DeleteSearchJob(searchJobID string, cookies []*http.Cookie, opts ...CallOption) error
+}
+
+// WithSearchBackend makes the client run searches with backend instead of the v1
+// Search Job API.
+func WithSearchBackend(backend SearchBackend) ClientOption {
+	return func(c *Client) {
+		c.SearchBackend = backend
+	}
+}
+
+// SearchJobAPI returns the backend for the v1 Search Job API that the client uses when
+// it has no SearchBackend, so custom backends can fall back to it or wrap it.
+func (c *Client) SearchJobAPI() SearchBackend {
+	return searchJobAPI{c}
+}
+
+func (c *Client) searchBackend() SearchBackend {
+	if c.SearchBackend != nil {
+		return c.SearchBackend
+	}
+	return searchJobAPI{c}
+}
+
+// searchJobAPI is the SearchBackend for the v1 Search Job API.
+type searchJobAPI struct {
+	c *Client
+}
+
+func (a searchJobAPI) StartSearch(ssr StartSearchRequest, opts ...CallOption) (*SearchJob, []*http.Cookie, error) {
+	return a.c.postSearchJob(ssr, opts...)
+}
+
+func (a searchJobAPI) GetSearchJobStatus(searchJobID string, cookies []*http.Cookie, opts ...CallOption) (*SearchJobStatusResponse, error) {
+	return a.c.getSearchJobStatus(searchJobID, cookies, opts...)
+}
+
+func (a searchJobAPI) GetSearchResults(sjrr SearchJobResultsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobResult, error) {
+	return a.c.getSearchResults(sjrr, cookies, opts...)
+}
+
+func (a searchJobAPI) GetSearchRecords(sjrr SearchJobRecordsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobRecordsResult, error) {
+	return a.c.getSearchRecords(sjrr, cookies, opts...)
+}
+
+func (a searchJobAPI) DeleteSearchJob(searchJobID string, cookies []*http.Cookie, opts ...CallOption) error {
+	return a.c.deleteSearchJob(searchJobID, cookies, opts...)
+}
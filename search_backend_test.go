@@ -0,0 +1,67 @@
+package sumologic
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+// memorySearchBackend finishes every search at once with canned messages.
+type memorySearchBackend struct {
+	messages []*SearchJobResultMessage
+	deleted  []string
+}
+
+func (b *memorySearchBackend) StartSearch(ssr StartSearchRequest, opts ...CallOption) (*SearchJob, []*http.Cookie, error) {
+	return &SearchJob{ID: "memory"}, nil, nil
+}
+
+func (b *memorySearchBackend) GetSearchJobStatus(searchJobID string, cookies []*http.Cookie, opts ...CallOption) (*SearchJobStatusResponse, error) {
+	return &SearchJobStatusResponse{State: "DONE GATHERING RESULTS", MessageCount: len(b.messages)}, nil
+}
+
+func (b *memorySearchBackend) GetSearchResults(sjrr SearchJobResultsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobResult, error) {
+	return &SearchJobResult{Messages: b.messages}, nil
+}
+
+func (b *memorySearchBackend) GetSearchRecords(sjrr SearchJobRecordsRequest, cookies []*http.Cookie, opts ...CallOption) (*SearchJobRecordsResult, error) {
+	return &SearchJobRecordsResult{}, nil
+}
+
+func (b *memorySearchBackend) DeleteSearchJob(searchJobID string, cookies []*http.Cookie, opts ...CallOption) error {
+	b.deleted = append(b.deleted, searchJobID)
+	return nil
+}
+
+func TestWithSearchBackend(t *testing.T) {
+	backend := &memorySearchBackend{messages: []*SearchJobResultMessage{
+		{Map: map[string]interface{}{"_raw": "login from bob@example.com"}},
+	}}
+	c, _ := NewClient("accessToken", "https://api.sumologic.com/api/v1/", WithSearchBackend(backend))
+	c.Redactor = NewRedactor(RedactionRule{Pattern: regexp.MustCompile(`\S+@\S+`), Replacement: "[EMAIL]"})
+
+	sj, _, err := c.StartSearch(StartSearchRequest{Query: "login"})
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	if _, err := sj.WaitForCompletion(context.Background(), 0); err != nil {
+		t.Errorf("WaitForCompletion() returned an error: %s", err)
+		return
+	}
+	result, err := c.GetSearchResults(SearchJobResultsRequest{ID: sj.ID, Limit: 10}, nil)
+	if err != nil {
+		t.Errorf("GetSearchResults() returned an error: %s", err)
+		return
+	}
+	if raw := result.Messages[0].Map["_raw"]; raw != "login from [EMAIL]" {
+		t.Errorf("Expected results from the backend to be redacted, got %q", raw)
+	}
+	if err := sj.Delete(); err != nil || len(backend.deleted) != 1 {
+		t.Errorf("Expected SearchJob.Delete() to go through the backend, got %v", err)
+	}
+	if _, ok := c.SearchJobAPI().(searchJobAPI); !ok {
+		t.Errorf("Expected SearchJobAPI() to return the v1 backend")
+	}
+}
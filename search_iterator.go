@@ -0,0 +1,125 @@
+package sumologic
+
+import "context"
+
+// SearchMessagesIterator pages lazily through the messages of a search job. It implements
+// MessageIterator, so it can feed the sinks and exporters.
+type SearchMessagesIterator struct {
+	sj       *SearchJob
+	pageSize int
+	opts     []CallOption
+
+	page   []*SearchJobResultMessage
+	pos    int
+	offset int
+	// count is the number of messages the job had at the last status check and finished
+	// whether it has stopped gathering more.
+	count    int
+	finished bool
+	err      error
+}
+
+// MessagesIterator returns an iterator over the search job's messages, fetching pageSize
+// messages at a time, up to 10000. It can start before the job is done: when it runs out
+// of messages it waits for more, and it stops at the job's final message count. Requests
+// go through the client's rate limit and retries. The search job must have been returned
+// by StartSearchJob.
+func (sj *SearchJob) MessagesIterator(pageSize int, opts ...CallOption) *SearchMessagesIterator {
+	if pageSize <= 0 || pageSize > searchResultsPageLimit {
+		pageSize = searchResultsPageLimit
+	}
+	return &SearchMessagesIterator{sj: sj, pageSize: pageSize, opts: opts, pos: -1}
+}
+
+// Next advances to the next message. It returns false when there are no more messages or
+// an error stopped the iteration; Err tells which.
+func (it *SearchMessagesIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.pos++
+	if it.pos < len(it.page) {
+		return true
+	}
+
+	if it.offset >= it.count {
+		if it.finished {
+			return false
+		}
+		ctx := collectCallOptions(it.opts).ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		offset := it.offset
+		status, err := it.sj.wait(ctx, searchJobPollInterval, false, func(status *SearchJobStatusResponse) bool {
+			return status.MessageCount > offset
+		})
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.count = status.MessageCount
+		it.finished = status.State == "DONE GATHERING RESULTS" || status.State == "FORCE PAUSED"
+		if it.offset >= it.count {
+			return false
+		}
+	}
+
+	limit := it.count - it.offset
+	if limit > it.pageSize {
+		limit = it.pageSize
+	}
+	opts := append(append([]CallOption(nil), it.sj.opts...), it.opts...)
+	result, err := it.sj.client.getSearchJobMessages(SearchJobResultsRequest{
+		ID:     it.sj.ID,
+		Offset: it.offset,
+		Limit:  limit,
+	}, it.sj.cookies, opts...)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if len(result.Messages) == 0 {
+		return false
+	}
+	it.page, it.pos = result.Messages, 0
+	it.offset += len(result.Messages)
+	return true
+}
+
+// Message returns the current message.
+func (it *SearchMessagesIterator) Message() *SearchJobResultMessage {
+	return it.page[it.pos]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *SearchMessagesIterator) Err() error {
+	return it.err
+}
+
+// StreamMessages fetches the search job's messages in the background, pageSize at a time,
+// and sends them on the returned channel, starting while the job is still gathering
+// results. The message channel is closed once every message has been sent, the context is
+// done or fetching fails; the error channel then receives the error, if any, and is closed.
+// The search job must have been returned by StartSearchJob.
+func (sj *SearchJob) StreamMessages(ctx context.Context, pageSize int) (<-chan SearchJobResultMessage, <-chan error) {
+	it := sj.MessagesIterator(pageSize, WithContext(ctx))
+	messages := make(chan SearchJobResultMessage, it.pageSize)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(messages)
+		for it.Next() {
+			select {
+			case messages <- *it.Message():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+	return messages, errs
+}
@@ -0,0 +1,114 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newIteratorTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	statuses := []SearchJobStatusResponse{
+		{State: "GATHERING RESULTS", MessageCount: 3},
+		{State: "GATHERING RESULTS", MessageCount: 3},
+		{State: "DONE GATHERING RESULTS", MessageCount: 5},
+	}
+	polls := 0
+	pages := new([]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/search/jobs":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+		case "/search/jobs/123":
+			w.WriteHeader(http.StatusOK)
+			body, _ := json.Marshal(statuses[polls])
+			polls++
+			w.Write(body)
+		case "/search/jobs/123/messages":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			*pages = append(*pages, fmt.Sprintf("%d+%d", offset, limit))
+			var messages []*SearchJobResultMessage
+			for i := offset; i < offset+limit; i++ {
+				messages = append(messages, &SearchJobResultMessage{Map: map[string]interface{}{"n": strconv.Itoa(i)}})
+			}
+			w.WriteHeader(http.StatusOK)
+			body, _ := json.Marshal(SearchJobResult{Messages: messages})
+			w.Write(body)
+		default:
+			t.Errorf("Unexpected request to ‘%s’", r.URL.EscapedPath())
+		}
+	}))
+	return ts, pages
+}
+
+func TestMessagesIterator(t *testing.T) {
+	searchJobPollInterval = time.Millisecond
+	defer func() { searchJobPollInterval = time.Second }()
+
+	ts, pages := newIteratorTestServer(t)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	sj, _, err := c.StartSearch(StartSearchRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+
+	var it MessageIterator = sj.MessagesIterator(2)
+	var got []string
+	for it.Next() {
+		got = append(got, messageField(it.Message(), "n"))
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Iterator returned error: %v", err)
+		return
+	}
+	if fmt.Sprint(got) != "[0 1 2 3 4]" {
+		t.Errorf("Expected messages 0 to 4, got %v", got)
+	}
+	if fmt.Sprint(*pages) != "[0+2 2+1 3+2]" {
+		t.Errorf("Expected pages [0+2 2+1 3+2], got %v", *pages)
+	}
+}
+
+func TestStreamMessages(t *testing.T) {
+	searchJobPollInterval = time.Millisecond
+	defer func() { searchJobPollInterval = time.Second }()
+
+	ts, _ := newIteratorTestServer(t)
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	sj, _, err := c.StartSearch(StartSearchRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+
+	messages, errs := sj.StreamMessages(context.Background(), 2)
+	var got []string
+	for m := range messages {
+		got = append(got, messageField(&m, "n"))
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("StreamMessages() returned error: %v", err)
+	}
+	if fmt.Sprint(got) != "[0 1 2 3 4]" {
+		t.Errorf("Expected messages 0 to 4, got %v", got)
+	}
+}
@@ -0,0 +1,167 @@
+package sumologic
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrentSearchLimit is how many search jobs an organization can have open at
+// once before the API rejects new ones.
+const DefaultConcurrentSearchLimit = 200
+
+// SearchSlotStore holds the slots of open search jobs against a limit. Give several
+// clients the same store to share an organization's limit between them; a store backed by
+// a shared database shares it between processes.
+type SearchSlotStore interface {
+	// TryAcquire takes a slot for the key when fewer than limit slots are held, and
+	// reports whether it did.
+	TryAcquire(key string, limit int) (bool, error)
+	// Release frees the key's slot.
+	Release(key string) error
+}
+
+// SearchQuota keeps a client's StartSearchJob calls within the concurrent search limit.
+// Each search job takes a slot from its store when it's started, waiting for one to free
+// up when the limit is reached, and gives it back when it's deleted through the client
+// or the API reports it's canceled or gone. Jobs that are never deleted hold their slot
+// until the API expires them, so delete search jobs once their results have been read.
+type SearchQuota struct {
+	// Limit is the number of slots, DefaultConcurrentSearchLimit when zero.
+	Limit int
+	// Store holds the slots, a MemorySearchSlotStore of the quota's own when nil.
+	Store SearchSlotStore
+	// PollInterval is the delay between attempts to take a slot, a second by default.
+	PollInterval time.Duration
+
+	mu   sync.Mutex
+	jobs map[string]string
+}
+
+// WithSearchQuota makes the client wait for a slot of q before starting a search job.
+func WithSearchQuota(q *SearchQuota) ClientOption {
+	return func(c *Client) {
+		c.SearchQuota = q
+	}
+}
+
+// Open returns the number of search jobs started through the quota and not yet deleted.
+func (q *SearchQuota) Open() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.jobs)
+}
+
+// acquire waits until it takes a slot, or the context of the call options is done, and
+// returns the slot's key.
+func (q *SearchQuota) acquire(opts []CallOption) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	key := hex.EncodeToString(b)
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultConcurrentSearchLimit
+	}
+	pollInterval := q.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	for {
+		ok, err := q.store().TryAcquire(key, limit)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return key, nil
+		}
+		if err := sleepCallOptions(pollInterval, opts); err != nil {
+			return "", err
+		}
+	}
+}
+
+// started records that the search job holds the slot.
+func (q *SearchQuota) started(jobID, key string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.jobs == nil {
+		q.jobs = make(map[string]string)
+	}
+	q.jobs[jobID] = key
+}
+
+// release frees the slot of the search job, if it holds one.
+func (q *SearchQuota) release(jobID string) error {
+	q.mu.Lock()
+	key, ok := q.jobs[jobID]
+	delete(q.jobs, jobID)
+	q.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return q.store().Release(key)
+}
+
+func (q *SearchQuota) store() SearchSlotStore {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.Store == nil {
+		q.Store = NewMemorySearchSlotStore()
+	}
+	return q.Store
+}
+
+// MemorySearchSlotStore is a SearchSlotStore that holds slots in memory, shared by the
+// clients of a process.
+type MemorySearchSlotStore struct {
+	mu    sync.Mutex
+	slots map[string]bool
+}
+
+// NewMemorySearchSlotStore returns an empty MemorySearchSlotStore.
+func NewMemorySearchSlotStore() *MemorySearchSlotStore {
+	return &MemorySearchSlotStore{slots: make(map[string]bool)}
+}
+
+// TryAcquire takes a slot for the key when fewer than limit slots are held.
+func (s *MemorySearchSlotStore) TryAcquire(key string, limit int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.slots) >= limit {
+		return false, nil
+	}
+	s.slots[key] = true
+	return true, nil
+}
+
+// Release frees the key's slot.
+func (s *MemorySearchSlotStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.slots, key)
+	return nil
+}
+
+// InUse returns the number of slots held.
+func (s *MemorySearchSlotStore) InUse() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.slots)
+}
+
+// releaseSearchSlot frees the slot of a search job once the job is gone: deleted,
+// canceled, expired, or reported missing by the API.
+func (c *Client) releaseSearchSlot(jobID string, err error) error {
+	if c.SearchQuota == nil || (err != nil && !errors.Is(err, ErrJobNotFound)) {
+		return err
+	}
+	if rerr := c.SearchQuota.release(jobID); rerr != nil && err == nil {
+		return rerr
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+package sumologic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSearchQuotaWaitsForFreeSlot(t *testing.T) {
+	var started int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			n := atomic.AddInt32(&started, 1)
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"id": "job%d"}`, n)
+		case "DELETE":
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	store := NewMemorySearchSlotStore()
+	quota := &SearchQuota{Limit: 1, Store: store, PollInterval: 10 * time.Millisecond}
+	c, _ := NewClient("accessToken", ts.URL, WithSearchQuota(quota))
+
+	first, _, err := c.StartSearch(StartSearchRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	if store.InUse() != 1 || quota.Open() != 1 {
+		t.Errorf("Expected the first search to hold the only slot, got %d in use", store.InUse())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, _, err := c.StartSearch(StartSearchRequest{Query: "error"}, WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Errorf("Expected the second search to wait for a slot until its deadline, got %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := c.StartSearch(StartSearchRequest{Query: "error"})
+		done <- err
+	}()
+	time.Sleep(30 * time.Millisecond)
+	if err := first.Delete(); err != nil {
+		t.Errorf("Delete() returned an error: %s", err)
+		return
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("StartSearch() returned an error: %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Expected the waiting search to start once the first was deleted")
+	}
+	if atomic.LoadInt32(&started) != 2 {
+		t.Errorf("Expected 2 searches to reach the API, got %d", started)
+	}
+}
+
+func TestSearchQuotaReleasesFailedStart(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status": 400, "code": "searchjob.invalid.query", "message": "Invalid query"}`))
+	}))
+	defer ts.Close()
+
+	store := NewMemorySearchSlotStore()
+	c, _ := NewClient("accessToken", ts.URL, WithSearchQuota(&SearchQuota{Limit: 1, Store: store}))
+	if _, _, err := c.StartSearch(StartSearchRequest{Query: "| bad"}); err == nil {
+		t.Errorf("Expected an error for an invalid query")
+	}
+	if store.InUse() != 0 {
+		t.Errorf("Expected the slot of the failed search to be released, got %d in use", store.InUse())
+	}
+}
+
+func TestSearchQuotaSearchMessagesReleasesSlot(t *testing.T) {
+	var deleted int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "job1"}`))
+		case r.Method == "DELETE":
+			atomic.AddInt32(&deleted, 1)
+			w.WriteHeader(http.StatusOK)
+		case r.Header.Get("X-Test-State") == "canceled":
+			w.Write([]byte(`{"state": "CANCELED"}`))
+		default:
+			w.Write([]byte(`{"state": "DONE GATHERING RESULTS", "messageCount": 0}`))
+		}
+	}))
+	defer ts.Close()
+
+	quota := &SearchQuota{Limit: 1, PollInterval: 10 * time.Millisecond}
+	c, _ := NewClient("accessToken", ts.URL, WithSearchQuota(quota))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if _, err := c.searchMessages(StartSearchRequest{Query: "error"}, time.Millisecond, WithContext(ctx)); err != nil {
+			t.Errorf("searchMessages() returned an error: %s", err)
+			return
+		}
+	}
+	if _, err := c.searchMessages(StartSearchRequest{Query: "error"}, time.Millisecond, WithContext(ctx), WithHeader("X-Test-State", "canceled")); err == nil {
+		t.Errorf("Expected an error for a canceled search")
+	}
+	if quota.Open() != 0 || atomic.LoadInt32(&deleted) != 3 {
+		t.Errorf("Expected every search to be deleted and give back its slot, got %d open and %d deleted", quota.Open(), deleted)
+	}
+}
+
+func TestSearchQuotaReleasesCanceledAndExpiredJobs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "node-7"})
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"id": "%s"}`, r.Header.Get("X-Test-Job"))
+		default:
+			w.Write([]byte(`{"state": "CANCELED"}`))
+		}
+	}))
+	defer ts.Close()
+
+	quota := &SearchQuota{Limit: 1, PollInterval: 10 * time.Millisecond}
+	c, _ := NewClient("accessToken", ts.URL, WithSearchQuota(quota))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := func(id string) *SearchJob {
+		sj, _, err := c.StartSearch(StartSearchRequest{Query: "error"}, WithContext(ctx), WithHeader("X-Test-Job", id))
+		if err != nil {
+			t.Fatalf("StartSearch(%s) returned an error: %s", id, err)
+		}
+		return sj
+	}
+
+	canceled := start("canceled")
+	if _, err := canceled.GetStatus(); err != nil {
+		t.Fatalf("GetStatus() returned an error: %s", err)
+	}
+	if quota.Open() != 0 {
+		t.Errorf("Expected the canceled job to give back its slot, got %d open", quota.Open())
+	}
+
+	start("stale")
+	c.searchSessions["stale"].used = time.Now().Add(-searchSessionTTL - time.Minute)
+	start("fresh")
+	if quota.Open() != 1 {
+		t.Errorf("Expected the expired job to give back its slot, got %d open", quota.Open())
+	}
+}
@@ -0,0 +1,273 @@
+package sumologic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// canonicalSearchRequest is the serialized form of a StartSearchRequest. Its fields are
+// marshaled in this order.
+type canonicalSearchRequest struct {
+	Query    string `json:"query"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	TimeZone string `json:"timeZone"`
+}
+
+// CanonicalSearchRequest returns a stable serialization of a search request, the same for
+// requests running the same search: the query's lines are trimmed, runs of spaces and
+// tabs outside quoted strings are collapsed and blank lines dropped, and From and To are
+// resolved to UTC with millisecond precision. It suits cache keys, matching identical
+// searches and audit logs. Times are read as epoch milliseconds, RFC 3339, or ISO 8601
+// without an offset in TimeZone, as the API reads them.
+func CanonicalSearchRequest(ssr StartSearchRequest) ([]byte, error) {
+	loc := time.UTC
+	if ssr.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(ssr.TimeZone); err != nil {
+			return nil, fmt.Errorf("search request has an unknown time zone `%s`", ssr.TimeZone)
+		}
+	}
+	from, err := resolveSearchTime(ssr.From, loc)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveSearchTime(ssr.To, loc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(canonicalSearchRequest{
+		Query:    normalizeQuery(ssr.Query),
+		From:     from,
+		To:       to,
+		TimeZone: ssr.TimeZone,
+	})
+}
+
+// SearchRequestKey returns the hex SHA-256 of the canonical serialization of a search
+// request, a short key for caches and in-flight searches.
+func SearchRequestKey(ssr StartSearchRequest) (string, error) {
+	b, err := CanonicalSearchRequest(ssr)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// searchTimeLayout is the API's ISO 8601 format without an offset.
+const searchTimeLayout = "2006-01-02T15:04:05"
+
+func resolveSearchTime(s string, loc *time.Location) (string, error) {
+	var t time.Time
+	var err error
+	if ms, perr := strconv.ParseInt(s, 10, 64); perr == nil {
+		t = time.UnixMilli(ms)
+	} else if t, err = time.Parse(time.RFC3339, s); err != nil {
+		if t, err = time.ParseInLocation(searchTimeLayout, s, loc); err != nil {
+			return "", fmt.Errorf("search request has an invalid time `%s`", s)
+		}
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000Z"), nil
+}
+
+// normalizeQuery trims the query's lines, collapses runs of spaces and tabs outside
+// double quoted strings and drops blank lines. Line breaks are kept since they end
+// comments.
+func normalizeQuery(query string) string {
+	var lines []string
+	for _, line := range strings.Split(query, "\n") {
+		var sb strings.Builder
+		quoted, escaped, space := false, false, false
+		for _, r := range strings.TrimSpace(line) {
+			switch {
+			case quoted:
+				if escaped {
+					escaped = false
+				} else if r == '\\' {
+					escaped = true
+				} else if r == '"' {
+					quoted = false
+				}
+			case r == ' ' || r == '\t' || r == '\r':
+				space = true
+				continue
+			case r == '"':
+				quoted = true
+			}
+			if space {
+				sb.WriteByte(' ')
+				space = false
+			}
+			sb.WriteRune(r)
+		}
+		if sb.Len() > 0 {
+			lines = append(lines, sb.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SearchCoalescer shares one search job among identical searches started while it's
+// open, so concurrent callers running the same search, e.g. dashboards refreshed by many
+// users, make one job. Each caller gets its own SearchJob, and the job is only deleted
+// once every caller has deleted it. Callers waiting on a job being started get the error
+// of starting it. A coalescer may be shared by several clients: jobs are only shared
+// among clients with the same credentials and endpoint. A job stops being shared once it's canceled or gone, or when no call
+// about it has been made for TTL, since the API expires jobs that aren't polled. It's
+// safe for concurrent use.
+type SearchCoalescer struct {
+	// Key identifies identical searches, SearchRequestKey when nil. Searches whose key
+	// fails aren't shared.
+	Key func(ssr StartSearchRequest) (string, error)
+	// TTL is how long a job is shared without a call about it, 4 minutes when zero.
+	TTL time.Duration
+
+	mu       sync.Mutex
+	inFlight map[string]*coalescedSearch
+	jobs     map[string]*coalescedSearch
+}
+
+type coalescedSearch struct {
+	key     string
+	started chan struct{}
+	job     *SearchJob
+	err     error
+	callers int
+	used    time.Time
+}
+
+// WithSearchCoalescer makes the client share search jobs among identical searches
+// through sc.
+func WithSearchCoalescer(sc *SearchCoalescer) ClientOption {
+	return func(c *Client) {
+		c.SearchCoalescer = sc
+	}
+}
+
+// InFlight returns the number of shared search jobs that are open.
+func (sc *SearchCoalescer) InFlight() int {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return len(sc.inFlight)
+}
+
+// start returns the open job of an identical search, or starts one with c.
+func (sc *SearchCoalescer) start(c *Client, ssr StartSearchRequest, opts []CallOption) (*SearchJob, []*http.Cookie, error) {
+	keyFunc := sc.Key
+	if keyFunc == nil {
+		keyFunc = SearchRequestKey
+	}
+	key, err := keyFunc(ssr)
+	if err != nil {
+		return c.startSearch(ssr, opts...)
+	}
+	key = c.coalescerKey(key)
+
+	sc.mu.Lock()
+	if sc.inFlight == nil {
+		sc.inFlight = make(map[string]*coalescedSearch)
+		sc.jobs = make(map[string]*coalescedSearch)
+	}
+	s, ok := sc.inFlight[key]
+	if ok && s.job != nil && time.Since(s.used) > sc.ttl() {
+		// The API has likely expired the job.
+		sc.drop(s)
+		ok = false
+	}
+	if ok {
+		s.callers++
+		sc.mu.Unlock()
+		<-s.started
+		if s.err != nil {
+			return nil, nil, s.err
+		}
+		sj := *s.job
+		sj.opts = opts
+		return &sj, sj.cookies, nil
+	}
+	s = &coalescedSearch{key: key, started: make(chan struct{}), callers: 1}
+	sc.inFlight[key] = s
+	sc.mu.Unlock()
+
+	sj, cookies, err := c.startSearch(ssr, opts...)
+	sc.mu.Lock()
+	if err != nil {
+		s.err = err
+		delete(sc.inFlight, key)
+	} else {
+		s.job, s.used = sj, time.Now()
+		sc.jobs[sj.ID] = s
+	}
+	sc.mu.Unlock()
+	close(s.started)
+	return sj, cookies, err
+}
+
+// coalescerKey qualifies a search's key with the client's credentials and endpoint, which
+// keep clients of different accounts sharing a coalescer apart.
+func (c *Client) coalescerKey(key string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s", c.AuthToken, c.EndpointURL, key)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// release drops a caller of the job and reports whether it was the last, so the job can
+// be deleted. Jobs that aren't shared are always deleted.
+func (sc *SearchCoalescer) release(jobID string) bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	s, ok := sc.jobs[jobID]
+	if !ok {
+		return true
+	}
+	s.callers--
+	if s.callers > 0 {
+		return false
+	}
+	sc.drop(s)
+	return true
+}
+
+// touch records a call about the job, which keeps it from expiring.
+func (sc *SearchCoalescer) touch(jobID string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if s, ok := sc.jobs[jobID]; ok {
+		s.used = time.Now()
+	}
+}
+
+// gone stops sharing a job that was canceled or no longer exists. Its callers delete it
+// without waiting for each other.
+func (sc *SearchCoalescer) gone(jobID string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if s, ok := sc.jobs[jobID]; ok {
+		sc.drop(s)
+	}
+}
+
+// drop forgets the shared job. sc.mu must be held.
+func (sc *SearchCoalescer) drop(s *coalescedSearch) {
+	if s.job != nil && sc.jobs[s.job.ID] == s {
+		delete(sc.jobs, s.job.ID)
+	}
+	if sc.inFlight[s.key] == s {
+		delete(sc.inFlight, s.key)
+	}
+}
+
+func (sc *SearchCoalescer) ttl() time.Duration {
+	if sc.TTL > 0 {
+		return sc.TTL
+	}
+	return searchJobKeepalive
+}
@@ -0,0 +1,158 @@
+package sumologic
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCanonicalSearchRequest(t *testing.T) {
+	a, err := CanonicalSearchRequest(StartSearchRequest{
+		Query:    "  _sourceCategory=web   error\n\n\t| parse \"a  b\"   as x  ",
+		From:     "2026-10-01T14:00:00",
+		To:       "2026-10-01T15:00:00+02:00",
+		TimeZone: "Europe/Berlin",
+	})
+	if err != nil {
+		t.Errorf("CanonicalSearchRequest() returned an error: %s", err)
+		return
+	}
+	b, _ := CanonicalSearchRequest(StartSearchRequest{
+		Query:    "_sourceCategory=web error\n| parse \"a  b\" as x",
+		From:     "1790856000000",
+		To:       "2026-10-01T13:00:00Z",
+		TimeZone: "Europe/Berlin",
+	})
+	expected := `{"query":"_sourceCategory=web error\n| parse \"a  b\" as x","from":"2026-10-01T12:00:00.000Z","to":"2026-10-01T13:00:00.000Z","timeZone":"Europe/Berlin"}`
+	if string(a) != expected || string(b) != expected {
+		t.Errorf("Expected both requests to serialize as %s, got %s and %s", expected, a, b)
+	}
+	if _, err := SearchRequestKey(StartSearchRequest{Query: "error", From: "yesterday", To: "1790856000000"}); err == nil {
+		t.Errorf("Expected an invalid time to fail")
+	}
+}
+
+func TestSearchCoalescer(t *testing.T) {
+	var starts, deletes int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			starts++
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+		case "DELETE":
+			deletes++
+		}
+	}))
+	defer ts.Close()
+
+	sc := new(SearchCoalescer)
+	c, _ := NewClient("accessToken", ts.URL, WithSearchCoalescer(sc))
+	ssr := StartSearchRequest{Query: "error", From: "1790856000000", To: "1790859600000"}
+	first, _, err := c.StartSearch(ssr)
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	ssr.Query = " error "
+	second, _, err := c.StartSearch(ssr)
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	if starts != 1 || first.ID != second.ID || first == second || sc.InFlight() != 1 {
+		t.Errorf("Expected one shared job, got %d starts and jobs %s and %s", starts, first.ID, second.ID)
+	}
+
+	first.Delete()
+	if deletes != 0 {
+		t.Errorf("Expected the shared job to be kept while in use, got %d deletes", deletes)
+	}
+	second.Delete()
+	if deletes != 1 || sc.InFlight() != 0 {
+		t.Errorf("Expected the job to be deleted by its last caller, got %d deletes", deletes)
+	}
+	c.StartSearch(ssr)
+	if starts != 2 {
+		t.Errorf("Expected a new job once the shared one was deleted, got %d starts", starts)
+	}
+}
+
+func TestSearchCoalescerSeparatesCredentials(t *testing.T) {
+	var starts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"id": "job%d"}`, atomic.AddInt32(&starts, 1))
+		}
+	}))
+	defer ts.Close()
+
+	sc := new(SearchCoalescer)
+	first, _ := NewClient("accountA", ts.URL, WithSearchCoalescer(sc))
+	second, _ := NewClient("accountB", ts.URL, WithSearchCoalescer(sc))
+	third, _ := NewClient("accountA", ts.URL, WithSearchCoalescer(sc))
+	ssr := StartSearchRequest{Query: "error", From: "1790856000000", To: "1790859600000"}
+	a, err := first.StartSearchJob(ssr)
+	if err != nil {
+		t.Fatalf("StartSearchJob() returned an error: %s", err)
+	}
+	b, err := second.StartSearchJob(ssr)
+	if err != nil {
+		t.Fatalf("StartSearchJob() returned an error: %s", err)
+	}
+	if a.ID == b.ID || starts != 2 {
+		t.Errorf("Expected clients with different credentials to get their own jobs, got %s and %s", a.ID, b.ID)
+	}
+	if c, err := third.StartSearchJob(ssr); err != nil || c.ID != a.ID {
+		t.Errorf("Expected clients with the same credentials to share the job %s, got %+v, %v", a.ID, c, err)
+	}
+}
+
+func TestSearchCoalescerStopsSharingExpiredJobs(t *testing.T) {
+	var starts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"id": "job%d"}`, atomic.AddInt32(&starts, 1))
+		case r.URL.EscapedPath() == "/search/jobs/job1":
+			w.Write([]byte(`{"state": "CANCELED"}`))
+		default:
+			w.Write([]byte(`{"state": "GATHERING RESULTS"}`))
+		}
+	}))
+	defer ts.Close()
+
+	sc := &SearchCoalescer{TTL: 50 * time.Millisecond}
+	c, _ := NewClient("accessToken", ts.URL, WithSearchCoalescer(sc))
+	ssr := StartSearchRequest{Query: "error", From: "1790856000000", To: "1790859600000"}
+	canceled, _, err := c.StartSearch(ssr)
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	canceled.GetStatus()
+	if sc.InFlight() != 0 {
+		t.Errorf("Expected a canceled job to stop being shared, got %d in flight", sc.InFlight())
+	}
+
+	second, _, _ := c.StartSearch(ssr)
+	if second == nil || second.ID != "job2" {
+		t.Errorf("Expected a new job after the shared one was canceled, got %v", second)
+		return
+	}
+	time.Sleep(30 * time.Millisecond)
+	second.GetStatus()
+	time.Sleep(30 * time.Millisecond)
+	if sj, _, _ := c.StartSearch(ssr); sj == nil || sj.ID != "job2" {
+		t.Errorf("Expected a job in use to stay shared, got %v", sj)
+	}
+	time.Sleep(80 * time.Millisecond)
+	if sj, _, _ := c.StartSearch(ssr); sj == nil || sj.ID != "job3" {
+		t.Errorf("Expected a job unused past the TTL to stop being shared, got %v", sj)
+	}
+}
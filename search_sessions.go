@@ -0,0 +1,94 @@
+package sumologic
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// The search API ties a job to the node that runs it with session cookies, which every
+// call about the job must send. The client keeps the cookies of the jobs it starts, so
+// callers don't have to thread them through. A job's cookies are dropped once it's
+// deleted, canceled or gone, or when it hasn't been used for searchSessionTTL, after
+// which the API has expired it.
+
+// searchSessionTTL is how long the API keeps a search job that isn't polled.
+const searchSessionTTL = 5 * time.Minute
+
+type searchSession struct {
+	cookies []*http.Cookie
+	used    time.Time
+}
+
+func (c *Client) rememberSearchSession(jobID string, cookies []*http.Cookie) {
+	if len(cookies) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.searchSessions == nil {
+		c.searchSessions = make(map[string]*searchSession)
+	}
+	c.searchSessions[jobID] = &searchSession{cookies: cookies, used: time.Now()}
+}
+
+// expireSearchSessions drops the sessions of jobs unused for searchSessionTTL, which the
+// API has expired, and frees their search slots.
+func (c *Client) expireSearchSessions() {
+	now := time.Now()
+	var expired []string
+	c.mu.Lock()
+	for id, s := range c.searchSessions {
+		if now.Sub(s.used) > searchSessionTTL {
+			delete(c.searchSessions, id)
+			expired = append(expired, id)
+		}
+	}
+	c.mu.Unlock()
+	for _, id := range expired {
+		c.releaseSearchSlot(id, nil)
+	}
+}
+
+// searchSession returns cookies, or the session cookies of the job when cookies is nil.
+func (c *Client) searchSession(jobID string, cookies []*http.Cookie) []*http.Cookie {
+	if cookies != nil {
+		return cookies
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.searchSessions[jobID]
+	if !ok {
+		return nil
+	}
+	s.used = time.Now()
+	return s.cookies
+}
+
+func (c *Client) forgetSearchSession(jobID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.searchSessions, jobID)
+}
+
+// searchJobUsed records the outcome of a call about a search job: the job is kept shared
+// by the client's SearchCoalescer, or forgotten once the API reports it's gone.
+func (c *Client) searchJobUsed(jobID string, err error) {
+	if errors.Is(err, ErrJobNotFound) {
+		c.searchJobGone(jobID)
+		return
+	}
+	if err == nil && c.SearchCoalescer != nil {
+		c.SearchCoalescer.touch(jobID)
+	}
+}
+
+// searchJobGone forgets a search job that was canceled or no longer exists, and frees its
+// search slot.
+func (c *Client) searchJobGone(jobID string) {
+	c.forgetSearchSession(jobID)
+	c.releaseSearchSlot(jobID, nil)
+	if c.SearchCoalescer != nil {
+		c.SearchCoalescer.gone(jobID)
+	}
+}
@@ -0,0 +1,140 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSearchSessionCookies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "node-7"})
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+			return
+		}
+		if cookie, err := r.Cookie("JSESSIONID"); err != nil || cookie.Value != "node-7" {
+			t.Errorf("Expected the job's session cookie on %s %s, got %v", r.Method, r.URL.EscapedPath(), r.Cookies())
+		}
+		switch {
+		case r.Method == "DELETE":
+		case r.URL.EscapedPath() == "/search/jobs/123/messages":
+			w.Write([]byte(`{"messages": [{"map": {"_raw": "error"}}]}`))
+		default:
+			w.Write([]byte(`{"state": "DONE GATHERING RESULTS", "messageCount": 1}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	var search SearchService = c
+	sj, err := search.StartSearchJob(StartSearchRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("StartSearchJob() returned an error: %s", err)
+		return
+	}
+	if _, err := search.GetSearchJob(sj.ID); err != nil {
+		t.Errorf("GetSearchJob() returned an error: %s", err)
+	}
+	if result, err := search.GetSearchJobMessages(SearchJobResultsRequest{ID: sj.ID, Limit: 10}); err != nil || len(result.Messages) != 1 {
+		t.Errorf("GetSearchJobMessages() returned %+v, %v", result, err)
+	}
+	if status, err := sj.GetStatus(); err != nil || status.MessageCount != 1 {
+		t.Errorf("GetStatus() returned %+v, %v", status, err)
+	}
+	if result, err := sj.GetMessages(0, 10); err != nil || len(result.Messages) != 1 {
+		t.Errorf("GetMessages() returned %+v, %v", result, err)
+	}
+	if err := search.CloseSearchJob(sj.ID); err != nil {
+		t.Errorf("CloseSearchJob() returned an error: %s", err)
+	}
+	if len(c.searchSessions) != 0 {
+		t.Errorf("Expected the session to be forgotten once the job is deleted, got %v", c.searchSessions)
+	}
+}
+
+func TestDeprecatedSearchCookies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "node-7"})
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+			return
+		}
+		if cookie, err := r.Cookie("JSESSIONID"); err != nil || cookie.Value != "node-3" {
+			t.Errorf("Expected the given session cookie on %s %s, got %v", r.Method, r.URL.EscapedPath(), r.Cookies())
+		}
+		if r.Method != "DELETE" {
+			w.Write([]byte(`{"state": "DONE GATHERING RESULTS"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	sj, cookies, err := c.StartSearch(StartSearchRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	if len(cookies) != 1 || cookies[0].Value != "node-7" {
+		t.Errorf("Expected StartSearch() to return the job's session cookies, got %v", cookies)
+	}
+	given := []*http.Cookie{{Name: "JSESSIONID", Value: "node-3"}}
+	if _, err := c.GetSearchJobStatus(sj.ID, given); err != nil {
+		t.Errorf("GetSearchJobStatus() returned an error: %s", err)
+	}
+	if err := c.DeleteSearchJob(sj.ID, given); err != nil {
+		t.Errorf("DeleteSearchJob() returned an error: %s", err)
+	}
+	if len(c.searchSessions) != 0 {
+		t.Errorf("Expected the session to be forgotten once the job is deleted, got %v", c.searchSessions)
+	}
+}
+
+func TestSearchSessionsAreDropped(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "node-7"})
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"id": "%s"}`, r.Header.Get("X-Test-Job"))
+		case r.URL.EscapedPath() == "/search/jobs/canceled":
+			w.Write([]byte(`{"state": "CANCELED"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status": 404, "code": "searchjob.jobid.invalid", "message": "Job ID is invalid."}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	start := func(id string) *SearchJob {
+		sj, err := c.StartSearchJob(StartSearchRequest{Query: "error"}, WithHeader("X-Test-Job", id))
+		if err != nil {
+			t.Fatalf("StartSearchJob() returned an error: %s", err)
+		}
+		return sj
+	}
+
+	canceled, gone := start("canceled"), start("gone")
+	if _, err := canceled.GetStatus(); err != nil {
+		t.Errorf("GetStatus() returned an error: %s", err)
+	}
+	if _, err := gone.GetStatus(); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound, got %v", err)
+	}
+	if len(c.searchSessions) != 0 {
+		t.Errorf("Expected the sessions of canceled and missing jobs to be dropped, got %v", c.searchSessions)
+	}
+
+	start("stale")
+	c.searchSessions["stale"].used = time.Now().Add(-searchSessionTTL - time.Minute)
+	start("fresh")
+	if _, ok := c.searchSessions["stale"]; ok || len(c.searchSessions) != 1 {
+		t.Errorf("Expected the session of a job unused past its expiry to be dropped, got %v", c.searchSessions)
+	}
+}
@@ -1,7 +1,9 @@
 package sumologic
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -186,3 +188,207 @@ func TestGetSearchResults(t *testing.T) {
 	}
 
 }
+
+func TestWaitForMessages(t *testing.T) {
+	searchJobPollInterval = time.Millisecond
+	defer func() { searchJobPollInterval = time.Second }()
+
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+			return
+		}
+		if r.URL.EscapedPath() != "/search/jobs/123" {
+			t.Errorf("Expected request to ‘/search/jobs/123’, got ‘%s’", r.URL.EscapedPath())
+		}
+		polls++
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(SearchJobStatusResponse{State: "GATHERING RESULTS", MessageCount: polls * 50})
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned error: %v", err)
+		return
+	}
+	sj, _, err := c.StartSearch(StartSearchRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("StartSearch() returned error: %v", err)
+		return
+	}
+	status, err := sj.WaitForMessages(context.Background(), 100)
+	if err != nil {
+		t.Errorf("WaitForMessages() returned error: %v", err)
+		return
+	}
+	if status.State != "GATHERING RESULTS" || status.MessageCount != 100 || polls != 2 {
+		t.Errorf("Expected to return on the second poll with 100 messages, got %d polls and %+v", polls, status)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := sj.WaitForMessages(ctx, 1000); err == nil {
+		t.Errorf("Expected WaitForMessages() to fail once its context is canceled")
+	}
+}
+
+func TestDeleteSearchJob(t *testing.T) {
+	deleted := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "abc"})
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+			return
+		}
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/search/jobs/123" {
+			t.Errorf("Expected request to ‘/search/jobs/123’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if cookie, err := r.Cookie("JSESSIONID"); err != nil || cookie.Value != "abc" {
+			t.Errorf("Expected the search job's session cookie, got %v", r.Cookies())
+		}
+		if deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		deleted = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	sj, _, err := c.StartSearch(StartSearchRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	if err := sj.Delete(); err != nil {
+		t.Errorf("Delete() returned an error: %s", err)
+	}
+	if err := sj.Delete(); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Expected ErrJobNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestGetSearchRecords(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+			return
+		}
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/search/jobs/123/records" {
+			t.Errorf("Expected request to ‘/search/jobs/123/records’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("offset") != "10" || r.URL.Query().Get("limit") != "5" {
+			t.Errorf("Expected offset 10 and limit 5, got ‘%s’", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"fields": [
+				{"name": "_timeslice", "fieldType": "long", "keyField": true},
+				{"name": "_count", "fieldType": "int", "keyField": false},
+				{"name": "avg", "fieldType": "double", "keyField": false},
+				{"name": "host", "fieldType": "string", "keyField": true}
+			],
+			"records": [{"map": {"_timeslice": "1488326400000", "_count": "42", "avg": "1.5", "host": "a"}}]
+		}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	sj, _, err := c.StartSearch(StartSearchRequest{Query: "error | count by host"})
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	result, err := sj.GetRecords(10, 5)
+	if err != nil {
+		t.Errorf("GetRecords() returned an error: %s", err)
+		return
+	}
+	if len(result.Records) != 1 {
+		t.Errorf("Expected 1 record, got %d", len(result.Records))
+		return
+	}
+	record := result.Records[0]
+	for name, expected := range map[string]interface{}{
+		"_timeslice": int64(1488326400000),
+		"_count":     int64(42),
+		"avg":        1.5,
+		"host":       "a",
+		"missing":    nil,
+	} {
+		if v := result.Typed(record, name); v != expected {
+			t.Errorf("Expected %s to be %#v, got %#v", name, expected, v)
+		}
+	}
+}
+
+func TestWaitForCompletion(t *testing.T) {
+	states := []SearchJobStatusResponse{
+		{State: "NOT STARTED"},
+		{State: "GATHERING RESULTS", MessageCount: 10},
+		{State: "DONE GATHERING RESULTS", MessageCount: 25},
+	}
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "123"}`))
+			return
+		}
+		status := SearchJobStatusResponse{State: "GATHERING RESULTS", PendingErrors: []string{"Unexpected token"}}
+		if r.URL.EscapedPath() == "/search/jobs/123" {
+			status = states[polls]
+			polls++
+		}
+		w.WriteHeader(http.StatusOK)
+		body, _ := json.Marshal(status)
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+	sj, _, err := c.StartSearch(StartSearchRequest{Query: "error"})
+	if err != nil {
+		t.Errorf("StartSearch() returned an error: %s", err)
+		return
+	}
+	status, err := sj.WaitForCompletion(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Errorf("WaitForCompletion() returned an error: %s", err)
+		return
+	}
+	if polls != 3 || status.MessageCount != 25 {
+		t.Errorf("Expected the final status after 3 polls, got %d polls and %+v", polls, status)
+	}
+
+	broken := &SearchJob{ID: "456", client: c}
+	_, err = broken.WaitForCompletion(context.Background(), time.Millisecond)
+	if jobErr, ok := err.(*SearchJobError); !ok || jobErr.Errors[0] != "Unexpected token" {
+		t.Errorf("Expected a *SearchJobError with the pending error, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package sumologic
+
+import "errors"
+
+// SearchUsageRequest is a query whose scan volume over TimeRange is estimated.
+type SearchUsageRequest struct {
+	Query     string
+	TimeRange TimeRange
+	// ByReceiptTime estimates the search by the time messages were received rather than
+	// their message time.
+	ByReceiptTime bool
+}
+
+// SearchUsageEstimate is how much data a search would scan, which is what Flex and
+// infrequent tier searches are charged by.
+type SearchUsageEstimate struct {
+	DataScannedBytes int64
+}
+
+type searchUsageBody struct {
+	QueryString string `json:"queryString"`
+	TimeRange   struct {
+		Type string                `json:"type"`
+		From metricsQueryBoundary  `json:"from"`
+		To   *metricsQueryBoundary `json:"to"`
+	} `json:"timeRange"`
+	Timezone         string `json:"timezone"`
+	RunByReceiptTime bool   `json:"runByReceiptTime"`
+}
+
+type searchUsageResponse struct {
+	EstimatedUsageDetails struct {
+		DataScannedInBytes int64 `json:"dataScannedInBytes"`
+	} `json:"estimatedUsageDetails"`
+}
+
+// EstimateSearchUsage estimates the data a search would scan without running it.
+// POST logSearches/estimatedUsage
+func (c *Client) EstimateSearchUsage(sur SearchUsageRequest, opts ...CallOption) (*SearchUsageEstimate, error) {
+	if err := sur.TimeRange.Validate(); err != nil {
+		return nil, err
+	}
+	var in searchUsageBody
+	in.QueryString = sur.Query
+	in.TimeRange.Type = "BeginBoundedTimeRange"
+	in.TimeRange.From = metricsQueryBoundary{Type: "EpochTimeRangeBoundary", EpochMillis: sur.TimeRange.From.UnixMilli()}
+	in.TimeRange.To = &metricsQueryBoundary{Type: "EpochTimeRangeBoundary", EpochMillis: sur.TimeRange.To.UnixMilli()}
+	in.Timezone = sur.TimeRange.TimeZone()
+	in.RunByReceiptTime = sur.ByReceiptTime
+
+	var sr = new(searchUsageResponse)
+	err := c.do(call{
+		method:     "POST",
+		path:       "logSearches/estimatedUsage",
+		in:         in,
+		out:        sr,
+		badRequest: errors.New("Bad Request. Please check the query and time range to estimate"),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchUsageEstimate{DataScannedBytes: sr.EstimatedUsageDetails.DataScannedInBytes}, nil
+}
@@ -0,0 +1,41 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEstimateSearchUsage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/logSearches/estimatedUsage" {
+			t.Errorf("Expected request to ‘/logSearches/estimatedUsage’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var in searchUsageBody
+		json.NewDecoder(r.Body).Decode(&in)
+		if in.QueryString != "error" || in.TimeRange.From.EpochMillis != 1790856000000 || in.TimeRange.To.EpochMillis != 1790859600000 || in.Timezone != "UTC" {
+			t.Errorf("Expected the query and time range, got %+v", in)
+		}
+		w.Write([]byte(`{"estimatedUsageDetails": {"dataScannedInBytes": 1048576}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	from := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	estimate, err := c.EstimateSearchUsage(SearchUsageRequest{Query: "error", TimeRange: TimeRange{From: from, To: from.Add(time.Hour)}})
+	if err != nil {
+		t.Errorf("EstimateSearchUsage() returned an error: %s", err)
+		return
+	}
+	if estimate.DataScannedBytes != 1048576 {
+		t.Errorf("Expected 1048576 bytes scanned, got %d", estimate.DataScannedBytes)
+	}
+	if _, err := c.EstimateSearchUsage(SearchUsageRequest{Query: "error", TimeRange: TimeRange{From: from, To: from}}); err != ErrTimeRangeOrder {
+		t.Errorf("Expected ErrTimeRangeOrder, got %v", err)
+	}
+}
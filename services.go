@@ -0,0 +1,73 @@
+package sumologic
+
+import (
+	"encoding/json"
+)
+
+// SearchService is the search API of a Client. Code that takes a SearchService rather
+// than a *Client can be tested with a mock of just this group of calls.
+type SearchService interface {
+	StartSearchJob(ssr StartSearchRequest, opts ...CallOption) (*SearchJob, error)
+	GetSearchJob(searchJobID string, opts ...CallOption) (*SearchJobStatusResponse, error)
+	GetSearchJobMessages(sjrr SearchJobResultsRequest, opts ...CallOption) (*SearchJobResult, error)
+	GetSearchJobRecords(sjrr SearchJobRecordsRequest, opts ...CallOption) (*SearchJobRecordsResult, error)
+	CloseSearchJob(searchJobID string, opts ...CallOption) error
+}
+
+// CollectorsService is the collector and source management API of a Client.
+type CollectorsService interface {
+	ListCollectors(lcr ListCollectorsRequest, opts ...CallOption) (*CollectorList, error)
+	ListAllCollectors(lcr ListCollectorsRequest, opts ...CallOption) ([]Collector, error)
+	LookupCollectorByName(name string, opts ...CallOption) (string, error)
+	GetHostedCollector(id int, opts ...CallOption) (*Collector, string, error)
+	CreateHostedCollector(collector Collector, opts ...CallOption) (*Collector, error)
+	UpdateHostedCollector(collector Collector, etag string, opts ...CallOption) (*Collector, error)
+	DeleteHostedCollector(id int, opts ...CallOption) error
+
+	ListSources(collectorID int, opts ...CallOption) ([]Source, error)
+	GetSource(collectorID, id int, opts ...CallOption) (Source, string, error)
+	CreateSource(collectorID int, source Source, opts ...CallOption) (Source, error)
+	UpdateSource(collectorID int, source Source, etag string, opts ...CallOption) (Source, error)
+	DeleteSource(collectorID, id int, opts ...CallOption) error
+}
+
+// ContentService is the content library API of a Client: folders, dashboards, and
+// exporting and importing content.
+type ContentService interface {
+	GetPersonalFolder(opts ...CallOption) (*Folder, error)
+	GetGlobalFolder(opts ...CallOption) ([]ContentItem, error)
+	GetAdminRecommendedFolder(opts ...CallOption) (*Folder, error)
+	GetFolder(id string, opts ...CallOption) (*Folder, error)
+	CreateFolder(cfr CreateFolderRequest, opts ...CallOption) (*Folder, error)
+	UpdateFolder(id string, ufr UpdateFolderRequest, opts ...CallOption) (*Folder, error)
+	WalkFolder(id string, fn func(item ContentItem, path []string) error, opts ...CallOption) error
+
+	ListDashboards(limit int, token string, opts ...CallOption) (*DashboardList, error)
+	ListAllDashboards(opts ...CallOption) ([]Dashboard, error)
+	GetDashboard(id string, opts ...CallOption) (*Dashboard, error)
+	CreateDashboard(dashboard Dashboard, opts ...CallOption) (*Dashboard, error)
+	UpdateDashboard(dashboard Dashboard, opts ...CallOption) (*Dashboard, error)
+	DeleteDashboard(id string, opts ...CallOption) error
+
+	ExportContent(id string, opts ...CallOption) (json.RawMessage, error)
+	ImportContent(folderID string, content json.RawMessage, overwrite bool, opts ...CallOption) error
+}
+
+var (
+	_ SearchService     = (*Client)(nil)
+	_ CollectorsService = (*Client)(nil)
+	_ ContentService    = (*Client)(nil)
+)
+
+// Services groups a client's API by service. Every service shares the client's transport,
+// so credentials, retries, rate limiting and the other client settings apply to all.
+type Services struct {
+	Search     SearchService
+	Collectors CollectorsService
+	Content    ContentService
+}
+
+// Services returns the client's API grouped by service.
+func (c *Client) Services() Services {
+	return Services{Search: c, Collectors: c, Content: c}
+}
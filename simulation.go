@@ -0,0 +1,151 @@
+package sumologic
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SimulatedTransport is an http.RoundTripper that adds latency, rate limiting, server
+// errors and session expiry to the responses of another transport, typically one reaching
+// an httptest server standing in for the API. Set it as the transport of the client's
+// HTTPClient to test how an application's retries and backoff cope with them. Failures
+// are drawn from a random source seeded with Seed, so a test sees the same sequence on
+// every run. It's safe for concurrent use.
+type SimulatedTransport struct {
+	// Transport sends the requests that aren't failed, http.DefaultTransport when nil.
+	Transport http.RoundTripper
+
+	// Latency is added to every request, plus a random duration up to Jitter.
+	Latency time.Duration
+	Jitter  time.Duration
+
+	// RateLimitRate is the fraction of requests, from 0 to 1, answered 429 Too Many
+	// Requests, with a Retry-After header of RetryAfter when it's at least a second.
+	RateLimitRate float64
+	RetryAfter    time.Duration
+	// ServerErrorRate is the fraction of requests answered 503 Service Unavailable.
+	ServerErrorRate float64
+	// SessionLifetime, when set, is how long after the first request the session
+	// expires. Every request after that is answered 401 Unauthorized until ResetSession.
+	SessionLifetime time.Duration
+
+	// Seed seeds the random source of failures and jitter.
+	Seed int64
+
+	mu           sync.Mutex
+	rand         *rand.Rand
+	sessionStart time.Time
+	stats        SimulationStats
+}
+
+// SimulationStats counts the requests a SimulatedTransport handled and the failures it
+// injected.
+type SimulationStats struct {
+	Requests       int
+	RateLimited    int
+	ServerErrors   int
+	SessionExpired int
+}
+
+// Stats returns the counts of requests and injected failures so far.
+func (t *SimulatedTransport) Stats() SimulationStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
+
+// ResetSession starts a new session, as when the application logs in again.
+func (t *SimulatedTransport) ResetSession() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessionStart = time.Time{}
+}
+
+// RoundTrip waits out the simulated latency, then fails the request or passes it on.
+func (t *SimulatedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay, status := t.draw()
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+	if status != 0 {
+		return t.failure(req, status), nil
+	}
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(req)
+}
+
+// draw picks the latency of a request and the status of the failure to answer it with,
+// zero to send it.
+func (t *SimulatedTransport) draw() (time.Duration, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.rand == nil {
+		t.rand = rand.New(rand.NewSource(t.Seed))
+	}
+	t.stats.Requests++
+
+	delay := t.Latency
+	if t.Jitter > 0 {
+		delay += time.Duration(t.rand.Int63n(int64(t.Jitter)))
+	}
+
+	now := time.Now()
+	if t.sessionStart.IsZero() {
+		t.sessionStart = now
+	}
+	if t.SessionLifetime > 0 && now.Sub(t.sessionStart) > t.SessionLifetime {
+		t.stats.SessionExpired++
+		return delay, http.StatusUnauthorized
+	}
+	if t.RateLimitRate > 0 && t.rand.Float64() < t.RateLimitRate {
+		t.stats.RateLimited++
+		return delay, http.StatusTooManyRequests
+	}
+	if t.ServerErrorRate > 0 && t.rand.Float64() < t.ServerErrorRate {
+		t.stats.ServerErrors++
+		return delay, http.StatusServiceUnavailable
+	}
+	return delay, 0
+}
+
+// failure builds the response of an injected failure, with an error body like the API's.
+func (t *SimulatedTransport) failure(req *http.Request, status int) *http.Response {
+	var body string
+	header := http.Header{"Content-Type": {"application/json"}}
+	switch status {
+	case http.StatusTooManyRequests:
+		body = `{"status": 429, "code": "rate.limit.exceeded", "message": "Rate limit exceeded"}`
+		if t.RetryAfter >= time.Second {
+			header.Set("Retry-After", strconv.Itoa(int(t.RetryAfter/time.Second)))
+		}
+	case http.StatusUnauthorized:
+		body = `{"status": 401, "code": "unauthorized", "message": "Session expired"}`
+	default:
+		body = `{"status": 503, "code": "service.unavailable", "message": "Service temporarily unavailable"}`
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,83 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func simulatedClient(t *testing.T, sim *SimulatedTransport) *Client {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"collector": {"id": 1, "name": "test"}}`))
+	}))
+	t.Cleanup(ts.Close)
+	c, _ := NewClient("accessToken", ts.URL, WithHTTPClient(&http.Client{Transport: sim}), WithRateLimit(0, 0))
+	return c
+}
+
+func TestSimulatedTransportServerErrorsAreRetried(t *testing.T) {
+	sim := &SimulatedTransport{ServerErrorRate: 0.5, Seed: 1}
+	c := simulatedClient(t, sim)
+	policy := WithRetryPolicy(RetryPolicy{MaxAttempts: 20, Backoff: time.Millisecond})
+
+	for i := 0; i < 10; i++ {
+		if _, _, err := c.GetHostedCollector(1, policy); err != nil {
+			t.Errorf("GetHostedCollector() returned an error: %s", err)
+			return
+		}
+	}
+	stats := sim.Stats()
+	if stats.ServerErrors == 0 || stats.Requests != stats.ServerErrors+10 {
+		t.Errorf("Expected injected server errors to be retried, got %+v", stats)
+	}
+}
+
+func TestSimulatedTransportRateLimit(t *testing.T) {
+	sim := &SimulatedTransport{RateLimitRate: 1}
+	c := simulatedClient(t, sim)
+
+	_, _, err := c.GetHostedCollector(1, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}))
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+	if sim.Stats().RateLimited != 2 {
+		t.Errorf("Expected 2 rate limited requests, got %+v", sim.Stats())
+	}
+}
+
+func TestSimulatedTransportSessionExpiry(t *testing.T) {
+	sim := &SimulatedTransport{SessionLifetime: 20 * time.Millisecond}
+	c := simulatedClient(t, sim)
+
+	if _, _, err := c.GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error: %s", err)
+		return
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, _, err := c.GetHostedCollector(1); err != ErrClientAuthenticationError {
+		t.Errorf("Expected ErrClientAuthenticationError after the session expired, got %v", err)
+	}
+	sim.ResetSession()
+	if _, _, err := c.GetHostedCollector(1); err != nil {
+		t.Errorf("GetHostedCollector() returned an error after a new session: %s", err)
+	}
+}
+
+func TestSimulatedTransportLatency(t *testing.T) {
+	sim := &SimulatedTransport{Latency: time.Second}
+	c := simulatedClient(t, sim)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := c.GetHostedCollector(1, WithContext(ctx), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	if err == nil {
+		t.Errorf("Expected the call to time out")
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("Expected the simulated latency to stop when the call is cancelled")
+	}
+}
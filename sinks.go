@@ -0,0 +1,270 @@
+package sumologic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MessageIterator yields search result messages one at a time. Next advances to the next
+// message, returning false when there are no more or an error stopped the iteration.
+type MessageIterator interface {
+	Next() bool
+	Message() *SearchJobResultMessage
+	Err() error
+}
+
+// NewSliceMessageIterator returns a MessageIterator over messages that have already been fetched.
+func NewSliceMessageIterator(messages []*SearchJobResultMessage) MessageIterator {
+	return &sliceMessageIterator{messages: messages, pos: -1}
+}
+
+type sliceMessageIterator struct {
+	messages []*SearchJobResultMessage
+	pos      int
+}
+
+func (it *sliceMessageIterator) Next() bool {
+	it.pos++
+	return it.pos < len(it.messages)
+}
+
+func (it *sliceMessageIterator) Message() *SearchJobResultMessage {
+	return it.messages[it.pos]
+}
+
+func (it *sliceMessageIterator) Err() error {
+	return nil
+}
+
+// SinkColumnType is the type of a column loaded by a sink.
+type SinkColumnType string
+
+// Column types derived from result fields.
+const (
+	SinkString    SinkColumnType = "STRING"
+	SinkInteger   SinkColumnType = "INTEGER"
+	SinkFloat     SinkColumnType = "FLOAT"
+	SinkTimestamp SinkColumnType = "TIMESTAMP"
+)
+
+// SinkColumn maps a message field to a warehouse column.
+type SinkColumn struct {
+	Name  string
+	Field string
+	Type  SinkColumnType
+}
+
+// timestampFields hold epoch milliseconds and are loaded as timestamps.
+var timestampFields = map[string]bool{
+	"_messagetime": true,
+	"_receipttime": true,
+}
+
+// SinkSchema derives columns from the fields of the messages. A field is an integer or
+// float column when all of its values parse as one, and a string column otherwise.
+// Column names are the field names lowercased, without leading underscores and with
+// anything but letters, digits and underscores replaced by underscores.
+func SinkSchema(messages []*SearchJobResultMessage) []SinkColumn {
+	types := make(map[string]SinkColumnType)
+	for _, m := range messages {
+		for field := range m.Map {
+			value := messageField(m, field)
+			if value == "" {
+				if _, ok := types[field]; !ok {
+					types[field] = ""
+				}
+				continue
+			}
+			types[field] = widenSinkColumnType(types[field], value)
+		}
+	}
+
+	var columns []SinkColumn
+	for field, t := range types {
+		if t == "" {
+			t = SinkString
+		}
+		if t == SinkInteger && timestampFields[strings.ToLower(field)] {
+			t = SinkTimestamp
+		}
+		columns = append(columns, SinkColumn{Name: sinkColumnName(field), Field: field, Type: t})
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Name < columns[j].Name })
+	return columns
+}
+
+// widenSinkColumnType returns the narrowest type holding both t and value.
+func widenSinkColumnType(t SinkColumnType, value string) SinkColumnType {
+	if t == SinkString {
+		return SinkString
+	}
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if t == "" {
+			return SinkInteger
+		}
+		return t
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return SinkFloat
+	}
+	return SinkString
+}
+
+func sinkColumnName(field string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(field))
+	name = strings.TrimLeft(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "f_" + name
+	}
+	return name
+}
+
+// sinkRow converts a message to the column values of a row. Values that don't convert to
+// the column's type, and missing fields, are nil.
+func sinkRow(m *SearchJobResultMessage, columns []SinkColumn) []interface{} {
+	row := make([]interface{}, len(columns))
+	for i, c := range columns {
+		value := messageField(m, c.Field)
+		if value == "" {
+			continue
+		}
+		switch c.Type {
+		case SinkInteger:
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				row[i] = v
+			}
+		case SinkFloat:
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				row[i] = v
+			}
+		case SinkTimestamp:
+			if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+				row[i] = time.Unix(0, ms*int64(time.Millisecond)).UTC()
+			}
+		default:
+			row[i] = value
+		}
+	}
+	return row
+}
+
+// RowWriter writes batches of rows to a warehouse table. SQLSink implements it for
+// database/sql; BigQuery, ClickHouse and other warehouse clients can be adapted to it.
+type RowWriter interface {
+	WriteRows(ctx context.Context, columns []SinkColumn, rows [][]interface{}) error
+}
+
+// SinkOptions configures LoadMessages.
+type SinkOptions struct {
+	// Columns to load. When empty they're derived from the first batch with SinkSchema,
+	// and fields first seen in later batches aren't loaded.
+	Columns []SinkColumn
+	// BatchSize is the number of rows written at once, 500 by default.
+	BatchSize int
+}
+
+// LoadMessages writes every message of the iterator to the writer in batches and returns
+// the number of messages written.
+func LoadMessages(ctx context.Context, it MessageIterator, w RowWriter, so SinkOptions) (int, error) {
+	batchSize := so.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	columns := so.Columns
+	written := 0
+	batch := make([]*SearchJobResultMessage, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if len(columns) == 0 {
+			columns = SinkSchema(batch)
+		}
+		rows := make([][]interface{}, len(batch))
+		for i, m := range batch {
+			rows[i] = sinkRow(m, columns)
+		}
+		if err := w.WriteRows(ctx, columns, rows); err != nil {
+			return err
+		}
+		written += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		batch = append(batch, it.Message())
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return written, err
+	}
+	return written, flush()
+}
+
+// SQLSink inserts rows into a database/sql table, one transaction per batch.
+// The table must already exist with the sink's column names.
+type SQLSink struct {
+	DB    *sql.DB
+	Table string
+	// Placeholder returns the bind parameter for the n-th value, starting at 1.
+	// It defaults to "?"; use e.g. "$n" for PostgreSQL.
+	Placeholder func(n int) string
+}
+
+// WriteRows inserts the rows with a single multi-row INSERT.
+func (s *SQLSink) WriteRows(ctx context.Context, columns []SinkColumn, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	placeholder := s.Placeholder
+	if placeholder == nil {
+		placeholder = func(int) string { return "?" }
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	var query strings.Builder
+	fmt.Fprintf(&query, "INSERT INTO %s (%s) VALUES ", s.Table, strings.Join(names, ", "))
+	args := make([]interface{}, 0, len(rows)*len(columns))
+	for i, row := range rows {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		params := make([]string, len(row))
+		for j, v := range row {
+			args = append(args, v)
+			params[j] = placeholder(len(args))
+		}
+		fmt.Fprintf(&query, "(%s)", strings.Join(params, ", "))
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query.String(), args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,69 @@
+package sumologic
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type recordingRowWriter struct {
+	columns []SinkColumn
+	batches [][][]interface{}
+}
+
+func (w *recordingRowWriter) WriteRows(ctx context.Context, columns []SinkColumn, rows [][]interface{}) error {
+	w.columns = columns
+	w.batches = append(w.batches, rows)
+	return nil
+}
+
+func sinkMessages() []*SearchJobResultMessage {
+	var messages []*SearchJobResultMessage
+	for _, m := range []map[string]interface{}{
+		{"_messagetime": "1488326400000", "_sourceCategory": "prod/app", "bytes": "10", "latency": "1"},
+		{"_messagetime": "1488326401000", "_sourceCategory": "prod/app", "bytes": "20", "latency": "1.5"},
+		{"_messagetime": "1488326402000", "_sourceCategory": "prod/db", "bytes": "", "latency": "2", "user-id": "7"},
+	} {
+		messages = append(messages, &SearchJobResultMessage{Map: m})
+	}
+	return messages
+}
+
+func TestSinkSchema(t *testing.T) {
+	columns := SinkSchema(sinkMessages())
+	expected := []SinkColumn{
+		{Name: "bytes", Field: "bytes", Type: SinkInteger},
+		{Name: "latency", Field: "latency", Type: SinkFloat},
+		{Name: "messagetime", Field: "_messagetime", Type: SinkTimestamp},
+		{Name: "sourcecategory", Field: "_sourceCategory", Type: SinkString},
+		{Name: "user_id", Field: "user-id", Type: SinkInteger},
+	}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Errorf("Expected %v, got %v", expected, columns)
+	}
+}
+
+func TestLoadMessages(t *testing.T) {
+	w := new(recordingRowWriter)
+	n, err := LoadMessages(context.Background(), NewSliceMessageIterator(sinkMessages()), w, SinkOptions{BatchSize: 2})
+	if err != nil {
+		t.Errorf("LoadMessages() returned error: %v", err)
+		return
+	}
+	if n != 3 || len(w.batches) != 2 || len(w.batches[0]) != 2 || len(w.batches[1]) != 1 {
+		t.Errorf("Expected 3 messages in batches of 2 and 1, got %d messages in %v", n, w.batches)
+		return
+	}
+	// The schema comes from the first batch, so user-id isn't loaded.
+	if len(w.columns) != 4 {
+		t.Errorf("Expected the 4 columns of the first batch, got %v", w.columns)
+	}
+	expected := []interface{}{int64(20), 1.5, time.Date(2017, 3, 1, 0, 0, 1, 0, time.UTC), "prod/app"}
+	if !reflect.DeepEqual(w.batches[0][1], expected) {
+		t.Errorf("Expected row %v, got %v", expected, w.batches[0][1])
+	}
+	if w.batches[1][0][0] != nil {
+		t.Errorf("Expected the empty bytes field to be nil, got %v", w.batches[1][0][0])
+	}
+}
@@ -0,0 +1,239 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// SLO is a service level objective or a folder of them in the SLO library, told apart by
+// Type. Folders list the items in them as Children.
+type SLO struct {
+	ID          string         `json:"id,omitempty"`
+	Type        string         `json:"type"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	ParentID    string         `json:"parentId,omitempty"`
+	Version     int            `json:"version,omitempty"`
+	SignalType  string         `json:"signalType,omitempty"`
+	Service     string         `json:"service,omitempty"`
+	Application string         `json:"application,omitempty"`
+	Compliance  *SLOCompliance `json:"compliance,omitempty"`
+	Indicator   *SLOIndicator  `json:"indicator,omitempty"`
+	Children    []SLO          `json:"children,omitempty"`
+	CreatedAt   string         `json:"createdAt,omitempty"`
+	CreatedBy   string         `json:"createdBy,omitempty"`
+	ModifiedAt  string         `json:"modifiedAt,omitempty"`
+	ModifiedBy  string         `json:"modifiedBy,omitempty"`
+}
+
+// SLO library item types.
+const (
+	SLOType       = "SlosLibrarySlo"
+	SLOFolderType = "SlosLibraryFolder"
+)
+
+// SLO signal types.
+const (
+	SLOSignalLatency      = "Latency"
+	SLOSignalError        = "Error"
+	SLOSignalThroughput   = "Throughput"
+	SLOSignalAvailability = "Availability"
+	SLOSignalOther        = "Other"
+)
+
+// SLOCompliance is the target of an SLO: the percentage of good time or requests over a
+// rolling window, e.g. a Size of 7d, or a calendar period, e.g. Week.
+type SLOCompliance struct {
+	ComplianceType string  `json:"complianceType"`
+	Target         float64 `json:"target"`
+	Timezone       string  `json:"timezone"`
+	Size           string  `json:"size"`
+	StartFrom      string  `json:"startFrom,omitempty"`
+}
+
+// SLO compliance types.
+const (
+	SLOComplianceRolling  = "Rolling"
+	SLOComplianceCalendar = "Calendar"
+)
+
+// SLOIndicator is the service level indicator of an SLO. Window-based indicators count
+// the windows of Size whose aggregated value passes Threshold; request-based ones count
+// the good requests out of the total.
+type SLOIndicator struct {
+	EvaluationType string          `json:"evaluationType"`
+	QueryType      string          `json:"queryType"`
+	Queries        []SLOQueryGroup `json:"queries"`
+	Threshold      float64         `json:"threshold,omitempty"`
+	Op             string          `json:"op,omitempty"`
+	Aggregation    string          `json:"aggregation,omitempty"`
+	Size           string          `json:"size,omitempty"`
+}
+
+// SLO indicator evaluation types.
+const (
+	SLOEvaluationWindow  = "Window"
+	SLOEvaluationRequest = "Request"
+)
+
+// SLOQueryGroup is a group of queries with the role QueryGroupType, such as Successful,
+// Unsuccessful, Total or Threshold, in an indicator.
+type SLOQueryGroup struct {
+	QueryGroupType string     `json:"queryGroupType"`
+	QueryGroup     []SLOQuery `json:"queryGroup"`
+}
+
+// SLOQuery is one query of an indicator's query group. Logs queries either count rows,
+// with UseRowCount, or use the value of Field.
+type SLOQuery struct {
+	RowID       string `json:"rowId"`
+	Query       string `json:"query"`
+	UseRowCount bool   `json:"useRowCount"`
+	Field       string `json:"field,omitempty"`
+}
+
+// SLOSearchResult is an SLO found by SearchSLOs, with the path of its folder.
+type SLOSearchResult struct {
+	Item SLO    `json:"item"`
+	Path string `json:"path"`
+}
+
+// ErrSLONotFound is returned when an SLO or SLO folder doesn't exist.
+var ErrSLONotFound = errors.New("SLO not found")
+
+// GetSLOsRootFolder gets the root folder of the SLO library.
+func (c *Client) GetSLOsRootFolder(opts ...CallOption) (*SLO, error) {
+	return c.getSLO("slos/root", opts...)
+}
+
+// GetSLO gets the SLO or folder with the specified ID.
+func (c *Client) GetSLO(id string, opts ...CallOption) (*SLO, error) {
+	return c.getSLO(fmt.Sprintf("slos/%s", id), opts...)
+}
+
+// CreateSLO creates an SLO or folder in the folder with the specified ID.
+// SLOs without a Type are created as SLOs.
+func (c *Client) CreateSLO(parentID string, s SLO, opts ...CallOption) (*SLO, error) {
+	if s.Type == "" {
+		s.Type = SLOType
+	}
+	q := url.Values{}
+	q.Set("parentId", parentID)
+	return c.sendSLO(call{method: "POST", path: "slos?" + q.Encode(), in: s, create: true}, s.Name, opts)
+}
+
+// UpdateSLO updates the SLO or folder with the same ID. Version must be the version
+// last read, so concurrent changes aren't overwritten.
+func (c *Client) UpdateSLO(s SLO, opts ...CallOption) (*SLO, error) {
+	if s.Type == "" {
+		s.Type = SLOType
+	}
+	return c.sendSLO(call{method: "PUT", path: fmt.Sprintf("slos/%s", s.ID), in: s}, s.Name, opts)
+}
+
+// DeleteSLO deletes the SLO or folder with the specified ID, along with everything in
+// the folder.
+func (c *Client) DeleteSLO(id string, opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("slos/%s", id), notFound: ErrSLONotFound}, opts)
+}
+
+// MoveSLO moves the SLO or folder with the specified ID into another folder.
+func (c *Client) MoveSLO(id, parentID string, opts ...CallOption) (*SLO, error) {
+	q := url.Values{}
+	q.Set("parentId", parentID)
+	return c.sendSLO(call{method: "POST", path: fmt.Sprintf("slos/%s/move?%s", id, q.Encode())}, id, opts)
+}
+
+// SearchSLOs returns a page of the SLOs and folders matching the query, such as a name.
+// A limit of 0 uses the API default.
+func (c *Client) SearchSLOs(query string, limit, offset int, opts ...CallOption) ([]SLOSearchResult, error) {
+	q := url.Values{}
+	q.Set("query", query)
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	}
+
+	var results []SLOSearchResult
+	if err := c.do(call{method: "GET", path: "slos/search?" + q.Encode(), out: &results}, opts); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// WalkSLOs calls fn for the folder with the specified ID and everything below it, parents
+// before their children. The root folder is walked when id is empty.
+func (c *Client) WalkSLOs(id string, fn func(s SLO) error, opts ...CallOption) error {
+	var folder *SLO
+	var err error
+	if id == "" {
+		folder, err = c.GetSLOsRootFolder(opts...)
+	} else {
+		folder, err = c.GetSLO(id, opts...)
+	}
+	if err != nil {
+		return err
+	}
+	if err := fn(*folder); err != nil {
+		return err
+	}
+	for _, child := range folder.Children {
+		if child.Type == SLOFolderType {
+			if err := c.WalkSLOs(child.ID, fn, opts...); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SLOs returns a ResourceClient for SLOs and SLO folders.
+// Create adds them to the root folder of the SLO library unless ParentID is set.
+func (c *Client) SLOs(opts ...CallOption) ResourceClient[SLO] {
+	return &resourceClient[SLO]{
+		get: func(id string) (*SLO, error) {
+			return c.GetSLO(id, opts...)
+		},
+		create: func(s SLO) (*SLO, error) {
+			parentID := s.ParentID
+			if parentID == "" {
+				root, err := c.GetSLOsRootFolder(opts...)
+				if err != nil {
+					return nil, err
+				}
+				parentID = root.ID
+			}
+			return c.CreateSLO(parentID, s, opts...)
+		},
+		update: func(s SLO) (*SLO, error) {
+			return c.UpdateSLO(s, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteSLO(id, opts...)
+		},
+	}
+}
+
+func (c *Client) getSLO(path string, opts ...CallOption) (*SLO, error) {
+	return c.sendSLO(call{method: "GET", path: path}, "", opts)
+}
+
+// sendSLO makes a call returning an SLO. name identifies the SLO in validation errors.
+func (c *Client) sendSLO(cl call, name string, opts []CallOption) (*SLO, error) {
+	var s = new(SLO)
+	cl.out = s
+	cl.notFound = ErrSLONotFound
+	cl.badRequest = fmt.Errorf("Bad Request. Please check the settings for SLO `%s`", name)
+	if err := c.do(cl, opts); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,90 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateSLO(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/slos" || r.URL.Query().Get("parentId") != "root" {
+			t.Errorf("Expected request to ‘/slos?parentId=root’, got ‘%s’", r.URL)
+		}
+		var s SLO
+		json.NewDecoder(r.Body).Decode(&s)
+		if s.Type != SLOType || s.Compliance.Target != 99.9 || s.Indicator.Queries[0].QueryGroup[0].RowID != "A" {
+			t.Errorf("Unexpected SLO %+v", s)
+		}
+		s.ID = "slo1"
+		json.NewEncoder(w).Encode(s)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	s, err := c.CreateSLO("root", SLO{
+		Name:       "Checkout availability",
+		SignalType: SLOSignalAvailability,
+		Compliance: &SLOCompliance{ComplianceType: SLOComplianceRolling, Target: 99.9, Timezone: "UTC", Size: "7d"},
+		Indicator: &SLOIndicator{
+			EvaluationType: SLOEvaluationRequest,
+			QueryType:      "Logs",
+			Queries: []SLOQueryGroup{
+				{QueryGroupType: "Unsuccessful", QueryGroup: []SLOQuery{{RowID: "A", Query: "_sourceCategory=checkout status>=500", UseRowCount: true}}},
+				{QueryGroupType: "Total", QueryGroup: []SLOQuery{{RowID: "B", Query: "_sourceCategory=checkout", UseRowCount: true}}},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("CreateSLO() returned an error: %s", err)
+		return
+	}
+	if s.ID != "slo1" {
+		t.Errorf("Expected the created SLO's ID, got ‘%s’", s.ID)
+	}
+}
+
+func TestWalkSLOs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/slos/root":
+			json.NewEncoder(w).Encode(SLO{ID: "root", Type: SLOFolderType, Children: []SLO{
+				{ID: "team", Type: SLOFolderType},
+				{ID: "slo1", Type: SLOType},
+			}})
+		case "/slos/team":
+			json.NewEncoder(w).Encode(SLO{ID: "team", Type: SLOFolderType, Children: []SLO{{ID: "slo2", Type: SLOType}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	var ids []string
+	err := c.WalkSLOs("", func(s SLO) error {
+		ids = append(ids, s.ID)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("WalkSLOs() returned an error: %s", err)
+		return
+	}
+	expected := []string{"root", "team", "slo2", "slo1"}
+	if len(ids) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+		return
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, ids)
+		}
+	}
+	if _, err := c.GetSLO("missing"); err != ErrSLONotFound {
+		t.Errorf("Expected ErrSLONotFound, got %v", err)
+	}
+}
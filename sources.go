@@ -0,0 +1,359 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Source types, as sent in a source's sourceType field.
+const (
+	SourceTypeHTTP    = "HTTP"
+	SourceTypeSyslog  = "Syslog"
+	SourceTypeScript  = "Script"
+	SourceTypePolling = "Polling"
+)
+
+// Content types of polling sources, which share the Polling source type.
+const (
+	ContentTypeS3         = "AwsS3Bucket"
+	ContentTypeCloudTrail = "AwsCloudTrailBucket"
+)
+
+// ErrSourceNotFound is returned when a source doesn't exist on a Read or Delete.
+var ErrSourceNotFound = errors.New("Source not found")
+
+// ErrSourceModified is returned by UpdateSource when the source changed since its ETag was read.
+var ErrSourceModified = errors.New("Source was modified since it was read")
+
+// Source is a source on a collector: one of *HTTPSource, *SyslogSource, *ScriptSource,
+// *S3Source, *CloudTrailSource, or *UnknownSource for types this package doesn't know.
+type Source interface {
+	// Base returns the fields common to every type of source.
+	Base() *SourceBase
+}
+
+// SourceBase holds the fields common to every type of source. SourceType is set when the
+// source is sent, from its Go type.
+type SourceBase struct {
+	ID          int            `json:"id,omitempty"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Category    string         `json:"category,omitempty"`
+	HostName    string         `json:"hostName,omitempty"`
+	TimeZone    string         `json:"timeZone,omitempty"`
+	SourceType  string         `json:"sourceType"`
+	Filters     []SourceFilter `json:"filters,omitempty"`
+	Alive       bool           `json:"alive,omitempty"`
+}
+
+// Base returns b.
+func (b *SourceBase) Base() *SourceBase {
+	return b
+}
+
+// SourceFilter is a processing rule applied to a source's messages.
+type SourceFilter struct {
+	Name       string `json:"name"`
+	FilterType string `json:"filterType"`
+	Regexp     string `json:"regexp"`
+	Mask       string `json:"mask,omitempty"`
+}
+
+// HTTPSource receives messages posted to its URL.
+type HTTPSource struct {
+	SourceBase
+	MessagePerRequest bool   `json:"messagePerRequest"`
+	URL               string `json:"url,omitempty"`
+}
+
+// SyslogSource receives syslog messages on an installed collector.
+type SyslogSource struct {
+	SourceBase
+	Protocol string `json:"protocol,omitempty"`
+	Port     int    `json:"port"`
+}
+
+// ScriptSource runs a script or commands on an installed collector on a schedule.
+type ScriptSource struct {
+	SourceBase
+	Commands       []string `json:"commands,omitempty"`
+	Script         string   `json:"script,omitempty"`
+	File           string   `json:"file,omitempty"`
+	WorkingDir     string   `json:"workingDir,omitempty"`
+	Timeout        int64    `json:"timeout,omitempty"`
+	CronExpression string   `json:"cronExpression,omitempty"`
+}
+
+// PollingSource holds the fields of sources that poll a third party, such as an S3 bucket.
+type PollingSource struct {
+	ContentType   string               `json:"contentType"`
+	ScanInterval  int64                `json:"scanInterval"`
+	Paused        bool                 `json:"paused"`
+	ThirdPartyRef PollingThirdPartyRef `json:"thirdPartyRef"`
+}
+
+// PollingThirdPartyRef lists the resources a polling source reads.
+type PollingThirdPartyRef struct {
+	Resources []PollingResource `json:"resources"`
+}
+
+// PollingResource is a resource read by a polling source and the credentials to read it.
+type PollingResource struct {
+	ServiceType    string                `json:"serviceType"`
+	Authentication PollingAuthentication `json:"authentication"`
+	Path           PollingPath           `json:"path"`
+}
+
+// PollingAuthentication holds either an AWS access key or a role to assume.
+type PollingAuthentication struct {
+	Type    string `json:"type"`
+	AwsID   string `json:"awsId,omitempty"`
+	AwsKey  string `json:"awsKey,omitempty"`
+	RoleARN string `json:"roleARN,omitempty"`
+}
+
+// PollingPath is where in the resource a polling source reads.
+type PollingPath struct {
+	Type           string `json:"type"`
+	BucketName     string `json:"bucketName,omitempty"`
+	PathExpression string `json:"pathExpression,omitempty"`
+}
+
+// S3Source reads log files from an S3 bucket.
+type S3Source struct {
+	SourceBase
+	PollingSource
+}
+
+// CloudTrailSource reads CloudTrail logs from an S3 bucket.
+type CloudTrailSource struct {
+	SourceBase
+	PollingSource
+}
+
+// UnknownSource is a source of a type this package doesn't know. Raw holds the source as
+// returned by the API and is sent back as is.
+type UnknownSource struct {
+	SourceBase
+	Raw json.RawMessage `json:"-"`
+}
+
+// sourceRequest is the wrapper for single source API calls.
+type sourceRequest struct {
+	Source json.RawMessage `json:"source"`
+}
+
+// sourceList is the response to listing a collector's sources.
+type sourceList struct {
+	Sources []json.RawMessage `json:"sources"`
+}
+
+// encodeSource marshals a copy of the source with its sourceType, and contentType for
+// polling sources, set from its Go type.
+func encodeSource(s Source) (json.RawMessage, error) {
+	switch s := s.(type) {
+	case *HTTPSource:
+		cp := *s
+		cp.SourceType = SourceTypeHTTP
+		return json.Marshal(cp)
+	case *SyslogSource:
+		cp := *s
+		cp.SourceType = SourceTypeSyslog
+		return json.Marshal(cp)
+	case *ScriptSource:
+		cp := *s
+		cp.SourceType = SourceTypeScript
+		return json.Marshal(cp)
+	case *S3Source:
+		cp := *s
+		cp.SourceType, cp.ContentType = SourceTypePolling, ContentTypeS3
+		return json.Marshal(cp)
+	case *CloudTrailSource:
+		cp := *s
+		cp.SourceType, cp.ContentType = SourceTypePolling, ContentTypeCloudTrail
+		return json.Marshal(cp)
+	case *UnknownSource:
+		if s.Raw != nil {
+			return s.Raw, nil
+		}
+		return json.Marshal(s.SourceBase)
+	default:
+		return nil, fmt.Errorf("unsupported source type %T", s)
+	}
+}
+
+// decodeSource unmarshals a source into the Go type matching its sourceType.
+func decodeSource(data json.RawMessage) (Source, error) {
+	var kind struct {
+		SourceType  string `json:"sourceType"`
+		ContentType string `json:"contentType"`
+	}
+	if err := json.Unmarshal(data, &kind); err != nil {
+		return nil, err
+	}
+
+	var s Source
+	switch {
+	case kind.SourceType == SourceTypeHTTP:
+		s = new(HTTPSource)
+	case kind.SourceType == SourceTypeSyslog:
+		s = new(SyslogSource)
+	case kind.SourceType == SourceTypeScript:
+		s = new(ScriptSource)
+	case kind.SourceType == SourceTypePolling && kind.ContentType == ContentTypeS3:
+		s = new(S3Source)
+	case kind.SourceType == SourceTypePolling && kind.ContentType == ContentTypeCloudTrail:
+		s = new(CloudTrailSource)
+	default:
+		s = &UnknownSource{Raw: data}
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// sendSource makes a call returning a source wrapped in a sourceRequest.
+func (c *Client) sendSource(cl call, opts []CallOption) (Source, error) {
+	var sr = new(sourceRequest)
+	cl.out = sr
+	if err := c.do(cl, opts); err != nil {
+		return nil, err
+	}
+	return decodeSource(sr.Source)
+}
+
+// ListSources returns the sources on the collector.
+func (c *Client) ListSources(collectorID int, opts ...CallOption) ([]Source, error) {
+	var sl = new(sourceList)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("collectors/%d/sources", collectorID),
+		out:      sl,
+		notFound: ErrCollectorNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]Source, 0, len(sl.Sources))
+	for _, raw := range sl.Sources {
+		s, err := decodeSource(raw)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, s)
+	}
+	return sources, nil
+}
+
+// GetSource gets the source with the specified ID and its ETag, for use with UpdateSource.
+func (c *Client) GetSource(collectorID, id int, opts ...CallOption) (Source, string, error) {
+	var header http.Header
+	s, err := c.sendSource(call{
+		method:   "GET",
+		path:     fmt.Sprintf("collectors/%d/sources/%d", collectorID, id),
+		notFound: ErrSourceNotFound,
+		header:   &header,
+	}, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return s, header.Get("ETag"), nil
+}
+
+// CreateSource creates a source on the collector.
+func (c *Client) CreateSource(collectorID int, source Source, opts ...CallOption) (Source, error) {
+	raw, err := encodeSource(source)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendSource(call{
+		method:     "POST",
+		path:       fmt.Sprintf("collectors/%d/sources", collectorID),
+		in:         sourceRequest{Source: raw},
+		create:     true,
+		notFound:   ErrCollectorNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check if a source with this name `%s` already exists", source.Base().Name),
+	}, opts)
+}
+
+// UpdateSource updates an existing source. The update only succeeds if the source hasn't
+// changed since etag was read with GetSource; otherwise it returns ErrSourceModified.
+func (c *Client) UpdateSource(collectorID int, source Source, etag string, opts ...CallOption) (Source, error) {
+	raw, err := encodeSource(source)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendSource(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("collectors/%d/sources/%d", collectorID, source.Base().ID),
+		in:         sourceRequest{Source: raw},
+		notFound:   ErrSourceNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check if a source with this name `%s` already exists", source.Base().Name),
+		modified:   ErrSourceModified,
+	}, append(append([]CallOption(nil), opts...), WithHeader("If-Match", etag)))
+}
+
+// DeleteSource deletes the source with the specified ID.
+func (c *Client) DeleteSource(collectorID, id int, opts ...CallOption) error {
+	return c.do(call{
+		method:   "DELETE",
+		path:     fmt.Sprintf("collectors/%d/sources/%d", collectorID, id),
+		notFound: ErrSourceNotFound,
+	}, opts)
+}
+
+// Sources returns a ResourceClient for the sources on a collector. Update uses the ETag of
+// the source's last Get, so it returns ErrSourceModified if the source changed since;
+// sources that weren't read with Get first are updated whatever their state.
+func (c *Client) Sources(collectorID int, opts ...CallOption) ResourceClient[Source] {
+	var etags etagCache
+	return &resourceClient[Source]{
+		list: func() ([]Source, error) {
+			return c.ListSources(collectorID, opts...)
+		},
+		get: func(id string) (*Source, error) {
+			i, err := strconv.Atoi(id)
+			if err != nil {
+				return nil, err
+			}
+			s, etag, err := c.GetSource(collectorID, i, opts...)
+			if err != nil {
+				return nil, err
+			}
+			etags.remember(strconv.Itoa(i), etag)
+			return &s, nil
+		},
+		create: func(source Source) (*Source, error) {
+			s, err := c.CreateSource(collectorID, source, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return &s, nil
+		},
+		update: func(source Source) (*Source, error) {
+			etag, ok := etags.take(strconv.Itoa(source.Base().ID))
+			if !ok {
+				var err error
+				if _, etag, err = c.GetSource(collectorID, source.Base().ID, opts...); err != nil {
+					return nil, err
+				}
+			}
+			s, err := c.UpdateSource(collectorID, source, etag, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return &s, nil
+		},
+		delete: func(id string) error {
+			i, err := strconv.Atoi(id)
+			if err != nil {
+				return err
+			}
+			return c.DeleteSource(collectorID, i, opts...)
+		},
+	}
+}
@@ -0,0 +1,195 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestListSourcesDecodesTypes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected ‘GET’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/collectors/1/sources" {
+			t.Errorf("Expected request to ‘/collectors/1/sources’, got ‘%s’", r.URL.EscapedPath())
+		}
+		w.Write([]byte(`{"sources": [
+			{"id": 1, "name": "http", "sourceType": "HTTP", "messagePerRequest": true},
+			{"id": 2, "name": "syslog", "sourceType": "Syslog", "port": 514},
+			{"id": 3, "name": "script", "sourceType": "Script", "commands": ["/bin/sh"]},
+			{"id": 4, "name": "s3", "sourceType": "Polling", "contentType": "AwsS3Bucket", "scanInterval": 300000},
+			{"id": 5, "name": "trail", "sourceType": "Polling", "contentType": "AwsCloudTrailBucket"},
+			{"id": 6, "name": "docker", "sourceType": "DockerLog"}
+		]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	sources, err := c.ListSources(1)
+	if err != nil {
+		t.Errorf("ListSources() returned an error: %s", err)
+		return
+	}
+	if len(sources) != 6 {
+		t.Errorf("Expected 6 sources, got %d", len(sources))
+		return
+	}
+	if s, ok := sources[0].(*HTTPSource); !ok || !s.MessagePerRequest {
+		t.Errorf("Expected an HTTP source with messagePerRequest, got %#v", sources[0])
+	}
+	if s, ok := sources[1].(*SyslogSource); !ok || s.Port != 514 {
+		t.Errorf("Expected a syslog source on port 514, got %#v", sources[1])
+	}
+	if s, ok := sources[2].(*ScriptSource); !ok || len(s.Commands) != 1 {
+		t.Errorf("Expected a script source with a command, got %#v", sources[2])
+	}
+	if s, ok := sources[3].(*S3Source); !ok || s.ScanInterval != 300000 {
+		t.Errorf("Expected an S3 source, got %#v", sources[3])
+	}
+	if _, ok := sources[4].(*CloudTrailSource); !ok {
+		t.Errorf("Expected a CloudTrail source, got %#v", sources[4])
+	}
+	if s, ok := sources[5].(*UnknownSource); !ok || s.Name != "docker" || s.Raw == nil {
+		t.Errorf("Expected an unknown source keeping its JSON, got %#v", sources[5])
+	}
+	for i, s := range sources {
+		if s.Base().ID != i+1 {
+			t.Errorf("Expected source %d to have ID %d, got %d", i, i+1, s.Base().ID)
+		}
+	}
+}
+
+func TestCreateSourceSetsSourceType(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var sent struct {
+			Source map[string]interface{} `json:"source"`
+		}
+		json.Unmarshal(body, &sent)
+		if sent.Source["sourceType"] != SourceTypePolling || sent.Source["contentType"] != ContentTypeS3 {
+			t.Errorf("Expected an S3 polling source, got %s", body)
+		}
+		sent.Source["id"] = 7
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(sent)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	source := &S3Source{SourceBase: SourceBase{Name: "s3"}}
+	created, err := c.CreateSource(1, source)
+	if err != nil {
+		t.Errorf("CreateSource() returned an error: %s", err)
+		return
+	}
+	if s, ok := created.(*S3Source); !ok || s.ID != 7 {
+		t.Errorf("Expected the created S3 source with ID 7, got %#v", created)
+	}
+	if source.SourceType != "" {
+		t.Errorf("Expected CreateSource() not to modify its argument, got source type %q", source.SourceType)
+	}
+}
+
+func TestUpdateSourceETag(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("ETag", "etag")
+			w.Write([]byte(`{"source": {"id": 2, "name": "http", "sourceType": "HTTP"}}`))
+		case "PUT":
+			if r.URL.EscapedPath() != "/collectors/1/sources/2" {
+				t.Errorf("Expected request to ‘/collectors/1/sources/2’, got ‘%s’", r.URL.EscapedPath())
+			}
+			if r.Header.Get("If-Match") != "etag" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	source, etag, err := c.GetSource(1, 2)
+	if err != nil || etag != "etag" {
+		t.Errorf("Expected GetSource() to return the ETag, got %q and %v", etag, err)
+		return
+	}
+	source.(*HTTPSource).Description = "updated"
+
+	if _, err := c.UpdateSource(1, source, "stale"); err != ErrSourceModified {
+		t.Errorf("Expected ErrSourceModified for a stale ETag, got %v", err)
+	}
+	updated, err := c.UpdateSource(1, source, etag)
+	if err != nil {
+		t.Errorf("UpdateSource() returned an error: %s", err)
+		return
+	}
+	if updated.Base().Description != "updated" {
+		t.Errorf("Expected the updated description, got %q", updated.Base().Description)
+	}
+
+	updatedPtr, err := c.Sources(1).Update(source)
+	if err != nil || (*updatedPtr).Base().ID != 2 {
+		t.Errorf("Expected Sources().Update() to use the current ETag, got %v", err)
+	}
+}
+
+func TestSourcesResourceClientConflict(t *testing.T) {
+	var gets int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			// The source changes after every read.
+			w.Header().Set("ETag", fmt.Sprintf("etag%d", atomic.AddInt32(&gets, 1)))
+			w.Write([]byte(`{"source": {"id": 2, "name": "http", "sourceType": "HTTP"}}`))
+		case "PUT":
+			if r.Header.Get("If-Match") != fmt.Sprintf("etag%d", atomic.LoadInt32(&gets)) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	rc := c.Sources(1)
+	source, err := rc.Get("2")
+	if err != nil {
+		t.Errorf("Get() returned an error: %s", err)
+		return
+	}
+	if _, _, err := c.GetSource(1, 2); err != nil {
+		t.Errorf("GetSource() returned an error: %s", err)
+		return
+	}
+	if _, err := rc.Update(*source); err != ErrSourceModified {
+		t.Errorf("Expected ErrSourceModified for a source changed since Get, got %v", err)
+	}
+}
+
+func TestDeleteSourceNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.DeleteSource(1, 2); err != ErrSourceNotFound {
+		t.Errorf("DeleteSource() returned the wrong error: %v", err)
+	}
+}
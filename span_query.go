@@ -0,0 +1,282 @@
+package sumologic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Statuses of a row of a span query.
+const (
+	SpanQueryStatusInProgress = "InProgress"
+	SpanQueryStatusDone       = "Done"
+	SpanQueryStatusFailed     = "Failed"
+)
+
+// SpanQueryRequest is a span analytics query over the time range. Each row is a query
+// of the tracing data, e.g. service=checkout AND duration > 500ms.
+type SpanQueryRequest struct {
+	Rows      []SpanQueryRow
+	TimeRange TimeRange
+}
+
+// SpanQueryRow is one row of a span query. Rows are named A, B, C... in order when
+// RowID is empty.
+type SpanQueryRow struct {
+	RowID string `json:"rowId"`
+	Query string `json:"query"`
+}
+
+// SpanQuery is a running span analytics query, returned by StartSpanQuery. Like a search
+// job, it gathers results in the background and should be deleted once they're read.
+type SpanQuery struct {
+	ID string `json:"id"`
+
+	client *Client
+	opts   []CallOption
+}
+
+// SpanQueryStatus is the status of each row of a span query.
+type SpanQueryStatus struct {
+	Rows []SpanQueryRowStatus `json:"rowStatuses"`
+}
+
+// SpanQueryRowStatus is the status of a row of a span query.
+type SpanQueryRowStatus struct {
+	RowID         string `json:"rowId"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"statusMessage,omitempty"`
+	SpanCount     int    `json:"spanCount"`
+}
+
+// Done reports whether every row of the query has finished.
+func (s *SpanQueryStatus) Done() bool {
+	for _, row := range s.Rows {
+		if row.Status == SpanQueryStatusInProgress {
+			return false
+		}
+	}
+	return true
+}
+
+// Span is one span of a trace.
+type Span struct {
+	ID             string            `json:"id"`
+	TraceID        string            `json:"traceId"`
+	ParentID       string            `json:"parentId,omitempty"`
+	Name           string            `json:"name"`
+	Service        string            `json:"service"`
+	Kind           string            `json:"kind,omitempty"`
+	StatusCode     string            `json:"statusCode,omitempty"`
+	StartTimestamp time.Time         `json:"startTimestamp"`
+	DurationNanos  int64             `json:"durationNs"`
+	Fields         map[string]string `json:"fields,omitempty"`
+}
+
+// Duration returns how long the span took.
+func (s Span) Duration() time.Duration {
+	return time.Duration(s.DurationNanos)
+}
+
+// SpanList is one page of the spans of a row. Next is the token for the following page
+// and is empty on the last page.
+type SpanList struct {
+	Data []Span `json:"data"`
+	Next string `json:"next,omitempty"`
+}
+
+// SpanAggregates is the result of a row that aggregates spans, e.g. with a count by
+// service: the names of its columns and its rows of values.
+type SpanAggregates struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// SpanQueryError is returned when rows of a span query fail.
+type SpanQueryError struct {
+	ID     string
+	Errors []string
+}
+
+func (e *SpanQueryError) Error() string {
+	return fmt.Sprintf("span query %s failed: %s", e.ID, strings.Join(e.Errors, "; "))
+}
+
+// ErrSpanQueryNotFound is returned when a span query doesn't exist, e.g. because it
+// expired or was deleted.
+var ErrSpanQueryNotFound = errors.New("Span query not found")
+
+// spanQueryPollInterval is the delay between status checks while waiting on a span query.
+var spanQueryPollInterval = time.Second
+
+type spanQueryBody struct {
+	QueryRows []SpanQueryRow `json:"queryRows"`
+	TimeRange struct {
+		Type string                `json:"type"`
+		From metricsQueryBoundary  `json:"from"`
+		To   *metricsQueryBoundary `json:"to"`
+	} `json:"timeRange"`
+}
+
+// StartSpanQuery starts a span analytics query.
+// POST spansQuery
+func (c *Client) StartSpanQuery(sqr SpanQueryRequest, opts ...CallOption) (*SpanQuery, error) {
+	if len(sqr.Rows) == 0 {
+		return nil, fmt.Errorf("span query has no rows")
+	}
+	if err := sqr.TimeRange.Validate(); err != nil {
+		return nil, err
+	}
+	var in spanQueryBody
+	for i, row := range sqr.Rows {
+		if row.RowID == "" {
+			row.RowID = string(rune('A' + i))
+		}
+		in.QueryRows = append(in.QueryRows, row)
+	}
+	in.TimeRange.Type = "BeginBoundedTimeRange"
+	in.TimeRange.From = metricsQueryBoundary{Type: "EpochTimeRangeBoundary", EpochMillis: sqr.TimeRange.From.UnixMilli()}
+	in.TimeRange.To = &metricsQueryBoundary{Type: "EpochTimeRangeBoundary", EpochMillis: sqr.TimeRange.To.UnixMilli()}
+
+	var sq = new(SpanQuery)
+	err := c.do(call{
+		method:     "POST",
+		path:       "spansQuery",
+		in:         in,
+		out:        sq,
+		badRequest: errors.New("Bad Request. Please check the rows and time range of the span query"),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	sq.client, sq.opts = c, opts
+	return sq, nil
+}
+
+// GetSpanQueryStatus gets the status of each row of a span query.
+func (c *Client) GetSpanQueryStatus(queryID string, opts ...CallOption) (*SpanQueryStatus, error) {
+	var status = new(SpanQueryStatus)
+	if err := c.getSpanQuery(fmt.Sprintf("spansQuery/%s/status", queryID), status, opts); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetSpanQuerySpans returns one page of the spans found by a row of a span query. A
+// limit of 0 uses the API default.
+func (c *Client) GetSpanQuerySpans(queryID, rowID string, limit int, token string, opts ...CallOption) (*SpanList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := fmt.Sprintf("spansQuery/%s/rows/%s/spans", queryID, rowID)
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var sl = new(SpanList)
+	if err := c.getSpanQuery(path, sl, opts); err != nil {
+		return nil, err
+	}
+	return sl, nil
+}
+
+// GetSpanQueryAggregates returns the aggregates computed by a row of a span query.
+func (c *Client) GetSpanQueryAggregates(queryID, rowID string, opts ...CallOption) (*SpanAggregates, error) {
+	var sa = new(SpanAggregates)
+	if err := c.getSpanQuery(fmt.Sprintf("spansQuery/%s/rows/%s/aggregates", queryID, rowID), sa, opts); err != nil {
+		return nil, err
+	}
+	return sa, nil
+}
+
+// DeleteSpanQuery deletes a span query, canceling it if it's still running.
+func (c *Client) DeleteSpanQuery(queryID string, opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("spansQuery/%s", queryID), notFound: ErrSpanQueryNotFound}, opts)
+}
+
+// WaitForCompletion polls the span query every pollInterval until all of its rows are
+// done and returns their final status. It returns a *SpanQueryError if rows failed.
+func (sq *SpanQuery) WaitForCompletion(ctx context.Context, pollInterval time.Duration) (*SpanQueryStatus, error) {
+	if sq.client == nil {
+		return nil, fmt.Errorf("span query %s wasn't started by this client", sq.ID)
+	}
+	if pollInterval <= 0 {
+		pollInterval = spanQueryPollInterval
+	}
+	opts := append(append([]CallOption(nil), sq.opts...), WithContext(ctx))
+	for {
+		status, err := sq.client.GetSpanQueryStatus(sq.ID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if status.Done() {
+			var errs []string
+			for _, row := range status.Rows {
+				if row.Status == SpanQueryStatusFailed {
+					errs = append(errs, fmt.Sprintf("row %s: %s", row.RowID, row.StatusMessage))
+				}
+			}
+			if len(errs) > 0 {
+				return status, &SpanQueryError{ID: sq.ID, Errors: errs}
+			}
+			return status, nil
+		}
+		if err := sleepCallOptions(pollInterval, opts); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Spans returns one page of the spans found by a row of the query.
+func (sq *SpanQuery) Spans(rowID string, limit int, token string, opts ...CallOption) (*SpanList, error) {
+	if sq.client == nil {
+		return nil, fmt.Errorf("span query %s wasn't started by this client", sq.ID)
+	}
+	return sq.client.GetSpanQuerySpans(sq.ID, rowID, limit, token, append(append([]CallOption(nil), sq.opts...), opts...)...)
+}
+
+// AllSpans follows the pagination tokens and returns every span found by a row of the query.
+func (sq *SpanQuery) AllSpans(rowID string, opts ...CallOption) ([]Span, error) {
+	var spans []Span
+	token := ""
+	for {
+		sl, err := sq.Spans(rowID, 0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, sl.Data...)
+		if sl.Next == "" {
+			return spans, nil
+		}
+		token = sl.Next
+	}
+}
+
+// Aggregates returns the aggregates computed by a row of the query.
+func (sq *SpanQuery) Aggregates(rowID string, opts ...CallOption) (*SpanAggregates, error) {
+	if sq.client == nil {
+		return nil, fmt.Errorf("span query %s wasn't started by this client", sq.ID)
+	}
+	return sq.client.GetSpanQueryAggregates(sq.ID, rowID, append(append([]CallOption(nil), sq.opts...), opts...)...)
+}
+
+// Delete deletes the span query.
+func (sq *SpanQuery) Delete(opts ...CallOption) error {
+	if sq.client == nil {
+		return fmt.Errorf("span query %s wasn't started by this client", sq.ID)
+	}
+	return sq.client.DeleteSpanQuery(sq.ID, append(append([]CallOption(nil), sq.opts...), opts...)...)
+}
+
+// getSpanQuery gets a resource of a span query into v.
+func (c *Client) getSpanQuery(path string, v interface{}, opts []CallOption) error {
+	return c.do(call{method: "GET", path: path, out: v, notFound: ErrSpanQueryNotFound}, opts)
+}
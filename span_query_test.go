@@ -0,0 +1,99 @@
+package sumologic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSpanQuery(t *testing.T) {
+	polls := 0
+	deleted := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /spansQuery":
+			var in spanQueryBody
+			json.NewDecoder(r.Body).Decode(&in)
+			if len(in.QueryRows) != 2 || in.QueryRows[0].RowID != "A" || in.QueryRows[1].RowID != "B" {
+				t.Errorf("Expected rows A and B, got %+v", in.QueryRows)
+			}
+			w.Write([]byte(`{"id": "q1"}`))
+		case "GET /spansQuery/q1/status":
+			polls++
+			status := SpanQueryStatusInProgress
+			if polls > 1 {
+				status = SpanQueryStatusDone
+			}
+			json.NewEncoder(w).Encode(SpanQueryStatus{Rows: []SpanQueryRowStatus{{RowID: "A", Status: status}, {RowID: "B", Status: status}}})
+		case "GET /spansQuery/q1/rows/A/spans":
+			if r.URL.Query().Get("token") == "" {
+				w.Write([]byte(`{"data": [{"id": "s1", "traceId": "t1", "service": "checkout", "startTimestamp": "2026-10-01T12:00:00Z", "durationNs": 1500000}], "next": "page2"}`))
+				return
+			}
+			w.Write([]byte(`{"data": [{"id": "s2", "traceId": "t1", "parentId": "s1", "service": "payments"}]}`))
+		case "GET /spansQuery/q1/rows/B/aggregates":
+			w.Write([]byte(`{"columns": ["service", "count"], "rows": [["checkout", 12]]}`))
+		case "DELETE /spansQuery/q1":
+			deleted = true
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	from := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	sq, err := c.StartSpanQuery(SpanQueryRequest{
+		Rows:      []SpanQueryRow{{Query: "service=checkout"}, {Query: "* | count by service"}},
+		TimeRange: TimeRange{From: from, To: from.Add(time.Hour)},
+	})
+	if err != nil {
+		t.Errorf("StartSpanQuery() returned an error: %s", err)
+		return
+	}
+	if _, err := sq.WaitForCompletion(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("WaitForCompletion() returned an error: %s", err)
+		return
+	}
+	spans, err := sq.AllSpans("A")
+	if err != nil {
+		t.Errorf("AllSpans() returned an error: %s", err)
+		return
+	}
+	if len(spans) != 2 || spans[1].ParentID != "s1" || spans[0].Duration() != 1500*time.Microsecond {
+		t.Errorf("Expected spans s1 and s2, got %+v", spans)
+	}
+	aggregates, err := sq.Aggregates("B")
+	if err != nil {
+		t.Errorf("Aggregates() returned an error: %s", err)
+		return
+	}
+	if len(aggregates.Rows) != 1 || aggregates.Rows[0][0] != "checkout" {
+		t.Errorf("Expected the count by service, got %+v", aggregates)
+	}
+	sq.Delete()
+	if !deleted {
+		t.Errorf("Expected the span query to be deleted")
+	}
+}
+
+func TestSpanQueryFailedRows(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.Write([]byte(`{"id": "q1"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(SpanQueryStatus{Rows: []SpanQueryRowStatus{{RowID: "A", Status: SpanQueryStatusFailed, StatusMessage: "unknown field"}}})
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	sq, _ := c.StartSpanQuery(SpanQueryRequest{Rows: []SpanQueryRow{{Query: "bogus="}}, TimeRange: LastTimeRange(time.Hour, nil)})
+	_, err := sq.WaitForCompletion(context.Background(), time.Millisecond)
+	if qe, ok := err.(*SpanQueryError); !ok || qe.Errors[0] != "row A: unknown field" {
+		t.Errorf("Expected a *SpanQueryError for row A, got %v", err)
+	}
+}
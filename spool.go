@@ -0,0 +1,173 @@
+package sumologic
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// MessageSpool accumulates search result messages, keeping them in memory until their
+// JSON encoded size reaches MaxMemoryBytes and spilling the rest to a temporary file, so
+// big searches don't exhaust the memory of small containers. Close removes the file.
+type MessageSpool struct {
+	// MaxMemoryBytes caps the size of the messages kept in memory. Zero means no cap.
+	MaxMemoryBytes int64
+	// Dir is where the spill file is created, the default temporary directory when empty.
+	Dir string
+
+	memory      []*SearchJobResultMessage
+	memoryBytes int64
+	file        *os.File
+	w           *bufio.Writer
+	spilled     int
+}
+
+// NewMessageSpool returns a spool keeping up to maxMemoryBytes of messages in memory.
+func NewMessageSpool(maxMemoryBytes int64) *MessageSpool {
+	return &MessageSpool{MaxMemoryBytes: maxMemoryBytes}
+}
+
+// Add appends messages to the spool.
+func (s *MessageSpool) Add(messages ...*SearchJobResultMessage) error {
+	for _, m := range messages {
+		b, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		if s.file == nil {
+			if s.MaxMemoryBytes <= 0 || s.memoryBytes+int64(len(b)) <= s.MaxMemoryBytes {
+				s.memory = append(s.memory, m)
+				s.memoryBytes += int64(len(b))
+				continue
+			}
+			if err := s.spill(); err != nil {
+				return err
+			}
+		}
+		if _, err := s.w.Write(append(b, '\n')); err != nil {
+			return err
+		}
+		s.spilled++
+	}
+	return nil
+}
+
+func (s *MessageSpool) spill() error {
+	f, err := ioutil.TempFile(s.Dir, "sumologic-spool-*.jsonl")
+	if err != nil {
+		return err
+	}
+	s.file = f
+	s.w = bufio.NewWriter(f)
+	return nil
+}
+
+// Len returns the number of messages in the spool.
+func (s *MessageSpool) Len() int {
+	return len(s.memory) + s.spilled
+}
+
+// Spilled returns the number of messages written to disk.
+func (s *MessageSpool) Spilled() int {
+	return s.spilled
+}
+
+// Iterator returns a MessageIterator over the spooled messages in the order they were
+// added. Messages added afterwards aren't included.
+func (s *MessageSpool) Iterator() (MessageIterator, error) {
+	it := &spoolIterator{memory: s.memory, pos: -1}
+	if s.file == nil {
+		return it, nil
+	}
+	if err := s.w.Flush(); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(s.file.Name())
+	if err != nil {
+		return nil, err
+	}
+	it.file = f
+	it.dec = json.NewDecoder(io.LimitReader(f, s.size()))
+	return it, nil
+}
+
+func (s *MessageSpool) size() int64 {
+	info, err := s.file.Stat()
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// Close removes the spill file.
+func (s *MessageSpool) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	s.file.Close()
+	err := os.Remove(s.file.Name())
+	s.file, s.w, s.memory, s.spilled = nil, nil, nil, 0
+	return err
+}
+
+// spoolIterator reads the messages kept in memory, then those in the spill file.
+type spoolIterator struct {
+	memory []*SearchJobResultMessage
+	pos    int
+	file   *os.File
+	dec    *json.Decoder
+	msg    *SearchJobResultMessage
+	err    error
+}
+
+func (it *spoolIterator) Next() bool {
+	if it.pos+1 < len(it.memory) {
+		it.pos++
+		it.msg = it.memory[it.pos]
+		return true
+	}
+	if it.dec == nil || it.err != nil {
+		return false
+	}
+	var m = new(SearchJobResultMessage)
+	if err := it.dec.Decode(m); err != nil {
+		if err != io.EOF {
+			it.err = err
+		}
+		it.file.Close()
+		it.dec = nil
+		return false
+	}
+	it.msg = m
+	return true
+}
+
+func (it *spoolIterator) Message() *SearchJobResultMessage {
+	return it.msg
+}
+
+func (it *spoolIterator) Err() error {
+	return it.err
+}
+
+// CollectMessages pages through all of the search job's messages, waiting for the job to
+// finish, into a spool that keeps up to maxMemoryBytes of them in memory and spills the
+// rest to disk. Close the spool once done with it. The search job must have been
+// returned by StartSearchJob.
+func (sj *SearchJob) CollectMessages(pageSize int, maxMemoryBytes int64, opts ...CallOption) (*MessageSpool, error) {
+	spool := NewMessageSpool(maxMemoryBytes)
+	it := sj.MessagesIterator(pageSize, opts...)
+	for it.Next() {
+		if err := spool.Add(it.Message()); err != nil {
+			spool.Close()
+			return nil, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		spool.Close()
+		return nil, err
+	}
+	return spool, nil
+}
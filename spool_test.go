@@ -0,0 +1,65 @@
+package sumologic
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMessageSpool(t *testing.T) {
+	dir := t.TempDir()
+	spool := NewMessageSpool(120)
+	spool.Dir = dir
+	messages := sinkMessages()
+	messages = append(messages, sinkMessages()...)
+	if err := spool.Add(messages...); err != nil {
+		t.Errorf("Add returned error: %v", err)
+		return
+	}
+	if spool.Len() != len(messages) {
+		t.Errorf("Expected %d messages, got %d", len(messages), spool.Len())
+	}
+	if spool.Spilled() == 0 || spool.Spilled() == len(messages) {
+		t.Errorf("Expected some messages to be spilled, got %d", spool.Spilled())
+	}
+
+	it, err := spool.Iterator()
+	if err != nil {
+		t.Errorf("Iterator returned error: %v", err)
+		return
+	}
+	var got []map[string]interface{}
+	for it.Next() {
+		got = append(got, it.Message().Map)
+	}
+	if it.Err() != nil {
+		t.Errorf("Iterator returned error: %v", it.Err())
+	}
+	var expected []map[string]interface{}
+	for _, m := range messages {
+		expected = append(expected, m.Map)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if err := spool.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("Expected the spill file to be removed, found %d files", len(entries))
+	}
+}
+
+func TestMessageSpoolUncapped(t *testing.T) {
+	spool := NewMessageSpool(0)
+	defer spool.Close()
+	if err := spool.Add(sinkMessages()...); err != nil {
+		t.Errorf("Add returned error: %v", err)
+		return
+	}
+	if spool.Spilled() != 0 {
+		t.Errorf("Expected no messages to be spilled, got %d", spool.Spilled())
+	}
+}
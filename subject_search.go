@@ -0,0 +1,136 @@
+package sumologic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SubjectSearchRequest describes a search for the data held about one subject,
+// e.g. for a privacy request.
+type SubjectSearchRequest struct {
+	// Identifier is searched for as a phrase, e.g. an email address or user ID.
+	Identifier string
+	// Indexes are the partitions and scheduled views to search.
+	Indexes  []string
+	From     string
+	To       string
+	TimeZone string
+
+	// Concurrency is the number of indexes searched at once, 2 by default.
+	Concurrency int
+	// PollInterval is the delay between search status checks, 5 seconds by default.
+	PollInterval time.Duration
+}
+
+// SubjectIndexReport describes where a subject's data appears in one index.
+type SubjectIndexReport struct {
+	Index            string
+	Messages         int
+	SourceCategories map[string]int
+	FirstSeen        time.Time
+	LastSeen         time.Time
+	// Err is set when the index couldn't be searched.
+	Err error
+}
+
+// SubjectDataReport lists, per index, where a subject's data appears.
+type SubjectDataReport struct {
+	Identifier string
+	Indexes    []SubjectIndexReport
+}
+
+// Found returns the reports of the indexes holding data about the subject.
+func (r *SubjectDataReport) Found() []SubjectIndexReport {
+	var found []SubjectIndexReport
+	for _, ir := range r.Indexes {
+		if ir.Messages > 0 {
+			found = append(found, ir)
+		}
+	}
+	return found
+}
+
+// FindSubjectData searches every requested index for the identifier and reports where it
+// appears. An index that fails to search is reported with its error rather than failing the
+// whole report, since partial answers still need to be followed up.
+func (c *Client) FindSubjectData(ssr SubjectSearchRequest, opts ...CallOption) (*SubjectDataReport, error) {
+	if ssr.Identifier == "" {
+		return nil, fmt.Errorf("subject search needs an identifier")
+	}
+	concurrency := ssr.Concurrency
+	if concurrency <= 0 {
+		concurrency = 2
+	}
+	pollInterval := ssr.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	report := &SubjectDataReport{
+		Identifier: ssr.Identifier,
+		Indexes:    make([]SubjectIndexReport, len(ssr.Indexes)),
+	}
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, index := range ssr.Indexes {
+		wg.Add(1)
+		go func(i int, index string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			messages, err := c.searchMessages(StartSearchRequest{
+				Query:    fmt.Sprintf("_index=%s %s", index, quoteSearchPhrase(ssr.Identifier)),
+				From:     ssr.From,
+				To:       ssr.To,
+				TimeZone: ssr.TimeZone,
+			}, pollInterval, opts...)
+			ir := SubjectIndexReport{Index: index, Err: err}
+			if err == nil {
+				summarizeSubjectMessages(&ir, messages)
+			}
+			report.Indexes[i] = ir
+		}(i, index)
+	}
+	wg.Wait()
+
+	sort.Stable(bySubjectIndex(report.Indexes))
+	return report, nil
+}
+
+func summarizeSubjectMessages(ir *SubjectIndexReport, messages []*SearchJobResultMessage) {
+	ir.Messages = len(messages)
+	ir.SourceCategories = make(map[string]int)
+	for _, m := range messages {
+		ir.SourceCategories[messageField(m, "_sourcecategory")]++
+
+		ms, err := strconv.ParseInt(messageField(m, "_messagetime"), 10, 64)
+		if err != nil {
+			continue
+		}
+		t := time.Unix(0, ms*int64(time.Millisecond)).UTC()
+		if ir.FirstSeen.IsZero() || t.Before(ir.FirstSeen) {
+			ir.FirstSeen = t
+		}
+		if t.After(ir.LastSeen) {
+			ir.LastSeen = t
+		}
+	}
+}
+
+// quoteSearchPhrase quotes s as a phrase for a search query.
+func quoteSearchPhrase(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + s + `"`
+}
+
+type bySubjectIndex []SubjectIndexReport
+
+func (s bySubjectIndex) Len() int           { return len(s) }
+func (s bySubjectIndex) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s bySubjectIndex) Less(i, j int) bool { return s[i].Messages > s[j].Messages }
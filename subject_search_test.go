@@ -0,0 +1,91 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFindSubjectData(t *testing.T) {
+	var mu sync.Mutex
+	queries := make(map[string]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		path := r.URL.EscapedPath()
+		switch {
+		case r.Method == "POST" && path == "/search/jobs":
+			body, _ := ioutil.ReadAll(r.Body)
+			var ssr StartSearchRequest
+			json.Unmarshal(body, &ssr)
+			id := strings.Fields(strings.TrimPrefix(ssr.Query, "_index="))[0]
+			queries[id] = ssr.Query
+			if id == "broken" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code": "searchjob.invalid", "message": "no such index"}`))
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+			body, _ = json.Marshal(SearchJob{ID: id})
+			w.Write(body)
+		case strings.HasSuffix(path, "/messages"):
+			w.WriteHeader(http.StatusOK)
+			var messages []*SearchJobResultMessage
+			if strings.Contains(path, "/app/") {
+				messages = []*SearchJobResultMessage{
+					{Map: map[string]interface{}{"_sourcecategory": "prod/app", "_messagetime": "1488326400000"}},
+					{Map: map[string]interface{}{"_sourcecategory": "prod/app", "_messagetime": "1488412800000"}},
+				}
+			}
+			body, _ := json.Marshal(SearchJobResult{Messages: messages})
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusOK)
+			count := 0
+			if strings.HasSuffix(path, "/app") {
+				count = 2
+			}
+			body, _ := json.Marshal(SearchJobStatusResponse{State: "DONE GATHERING RESULTS", MessageCount: count})
+			w.Write(body)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	report, err := c.FindSubjectData(SubjectSearchRequest{
+		Identifier:   `jane"doe@example.com`,
+		Indexes:      []string{"audit", "app", "broken"},
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Errorf("FindSubjectData() returned an error: %s", err)
+		return
+	}
+
+	if q := queries["app"]; q != `_index=app "jane\"doe@example.com"` {
+		t.Errorf("Unexpected query %s", q)
+	}
+	found := report.Found()
+	if len(found) != 1 || found[0].Index != "app" || found[0].SourceCategories["prod/app"] != 2 {
+		t.Errorf("Expected the subject to be found in app, got %v", found)
+		return
+	}
+	if !found[0].FirstSeen.Equal(time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)) || !found[0].LastSeen.Equal(time.Date(2017, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected first and last seen %s and %s", found[0].FirstSeen, found[0].LastSeen)
+	}
+	for _, ir := range report.Indexes {
+		if ir.Index == "broken" && ir.Err == nil {
+			t.Errorf("Expected the broken index to be reported with its error")
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package sumologic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// TemplateParams are the per-service parameters a template is rendered with.
+type TemplateParams map[string]interface{}
+
+// Template is a content definition, such as a dashboard, saved search or monitor,
+// written as JSON with text/template actions. Rendering it with a service's parameters
+// and decoding the result gives that service's definition. Besides the text/template
+// builtins, templates can call:
+//
+//	json      the argument as a JSON value, e.g. "title": {{json .service}}
+//	required  fails the render when the parameter is missing or empty: {{required "service" .service}}
+//	default   the parameter, or a fallback when it's missing or empty: {{default "5m" .interval}}
+//	lower, upper, trim, replace, join
+//
+// Guard parameters every service must set with required; a missing parameter is nil.
+type Template[T any] struct {
+	tmpl *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"required": func(name string, v interface{}) (interface{}, error) {
+		if isEmptyParam(v) {
+			return nil, fmt.Errorf("parameter `%s` is required", name)
+		}
+		return v, nil
+	},
+	"default": func(fallback, v interface{}) interface{} {
+		if isEmptyParam(v) {
+			return fallback
+		}
+		return v
+	},
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"trim":    strings.TrimSpace,
+	"replace": func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"join":    func(sep string, elems []string) string { return strings.Join(elems, sep) },
+}
+
+func isEmptyParam(v interface{}) bool {
+	return v == nil || v == ""
+}
+
+// ParseTemplate parses a template of a T definition.
+func ParseTemplate[T any](name, text string) (*Template[T], error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template[T]{tmpl: tmpl}, nil
+}
+
+// MustParseTemplate is like ParseTemplate but panics if the template can't be parsed.
+func MustParseTemplate[T any](name, text string) *Template[T] {
+	t, err := ParseTemplate[T](name, text)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Render renders the template with the parameters and decodes the result.
+func (t *Template[T]) Render(params TemplateParams) (*T, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, map[string]interface{}(params)); err != nil {
+		return nil, err
+	}
+	var v = new(T)
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		return nil, fmt.Errorf("template `%s` didn't render valid JSON: %s", t.tmpl.Name(), err)
+	}
+	if v == nil {
+		return nil, fmt.Errorf("template `%s` rendered null", t.tmpl.Name())
+	}
+	return v, nil
+}
+
+// ContentBundle is the standard content of one service.
+type ContentBundle struct {
+	Dashboards    []Dashboard
+	SavedSearches []LegacySavedSearch
+	Monitors      []Monitor
+}
+
+// BundleTemplate renders a ContentBundle for each service from one set of templates.
+type BundleTemplate struct {
+	Dashboards    []*Template[Dashboard]
+	SavedSearches []*Template[LegacySavedSearch]
+	Monitors      []*Template[Monitor]
+}
+
+// Render renders every template with the parameters.
+func (bt BundleTemplate) Render(params TemplateParams) (*ContentBundle, error) {
+	b := new(ContentBundle)
+	for _, t := range bt.Dashboards {
+		d, err := t.Render(params)
+		if err != nil {
+			return nil, err
+		}
+		b.Dashboards = append(b.Dashboards, *d)
+	}
+	for _, t := range bt.SavedSearches {
+		s, err := t.Render(params)
+		if err != nil {
+			return nil, err
+		}
+		b.SavedSearches = append(b.SavedSearches, *s)
+	}
+	for _, t := range bt.Monitors {
+		m, err := t.Render(params)
+		if err != nil {
+			return nil, err
+		}
+		b.Monitors = append(b.Monitors, *m)
+	}
+	return b, nil
+}
+
+// RenderServices renders a bundle for each service from its parameters, keyed by service.
+// The service name is available to the templates as .service unless the parameters set it.
+func (bt BundleTemplate) RenderServices(services map[string]TemplateParams) (map[string]*ContentBundle, error) {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bundles := make(map[string]*ContentBundle, len(services))
+	for _, name := range names {
+		params := TemplateParams{"service": name}
+		for k, v := range services[name] {
+			params[k] = v
+		}
+		b, err := bt.Render(params)
+		if err != nil {
+			return nil, fmt.Errorf("service `%s`: %s", name, err)
+		}
+		bundles[name] = b
+	}
+	return bundles, nil
+}
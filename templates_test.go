@@ -0,0 +1,92 @@
+package sumologic
+
+import (
+	"strings"
+	"testing"
+)
+
+var serviceDashboardTemplate = MustParseTemplate[Dashboard]("dashboard", `{
+	"title": {{json (printf "%s overview" (required "service" .service))}},
+	"panels": [{
+		"key": "errors",
+		"title": "Errors",
+		"panelType": "SumoSearchPanel",
+		"queries": [{
+			"queryKey": "A",
+			"queryType": "Logs",
+			"queryString": {{json (printf "_sourceCategory=%s/%s error | timeslice %s | count by _timeslice" (default "prod" .env) (lower .service) (default "1m" .interval))}}
+		}]
+	}],
+	"layout": {"layoutType": "Grid", "layoutStructures": []}
+}`)
+
+var serviceSearchTemplate = MustParseTemplate[LegacySavedSearch]("search", `{
+	"type": "SavedSearchWithScheduleSyncDefinition",
+	"name": {{json (printf "%s errors" .service)}},
+	"search": {"queryText": {{json (printf "_sourceCategory=%s error" (join " OR " (required "categories" .categories)))}}, "defaultTimeRange": "-15m"}
+}`)
+
+func TestTemplateRender(t *testing.T) {
+	d, err := serviceDashboardTemplate.Render(TemplateParams{"service": `Check"out`, "interval": "5m"})
+	if err != nil {
+		t.Errorf("Render() returned an error: %s", err)
+		return
+	}
+	if d.Title != `Check"out overview` {
+		t.Errorf("Expected the service name to be JSON escaped in the title, got %q", d.Title)
+	}
+	if q := d.Panels[0].Queries[0].QueryString; q != `_sourceCategory=prod/check"out error | timeslice 5m | count by _timeslice` {
+		t.Errorf("Unexpected query %q", q)
+	}
+
+	if _, err := serviceDashboardTemplate.Render(TemplateParams{}); err == nil || !strings.Contains(err.Error(), "parameter `service` is required") {
+		t.Errorf("Expected a missing required parameter to fail the render, got %v", err)
+	}
+}
+
+func TestTemplateInvalidJSON(t *testing.T) {
+	tmpl := MustParseTemplate[Dashboard]("broken", `{"title": {{.service}}}`)
+	if _, err := tmpl.Render(TemplateParams{"service": "checkout"}); err == nil {
+		t.Errorf("Expected an error for a template rendering invalid JSON")
+	}
+}
+
+func TestTemplateRendersNull(t *testing.T) {
+	tmpl := MustParseTemplate[Dashboard]("optional", `{{if .enabled}}{"title": "overview"}{{else}}null{{end}}`)
+	if d, err := tmpl.Render(TemplateParams{}); err == nil || !strings.Contains(err.Error(), "template `optional` rendered null") {
+		t.Errorf("Expected a null render to fail naming the template, got %+v, %v", d, err)
+	}
+	bt := BundleTemplate{Dashboards: []*Template[Dashboard]{tmpl}}
+	if _, err := bt.Render(TemplateParams{}); err == nil {
+		t.Errorf("Expected a bundle with a null render to fail")
+	}
+}
+
+func TestBundleTemplateRenderServices(t *testing.T) {
+	bt := BundleTemplate{
+		Dashboards:    []*Template[Dashboard]{serviceDashboardTemplate},
+		SavedSearches: []*Template[LegacySavedSearch]{serviceSearchTemplate},
+	}
+	bundles, err := bt.RenderServices(map[string]TemplateParams{
+		"checkout": {"categories": []string{"checkout/api", "checkout/worker"}},
+		"payments": {"categories": []string{"payments"}, "env": "staging"},
+	})
+	if err != nil {
+		t.Errorf("RenderServices() returned an error: %s", err)
+		return
+	}
+	if len(bundles) != 2 {
+		t.Errorf("Expected 2 bundles, got %d", len(bundles))
+		return
+	}
+	if title := bundles["payments"].Dashboards[0].Title; title != "payments overview" {
+		t.Errorf("Expected the service name to be passed to templates, got %q", title)
+	}
+	if q := bundles["checkout"].SavedSearches[0].Search.QueryText; q != "_sourceCategory=checkout/api OR checkout/worker error" {
+		t.Errorf("Unexpected saved search query %q", q)
+	}
+
+	if _, err := bt.RenderServices(map[string]TemplateParams{"broken": {}}); err == nil || !strings.Contains(err.Error(), "service `broken`") {
+		t.Errorf("Expected the failing service to be named in the error, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package sumologic
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxSearchTimeRange is the longest time range TimeRange.Validate accepts. Longer ranges
+// have to be split into several searches.
+const MaxSearchTimeRange = 366 * 24 * time.Hour
+
+var (
+	// ErrTimeRangeOrder is returned when the start of a time range isn't before its end.
+	ErrTimeRangeOrder = errors.New("Time range start must be before its end")
+	// ErrTimeRangeTooLong is returned when a time range is longer than MaxSearchTimeRange.
+	ErrTimeRangeTooLong = errors.New("Time range is longer than the API allows")
+)
+
+// TimeRange is the time range of a search, formatted in the time zone of Location. With
+// ISO 8601 offsets in From and To the range means the same instant wherever the API runs,
+// and TimeZone, set to Location, only affects how timestamps of the results are shown.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+	// Location is the time zone of the search, UTC when nil. It must be a named location,
+	// e.g. from time.LoadLocation("Europe/Berlin"), because the API takes its name.
+	Location *time.Location
+}
+
+// LastTimeRange returns the time range covering the window of time up to now, in loc.
+func LastTimeRange(window time.Duration, loc *time.Location) TimeRange {
+	now := time.Now()
+	return TimeRange{From: now.Add(-window), To: now, Location: loc}
+}
+
+// Validate checks that the range starts before it ends, isn't longer than
+// MaxSearchTimeRange and has a named time zone.
+func (tr TimeRange) Validate() error {
+	if !tr.From.Before(tr.To) {
+		return ErrTimeRangeOrder
+	}
+	if tr.To.Sub(tr.From) > MaxSearchTimeRange {
+		return ErrTimeRangeTooLong
+	}
+	if tr.location().String() == "Local" {
+		return errors.New("Time range location must be a named time zone such as America/New_York, not Local")
+	}
+	return nil
+}
+
+// FromString returns the start of the range in ISO 8601 format with the offset of its time zone.
+func (tr TimeRange) FromString() string {
+	return tr.From.In(tr.location()).Format(time.RFC3339)
+}
+
+// ToString returns the end of the range in ISO 8601 format with the offset of its time zone.
+func (tr TimeRange) ToString() string {
+	return tr.To.In(tr.location()).Format(time.RFC3339)
+}
+
+// TimeZone returns the name of the range's time zone.
+func (tr TimeRange) TimeZone() string {
+	return tr.location().String()
+}
+
+func (tr TimeRange) location() *time.Location {
+	if tr.Location == nil {
+		return time.UTC
+	}
+	return tr.Location
+}
+
+// SetTimeRange validates the time range and sets From, To and TimeZone of the request from it.
+func (ssr *StartSearchRequest) SetTimeRange(tr TimeRange) error {
+	if err := tr.Validate(); err != nil {
+		return err
+	}
+	ssr.From = tr.FromString()
+	ssr.To = tr.ToString()
+	ssr.TimeZone = tr.TimeZone()
+	return nil
+}
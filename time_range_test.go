@@ -0,0 +1,44 @@
+package sumologic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartSearchRequestSetTimeRange(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %s", err)
+	}
+	from := time.Date(2021, time.July, 1, 12, 0, 0, 0, time.UTC)
+	tr := TimeRange{From: from, To: from.Add(time.Hour), Location: loc}
+
+	var ssr StartSearchRequest
+	if err := ssr.SetTimeRange(tr); err != nil {
+		t.Errorf("SetTimeRange() returned an error: %s", err)
+		return
+	}
+	if ssr.From != "2021-07-01T08:00:00-04:00" || ssr.To != "2021-07-01T09:00:00-04:00" {
+		t.Errorf("Expected the range with the New York offset, got %s to %s", ssr.From, ssr.To)
+	}
+	if ssr.TimeZone != "America/New_York" {
+		t.Errorf("Expected time zone America/New_York, got %s", ssr.TimeZone)
+	}
+}
+
+func TestTimeRangeValidate(t *testing.T) {
+	now := time.Now()
+	if err := (TimeRange{From: now, To: now}).Validate(); err != ErrTimeRangeOrder {
+		t.Errorf("Expected ErrTimeRangeOrder for an empty range, got %v", err)
+	}
+	if err := (TimeRange{From: now.Add(-MaxSearchTimeRange - time.Hour), To: now}).Validate(); err != ErrTimeRangeTooLong {
+		t.Errorf("Expected ErrTimeRangeTooLong, got %v", err)
+	}
+	if err := (TimeRange{From: now.Add(-time.Hour), To: now, Location: time.Local}).Validate(); err == nil {
+		t.Errorf("Expected an error for the Local time zone")
+	}
+	tr := LastTimeRange(15*time.Minute, nil)
+	if err := tr.Validate(); err != nil || tr.TimeZone() != "UTC" {
+		t.Errorf("Expected a valid UTC range, got %v in %s", err, tr.TimeZone())
+	}
+}
@@ -0,0 +1,190 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Token types.
+const (
+	TokenTypeCollectorRegistration = "CollectorRegistration"
+)
+
+// Token statuses.
+const (
+	TokenStatusActive   = "Active"
+	TokenStatusInactive = "Inactive"
+)
+
+// Token is an installation token, used in place of an access key to register collectors.
+// EncodedTokenAndURL is the value to pass to the collector installer.
+type Token struct {
+	ID                 string `json:"id,omitempty"`
+	Name               string `json:"name"`
+	Description        string `json:"description,omitempty"`
+	Status             string `json:"status,omitempty"`
+	Type               string `json:"type"`
+	EncodedTokenAndURL string `json:"encodedTokenAndUrl,omitempty"`
+	CreatedAt          string `json:"createdAt,omitempty"`
+	CreatedBy          string `json:"createdBy,omitempty"`
+	ModifiedAt         string `json:"modifiedAt,omitempty"`
+	ModifiedBy         string `json:"modifiedBy,omitempty"`
+}
+
+// TokenList is one page of tokens. Next is the token for the following page and is empty
+// on the last page.
+type TokenList struct {
+	Data []Token `json:"data"`
+	Next string  `json:"next,omitempty"`
+}
+
+// ErrTokenNotFound is returned when a token doesn't exist.
+var ErrTokenNotFound = errors.New("Token not found")
+
+// ListTokens returns one page of tokens. A limit of 0 uses the API default.
+func (c *Client) ListTokens(limit int, token string, opts ...CallOption) (*TokenList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "tokens"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var tl = new(TokenList)
+	if err := c.do(call{method: "GET", path: path, out: tl}, opts); err != nil {
+		return nil, err
+	}
+	return tl, nil
+}
+
+// ListAllTokens follows the pagination tokens and returns every token.
+func (c *Client) ListAllTokens(opts ...CallOption) ([]Token, error) {
+	var tokens []Token
+	next := ""
+	for {
+		tl, err := c.ListTokens(0, next, opts...)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tl.Data...)
+		if tl.Next == "" {
+			return tokens, nil
+		}
+		next = tl.Next
+	}
+}
+
+// GetToken gets the token with the specified ID.
+func (c *Client) GetToken(id string, opts ...CallOption) (*Token, error) {
+	var t = new(Token)
+	err := c.do(call{
+		method:   "GET",
+		path:     fmt.Sprintf("tokens/%s", id),
+		out:      t,
+		notFound: ErrTokenNotFound,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CreateToken creates a token. Type defaults to CollectorRegistration.
+func (c *Client) CreateToken(token Token, opts ...CallOption) (*Token, error) {
+	if token.Type == "" {
+		token.Type = TokenTypeCollectorRegistration
+	}
+	var t = new(Token)
+	err := c.do(call{
+		method:     "POST",
+		path:       "tokens",
+		in:         token,
+		out:        t,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if a token with this name `%s` already exists", token.Name),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UpdateToken updates the name, description and status of a token. Setting its status to
+// Inactive stops new collectors registering with it; collectors already registered with it
+// keep working.
+func (c *Client) UpdateToken(token Token, opts ...CallOption) (*Token, error) {
+	if token.Type == "" {
+		token.Type = TokenTypeCollectorRegistration
+	}
+	id := token.ID
+	token.ID, token.EncodedTokenAndURL = "", ""
+	var t = new(Token)
+	err := c.do(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("tokens/%s", id),
+		in:         token,
+		out:        t,
+		notFound:   ErrTokenNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for token `%s`", id),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeleteToken deletes the token with the specified ID.
+func (c *Client) DeleteToken(id string, opts ...CallOption) error {
+	return c.do(call{method: "DELETE", path: fmt.Sprintf("tokens/%s", id), notFound: ErrTokenNotFound}, opts)
+}
+
+// RotateToken creates a token with the name and description of the token with the
+// specified ID and deactivates the old one, so automation can swap in the new token
+// before deleting the old one. The new token gets the name suffix, as names are unique.
+func (c *Client) RotateToken(id, suffix string, opts ...CallOption) (*Token, error) {
+	old, err := c.GetToken(id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	t, err := c.CreateToken(Token{
+		Name:        old.Name + suffix,
+		Description: old.Description,
+		Type:        old.Type,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	old.Status = TokenStatusInactive
+	if _, err := c.UpdateToken(*old, opts...); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// Tokens returns a ResourceClient for installation tokens.
+func (c *Client) Tokens(opts ...CallOption) ResourceClient[Token] {
+	return &resourceClient[Token]{
+		list: func() ([]Token, error) {
+			return c.ListAllTokens(opts...)
+		},
+		get: func(id string) (*Token, error) {
+			return c.GetToken(id, opts...)
+		},
+		create: func(t Token) (*Token, error) {
+			return c.CreateToken(t, opts...)
+		},
+		update: func(t Token) (*Token, error) {
+			return c.UpdateToken(t, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteToken(id, opts...)
+		},
+	}
+}
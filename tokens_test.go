@@ -0,0 +1,93 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.EscapedPath() != "/tokens" {
+			t.Errorf("Expected request to ‘/tokens’, got ‘%s’", r.URL.EscapedPath())
+		}
+		var tok Token
+		json.NewDecoder(r.Body).Decode(&tok)
+		if tok.Type != TokenTypeCollectorRegistration {
+			t.Errorf("Expected the type to default to CollectorRegistration, got ‘%s’", tok.Type)
+		}
+		tok.ID, tok.Status, tok.EncodedTokenAndURL = "tok1", TokenStatusActive, "encoded"
+		json.NewEncoder(w).Encode(tok)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	tok, err := c.CreateToken(Token{Name: "deploy"})
+	if err != nil {
+		t.Errorf("CreateToken() returned an error: %s", err)
+		return
+	}
+	if tok.ID != "tok1" || tok.EncodedTokenAndURL != "encoded" {
+		t.Errorf("Expected the created token, got %+v", tok)
+	}
+}
+
+func TestGetTokenNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if _, err := c.GetToken("missing"); err != ErrTokenNotFound {
+		t.Errorf("Expected ErrTokenNotFound, got %v", err)
+	}
+}
+
+func TestRotateToken(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		var tok Token
+		json.NewDecoder(r.Body).Decode(&tok)
+		switch r.Method {
+		case "GET":
+			tok = Token{ID: "old", Name: "deploy", Type: TokenTypeCollectorRegistration, Status: TokenStatusActive}
+		case "POST":
+			if tok.Name != "deploy-2" {
+				t.Errorf("Expected the new token to be named ‘deploy-2’, got ‘%s’", tok.Name)
+			}
+			tok.ID = "new"
+		case "PUT":
+			if tok.Status != TokenStatusInactive || tok.ID != "" {
+				t.Errorf("Expected the old token to be deactivated, got %+v", tok)
+			}
+		}
+		json.NewEncoder(w).Encode(tok)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	tok, err := c.RotateToken("old", "-2")
+	if err != nil {
+		t.Errorf("RotateToken() returned an error: %s", err)
+		return
+	}
+	if tok.ID != "new" {
+		t.Errorf("Expected the new token, got ‘%s’", tok.ID)
+	}
+	expected := []string{"GET /tokens/old", "POST /tokens", "PUT /tokens/old"}
+	if len(requests) != len(expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+		return
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("Expected requests %v, got %v", expected, requests)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package sumologic
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// UndoLog records the changes a multi-step operation makes, such as onboarding a service
+// or a bulk update, so they can be reverted when a later step fails. Changes are undone
+// in the reverse order they were made. It's safe for concurrent use.
+type UndoLog struct {
+	mu    sync.Mutex
+	steps []UndoStep
+}
+
+// UndoStep is one recorded change and the function that reverts it.
+type UndoStep struct {
+	Description string
+	Undo        func() error
+}
+
+// UndoError is returned by Undo when some changes couldn't be reverted. Failed holds
+// those steps, with the error of each in Errors, so they can be retried or fixed by hand.
+type UndoError struct {
+	Failed []UndoStep
+	Errors []error
+}
+
+func (e *UndoError) Error() string {
+	msgs := make([]string, len(e.Failed))
+	for i, step := range e.Failed {
+		msgs[i] = fmt.Sprintf("%s: %s", step.Description, e.Errors[i])
+	}
+	return fmt.Sprintf("%d changes couldn't be undone: %s", len(e.Failed), strings.Join(msgs, "; "))
+}
+
+// Record adds a change and the function that reverts it.
+func (l *UndoLog) Record(description string, undo func() error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.steps = append(l.steps, UndoStep{Description: description, Undo: undo})
+}
+
+// Steps returns the recorded changes in the order they were made.
+func (l *UndoLog) Steps() []UndoStep {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]UndoStep(nil), l.steps...)
+}
+
+// Undo reverts the recorded changes, most recent first, and clears the log. Every change
+// is attempted even when an earlier one fails; the failures are returned as an *UndoError.
+func (l *UndoLog) Undo() error {
+	l.mu.Lock()
+	steps := l.steps
+	l.steps = nil
+	l.mu.Unlock()
+
+	var ue UndoError
+	for i := len(steps) - 1; i >= 0; i-- {
+		if err := steps[i].Undo(); err != nil {
+			ue.Failed = append(ue.Failed, steps[i])
+			ue.Errors = append(ue.Errors, err)
+		}
+	}
+	if len(ue.Failed) > 0 {
+		return &ue
+	}
+	return nil
+}
+
+// CreateUndoable creates the resource with rc and records its deletion in the log.
+// id returns the ID of the created resource.
+func CreateUndoable[T any](l *UndoLog, kind string, rc ResourceClient[T], resource T, id func(T) string) (*T, error) {
+	created, err := rc.Create(resource)
+	if err != nil {
+		return nil, err
+	}
+	createdID := id(*created)
+	l.Record(fmt.Sprintf("delete created %s %s", kind, createdID), func() error {
+		return rc.Delete(createdID)
+	})
+	return created, nil
+}
+
+// UpdateUndoable updates the resource with rc and records restoring the version it
+// replaced, which is read first.
+func UpdateUndoable[T any](l *UndoLog, kind string, rc ResourceClient[T], resource T, id func(T) string) (*T, error) {
+	resourceID := id(resource)
+	previous, err := rc.Get(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := rc.Update(resource)
+	if err != nil {
+		return nil, err
+	}
+	l.Record(fmt.Sprintf("restore updated %s %s", kind, resourceID), func() error {
+		_, err := rc.Update(*previous)
+		return err
+	})
+	return updated, nil
+}
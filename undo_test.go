@@ -0,0 +1,99 @@
+package sumologic
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// memoryCollectors is a ResourceClient keeping collectors in a map.
+func memoryCollectors(store map[int]Collector) ResourceClient[Collector] {
+	next := 100
+	return &resourceClient[Collector]{
+		get: func(id string) (*Collector, error) {
+			i, _ := strconv.Atoi(id)
+			c, ok := store[i]
+			if !ok {
+				return nil, ErrCollectorNotFound
+			}
+			return &c, nil
+		},
+		create: func(c Collector) (*Collector, error) {
+			next++
+			c.ID = next
+			store[c.ID] = c
+			return &c, nil
+		},
+		update: func(c Collector) (*Collector, error) {
+			store[c.ID] = c
+			return &c, nil
+		},
+		delete: func(id string) error {
+			i, _ := strconv.Atoi(id)
+			if _, ok := store[i]; !ok {
+				return ErrCollectorNotFound
+			}
+			delete(store, i)
+			return nil
+		},
+	}
+}
+
+func collectorID(c Collector) string {
+	return strconv.Itoa(c.ID)
+}
+
+func TestUndoLog(t *testing.T) {
+	store := map[int]Collector{1: {ID: 1, Name: "existing"}}
+	rc := memoryCollectors(store)
+
+	var log UndoLog
+	created, err := CreateUndoable(&log, "collector", rc, Collector{Name: "new"}, collectorID)
+	if err != nil {
+		t.Errorf("CreateUndoable() returned an error: %s", err)
+		return
+	}
+	if _, err := UpdateUndoable(&log, "collector", rc, Collector{ID: 1, Name: "renamed"}, collectorID); err != nil {
+		t.Errorf("UpdateUndoable() returned an error: %s", err)
+		return
+	}
+	if len(log.Steps()) != 2 || store[1].Name != "renamed" {
+		t.Errorf("Expected 2 recorded steps and the update applied, got %+v", log.Steps())
+		return
+	}
+
+	if err := log.Undo(); err != nil {
+		t.Errorf("Undo() returned an error: %s", err)
+	}
+	if _, ok := store[created.ID]; ok {
+		t.Errorf("Expected the created collector to be deleted")
+	}
+	if store[1].Name != "existing" {
+		t.Errorf("Expected the updated collector to be restored, got %q", store[1].Name)
+	}
+	if len(log.Steps()) != 0 {
+		t.Errorf("Expected Undo() to clear the log")
+	}
+}
+
+func TestUndoLogContinuesAfterFailure(t *testing.T) {
+	var log UndoLog
+	var order []string
+	log.Record("first", func() error {
+		order = append(order, "first")
+		return nil
+	})
+	log.Record("second", func() error {
+		order = append(order, "second")
+		return errors.New("boom")
+	})
+
+	err := log.Undo()
+	var ue *UndoError
+	if !errors.As(err, &ue) || len(ue.Failed) != 1 || ue.Failed[0].Description != "second" {
+		t.Errorf("Expected an *UndoError for the second step, got %v", err)
+	}
+	if len(order) != 2 || order[0] != "second" {
+		t.Errorf("Expected every step to be undone most recent first, got %v", order)
+	}
+}
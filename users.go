@@ -0,0 +1,228 @@
+package sumologic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// User is a user of the organization.
+type User struct {
+	ID                 string   `json:"id,omitempty"`
+	FirstName          string   `json:"firstName"`
+	LastName           string   `json:"lastName"`
+	Email              string   `json:"email"`
+	RoleIDs            []string `json:"roleIds"`
+	IsActive           bool     `json:"isActive,omitempty"`
+	IsLocked           bool     `json:"isLocked,omitempty"`
+	IsMFAEnabled       bool     `json:"isMfaEnabled,omitempty"`
+	LastLoginTimestamp string   `json:"lastLoginTimestamp,omitempty"`
+	CreatedAt          string   `json:"createdAt,omitempty"`
+	CreatedBy          string   `json:"createdBy,omitempty"`
+	ModifiedAt         string   `json:"modifiedAt,omitempty"`
+	ModifiedBy         string   `json:"modifiedBy,omitempty"`
+}
+
+// UserList is one page of users. Next is the token for the following page and is empty
+// on the last page.
+type UserList struct {
+	Data []User `json:"data"`
+	Next string `json:"next,omitempty"`
+}
+
+// ListUsersFilter narrows and orders the users returned by ListUsers. Email matches
+// exactly; SortBy is one of firstName, lastName or email.
+type ListUsersFilter struct {
+	Email  string
+	SortBy string
+}
+
+// CreateUserRequest creates a user, who is sent an email to activate their account.
+type CreateUserRequest struct {
+	FirstName string   `json:"firstName"`
+	LastName  string   `json:"lastName"`
+	Email     string   `json:"email"`
+	RoleIDs   []string `json:"roleIds"`
+}
+
+// UpdateUserRequest holds the mutable settings of a user.
+type UpdateUserRequest struct {
+	FirstName string   `json:"firstName"`
+	LastName  string   `json:"lastName"`
+	IsActive  bool     `json:"isActive"`
+	RoleIDs   []string `json:"roleIds"`
+}
+
+// ErrUserNotFound is returned when a user doesn't exist.
+var ErrUserNotFound = errors.New("User not found")
+
+// ListUsers returns one page of users matching the filter. A limit of 0 uses the API default.
+func (c *Client) ListUsers(filter ListUsersFilter, limit int, token string, opts ...CallOption) (*UserList, error) {
+	q := url.Values{}
+	if filter.Email != "" {
+		q.Set("email", filter.Email)
+	}
+	if filter.SortBy != "" {
+		q.Set("sortBy", filter.SortBy)
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if token != "" {
+		q.Set("token", token)
+	}
+
+	path := "users"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var ul = new(UserList)
+	err := c.do(call{
+		method:     "GET",
+		path:       path,
+		out:        ul,
+		badRequest: errors.New("Bad Request. Please check the user filter is valid"),
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ul, nil
+}
+
+// ListAllUsers follows the pagination tokens and returns every user matching the filter.
+func (c *Client) ListAllUsers(filter ListUsersFilter, opts ...CallOption) ([]User, error) {
+	var users []User
+	token := ""
+	for {
+		ul, err := c.ListUsers(filter, 0, token, opts...)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, ul.Data...)
+		if ul.Next == "" {
+			return users, nil
+		}
+		token = ul.Next
+	}
+}
+
+// GetUser gets the user with the specified ID.
+func (c *Client) GetUser(id string, opts ...CallOption) (*User, error) {
+	return c.sendUser(call{method: "GET", path: fmt.Sprintf("users/%s", id)}, opts)
+}
+
+// CreateUser creates a user.
+func (c *Client) CreateUser(cur CreateUserRequest, opts ...CallOption) (*User, error) {
+	return c.sendUser(call{
+		method:     "POST",
+		path:       "users",
+		in:         cur,
+		create:     true,
+		badRequest: fmt.Errorf("Bad Request. Please check if a user with this email `%s` already exists", cur.Email),
+	}, opts)
+}
+
+// UpdateUser updates the user with the specified ID.
+func (c *Client) UpdateUser(id string, uur UpdateUserRequest, opts ...CallOption) (*User, error) {
+	return c.sendUser(call{
+		method:     "PUT",
+		path:       fmt.Sprintf("users/%s", id),
+		in:         uur,
+		badRequest: fmt.Errorf("Bad Request. Please check the settings for user `%s`", id),
+	}, opts)
+}
+
+// DeleteUser deletes the user with the specified ID. The content of the user is
+// transferred to the user with the transferTo ID, or deleted when transferTo is empty.
+func (c *Client) DeleteUser(id, transferTo string, opts ...CallOption) error {
+	path := fmt.Sprintf("users/%s", id)
+	if transferTo != "" {
+		q := url.Values{}
+		q.Set("transferTo", transferTo)
+		path += "?" + q.Encode()
+	}
+	return c.userAction("DELETE", path, nil, opts)
+}
+
+// RequestUserEmailChange asks the user with the specified ID to confirm changing their
+// email to email. The change takes effect once they follow the link sent to the new address.
+func (c *Client) RequestUserEmailChange(id, email string, opts ...CallOption) error {
+	return c.userAction("POST", fmt.Sprintf("users/%s/email/requestChange", id), struct {
+		Email string `json:"email"`
+	}{email}, opts)
+}
+
+// ResetUserPassword sends the user with the specified ID an email to reset their password.
+func (c *Client) ResetUserPassword(id string, opts ...CallOption) error {
+	return c.userAction("POST", fmt.Sprintf("users/%s/password/reset", id), nil, opts)
+}
+
+// UnlockUser unlocks the user with the specified ID after too many failed logins.
+func (c *Client) UnlockUser(id string, opts ...CallOption) error {
+	return c.userAction("POST", fmt.Sprintf("users/%s/unlock", id), nil, opts)
+}
+
+// DisableUserMFA disables multi-factor authentication for the user with the specified
+// ID, who sets it up again at their next login. The API requires the user's email and
+// password. There is no API to enable it for a user; require it with the account's
+// security policy instead.
+func (c *Client) DisableUserMFA(id, email, password string, opts ...CallOption) error {
+	return c.userAction("PUT", fmt.Sprintf("users/%s/mfa/disable", id), struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}{email, password}, opts)
+}
+
+// Users returns a ResourceClient for users. Deleting a user through it deletes their content.
+func (c *Client) Users(opts ...CallOption) ResourceClient[User] {
+	return &resourceClient[User]{
+		list: func() ([]User, error) {
+			return c.ListAllUsers(ListUsersFilter{}, opts...)
+		},
+		get: func(id string) (*User, error) {
+			return c.GetUser(id, opts...)
+		},
+		create: func(u User) (*User, error) {
+			return c.CreateUser(CreateUserRequest{
+				FirstName: u.FirstName,
+				LastName:  u.LastName,
+				Email:     u.Email,
+				RoleIDs:   u.RoleIDs,
+			}, opts...)
+		},
+		update: func(u User) (*User, error) {
+			return c.UpdateUser(u.ID, UpdateUserRequest{
+				FirstName: u.FirstName,
+				LastName:  u.LastName,
+				IsActive:  u.IsActive,
+				RoleIDs:   u.RoleIDs,
+			}, opts...)
+		},
+		delete: func(id string) error {
+			return c.DeleteUser(id, "", opts...)
+		},
+	}
+}
+
+// userAction sends a request that has no response body.
+func (c *Client) userAction(method, path string, in interface{}, opts []CallOption) error {
+	return c.do(call{
+		method:     method,
+		path:       path,
+		in:         in,
+		notFound:   ErrUserNotFound,
+		badRequest: fmt.Errorf("Bad Request. Please check the request to `%s` is valid", path),
+	}, opts)
+}
+
+// sendUser makes a call returning a user.
+func (c *Client) sendUser(cl call, opts []CallOption) (*User, error) {
+	var u = new(User)
+	cl.out = u
+	cl.notFound = ErrUserNotFound
+	if err := c.do(cl, opts); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
@@ -0,0 +1,132 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListAllUsersFilters(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/users" {
+			t.Errorf("Expected request to ‘/users’, got ‘%s’", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("email") != "jo@example.com" || r.URL.Query().Get("sortBy") != "lastName" {
+			t.Errorf("Expected the filter in the query, got ‘%s’", r.URL.RawQuery)
+		}
+		var ul UserList
+		switch r.URL.Query().Get("token") {
+		case "":
+			ul = UserList{Data: []User{{ID: "1", Email: "jo@example.com"}}, Next: "page2"}
+		case "page2":
+			ul = UserList{Data: []User{{ID: "2", Email: "jo@example.com"}}}
+		default:
+			t.Errorf("Unexpected token ‘%s’", r.URL.Query().Get("token"))
+		}
+		json.NewEncoder(w).Encode(ul)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	users, err := c.ListAllUsers(ListUsersFilter{Email: "jo@example.com", SortBy: "lastName"})
+	if err != nil {
+		t.Errorf("ListAllUsers() returned an error: %s", err)
+		return
+	}
+	if len(users) != 2 {
+		t.Errorf("ListAllUsers() expected 2 users, got %d", len(users))
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected ‘POST’ request, got ‘%s’", r.Method)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var u User
+		if err := json.Unmarshal(body, &u); err != nil {
+			t.Errorf("Unable to unmarshal User, got `%s`", body)
+		}
+		u.ID = "u1"
+		json.NewEncoder(w).Encode(u)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	u, err := c.CreateUser(CreateUserRequest{FirstName: "Jo", LastName: "Doe", Email: "jo@example.com", RoleIDs: []string{"r1"}})
+	if err != nil {
+		t.Errorf("CreateUser() returned an error: %s", err)
+		return
+	}
+	if u.ID != "u1" || u.Email != "jo@example.com" {
+		t.Errorf("Expected the created user, got %+v", u)
+	}
+}
+
+func TestDeleteUserTransfersContent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected ‘DELETE’ request, got ‘%s’", r.Method)
+		}
+		if r.URL.Query().Get("transferTo") != "u2" {
+			t.Errorf("Expected transferTo ‘u2’, got ‘%s’", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.DeleteUser("u1", "u2"); err != nil {
+		t.Errorf("DeleteUser() returned an error: %s", err)
+	}
+}
+
+func TestUserActions(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		if r.URL.EscapedPath() == "/users/missing/unlock" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient("accessToken", ts.URL)
+	if err := c.RequestUserEmailChange("u1", "new@example.com"); err != nil {
+		t.Errorf("RequestUserEmailChange() returned an error: %s", err)
+	}
+	if err := c.ResetUserPassword("u1"); err != nil {
+		t.Errorf("ResetUserPassword() returned an error: %s", err)
+	}
+	if err := c.UnlockUser("u1"); err != nil {
+		t.Errorf("UnlockUser() returned an error: %s", err)
+	}
+	if err := c.DisableUserMFA("u1", "jo@example.com", "secret"); err != nil {
+		t.Errorf("DisableUserMFA() returned an error: %s", err)
+	}
+	if err := c.UnlockUser("missing"); err != ErrUserNotFound {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+
+	expected := []string{
+		"POST /users/u1/email/requestChange",
+		"POST /users/u1/password/reset",
+		"POST /users/u1/unlock",
+		"PUT /users/u1/mfa/disable",
+		"POST /users/missing/unlock",
+	}
+	if len(paths) != len(expected) {
+		t.Errorf("Expected requests %v, got %v", expected, paths)
+		return
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("Expected request ‘%s’, got ‘%s’", expected[i], paths[i])
+		}
+	}
+}
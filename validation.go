@@ -0,0 +1,149 @@
+package sumologic
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldError is one field-level problem reported by the API for a create or update call.
+// FieldPath is the JSON path of the field, e.g. `schedule.startTime`, and may be empty when
+// the problem isn't tied to a field.
+type FieldError struct {
+	FieldPath string
+	Code      string
+	Message   string
+}
+
+// ValidationError is returned by create and update calls when the API rejects the request
+// as invalid.
+type ValidationError struct {
+	RequestID string
+	Errors    []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var msgs []string
+	for _, fe := range e.Errors {
+		msg := fe.Message
+		if fe.FieldPath != "" {
+			msg = fmt.Sprintf("%s: %s", fe.FieldPath, msg)
+		}
+		msgs = append(msgs, msg)
+	}
+	return "Validation failed with Sumo Logic: " + strings.Join(msgs, "; ")
+}
+
+// ForField returns the errors reported for the field with the given JSON path.
+func (e *ValidationError) ForField(path string) []FieldError {
+	var errs []FieldError
+	for _, fe := range e.Errors {
+		if fe.FieldPath == path {
+			errs = append(errs, fe)
+		}
+	}
+	return errs
+}
+
+// apiErrorBody covers both error formats of the API: a list of errors with metadata and
+// the single code and message of the older collector endpoints.
+type apiErrorBody struct {
+	ID      string `json:"id"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field"`
+	Errors  []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+		Meta    struct {
+			Field string `json:"field"`
+			Path  string `json:"path"`
+		} `json:"meta"`
+	} `json:"errors"`
+}
+
+// validationError decodes a 400 response body into a *ValidationError, or returns fallback
+// when the body doesn't hold any errors.
+func validationError(body []byte, fallback error) error {
+	var aeb apiErrorBody
+	if err := json.Unmarshal(body, &aeb); err != nil {
+		return fallback
+	}
+
+	ve := &ValidationError{RequestID: aeb.ID}
+	for _, e := range aeb.Errors {
+		fe := FieldError{
+			FieldPath: e.Meta.Field,
+			Code:      e.Code,
+			Message:   e.Message,
+		}
+		if fe.FieldPath == "" {
+			fe.FieldPath = e.Meta.Path
+		}
+		if fe.Message == "" {
+			fe.Message = e.Detail
+		}
+		ve.Errors = append(ve.Errors, fe)
+	}
+	if len(ve.Errors) == 0 && (aeb.Code != "" || aeb.Message != "") {
+		ve.Errors = append(ve.Errors, FieldError{
+			FieldPath: aeb.Field,
+			Code:      aeb.Code,
+			Message:   aeb.Message,
+		})
+	}
+	if len(ve.Errors) == 0 {
+		return fallback
+	}
+	return ve
+}
+
+// StructFieldPath maps the JSON path of a FieldError onto the Go field names of v, e.g.
+// `schedule.startTime` on a MutingSchedule becomes `Schedule.StartTime`. Array indexes
+// such as `panels[2].title` are kept. It returns false when the path doesn't match v.
+func StructFieldPath(v interface{}, jsonPath string) (string, bool) {
+	t := reflect.TypeOf(v)
+	var goPath []string
+	for _, segment := range strings.Split(jsonPath, ".") {
+		index := ""
+		if i := strings.Index(segment, "["); i >= 0 {
+			segment, index = segment[:i], segment[i:]
+		}
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return "", false
+		}
+		f, ok := fieldByJSONName(t, segment)
+		if !ok {
+			return "", false
+		}
+		goPath = append(goPath, f.Name+index)
+
+		t = f.Type
+		for n := strings.Count(index, "["); n > 0; n-- {
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+				return "", false
+			}
+			t = t.Elem()
+		}
+	}
+	return strings.Join(goPath, "."), true
+}
+
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == name || (tag == "" && strings.EqualFold(f.Name, name)) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,89 @@
+package sumologic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateConnectionValidationError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"id": "IUUQI-DGH5I-TJ045",
+			"errors": [
+				{"code": "connection:invalid_url", "message": "URL must use https", "meta": {"field": "url"}},
+				{"code": "connection:invalid_header", "message": "Header name is required", "meta": {"field": "headers[0].name"}},
+				{"code": "connection:limit", "message": "Too many connections"}
+			]
+		}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.CreateConnection(Connection{Name: "webhook"})
+	ve, ok := err.(*ValidationError)
+	if !ok {
+		t.Errorf("CreateConnection() returned the wrong error: %v", err)
+		return
+	}
+	if ve.RequestID != "IUUQI-DGH5I-TJ045" || len(ve.Errors) != 3 {
+		t.Errorf("Unexpected validation error %v", ve)
+	}
+	if fe := ve.ForField("url"); len(fe) != 1 || fe[0].Code != "connection:invalid_url" {
+		t.Errorf("Expected one error for url, got %v", fe)
+	}
+	if path, ok := StructFieldPath(Connection{}, ve.Errors[1].FieldPath); !ok || path != "Headers[0].Name" {
+		t.Errorf("Expected Headers[0].Name, got %s", path)
+	}
+}
+
+func TestCreateHostedCollectorLegacyValidationError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status": 400, "id": "ABC", "code": "collectors.validation.name.duplicate", "message": "A collector with this name already exists."}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("accessToken", ts.URL)
+	if err != nil {
+		t.Errorf("NewClient() returned an error: %s", err)
+		return
+	}
+
+	_, err = c.CreateHostedCollector(Collector{Name: "test"})
+	ve, ok := err.(*ValidationError)
+	if !ok {
+		t.Errorf("CreateHostedCollector() returned the wrong error: %v", err)
+		return
+	}
+	if len(ve.Errors) != 1 || ve.Errors[0].Code != "collectors.validation.name.duplicate" {
+		t.Errorf("Unexpected validation error %v", ve)
+	}
+}
+
+func TestValidationErrorFallback(t *testing.T) {
+	fallback := validationError([]byte(``), ErrClientAuthenticationError)
+	if fallback != ErrClientAuthenticationError {
+		t.Errorf("Expected the fallback error for an empty body, got %v", fallback)
+	}
+}
+
+func TestStructFieldPath(t *testing.T) {
+	for jsonPath, expected := range map[string]string{
+		"schedule.startTime":    "Schedule.StartTime",
+		"monitor.ids[1]":        "Monitor.IDs[1]",
+		"name":                  "Name",
+		"schedule.doesNotExist": "",
+	} {
+		path, ok := StructFieldPath(&MutingSchedule{}, jsonPath)
+		if path != expected || ok != (expected != "") {
+			t.Errorf("StructFieldPath(%s) expected %s, got %s", jsonPath, expected, path)
+		}
+	}
+}